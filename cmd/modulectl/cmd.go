@@ -2,13 +2,24 @@ package modulectl
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/mandelsoft/vfs/pkg/memoryfs"
 	"github.com/mandelsoft/vfs/pkg/osfs"
 	"github.com/spf13/cobra"
 
 	createcmd "github.com/kyma-project/modulectl/cmd/modulectl/create"
+	listcmd "github.com/kyma-project/modulectl/cmd/modulectl/list"
+	pullcmd "github.com/kyma-project/modulectl/cmd/modulectl/pull"
+	pushcmd "github.com/kyma-project/modulectl/cmd/modulectl/push"
+	releasemetacmd "github.com/kyma-project/modulectl/cmd/modulectl/releasemeta"
 	scaffoldcmd "github.com/kyma-project/modulectl/cmd/modulectl/scaffold"
+	schemacmd "github.com/kyma-project/modulectl/cmd/modulectl/schema"
+	validatecmd "github.com/kyma-project/modulectl/cmd/modulectl/validate"
+	verifycmd "github.com/kyma-project/modulectl/cmd/modulectl/verify"
 	"github.com/kyma-project/modulectl/cmd/modulectl/version"
 	"github.com/kyma-project/modulectl/internal/service/componentarchive"
 	"github.com/kyma-project/modulectl/internal/service/componentconstructor"
@@ -22,14 +33,28 @@ import (
 	"github.com/kyma-project/modulectl/internal/service/filegenerator/reusefilegenerator"
 	"github.com/kyma-project/modulectl/internal/service/fileresolver"
 	"github.com/kyma-project/modulectl/internal/service/git"
+	"github.com/kyma-project/modulectl/internal/service/imagedigest"
+	"github.com/kyma-project/modulectl/internal/service/list"
 	"github.com/kyma-project/modulectl/internal/service/manifestparser"
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer"
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer/helm"
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer/kustomize"
 	moduleconfiggenerator "github.com/kyma-project/modulectl/internal/service/moduleconfig/generator"
 	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/pull"
+	"github.com/kyma-project/modulectl/internal/service/push"
 	"github.com/kyma-project/modulectl/internal/service/registry"
+	"github.com/kyma-project/modulectl/internal/service/releasemeta"
 	"github.com/kyma-project/modulectl/internal/service/scaffold"
+	"github.com/kyma-project/modulectl/internal/service/schema"
+	"github.com/kyma-project/modulectl/internal/service/signature"
 	"github.com/kyma-project/modulectl/internal/service/templategenerator"
+	"github.com/kyma-project/modulectl/internal/service/validate"
 	"github.com/kyma-project/modulectl/internal/service/verifier"
+	"github.com/kyma-project/modulectl/internal/service/verify"
 	"github.com/kyma-project/modulectl/tools/filesystem"
+	iotools "github.com/kyma-project/modulectl/tools/io"
 	"github.com/kyma-project/modulectl/tools/ocirepo"
 	"github.com/kyma-project/modulectl/tools/yaml"
 
@@ -41,6 +66,9 @@ const (
 	manifestKind       = "manifest"
 	defaultCRKind      = "defaultcr"
 	securityConfigKind = "security-config"
+
+	// debugEnvVar enables the debug output, e.g. the source of the registry credentials, if set to true.
+	debugEnvVar = "MODULECTL_DEBUG"
 )
 
 //go:embed use.txt
@@ -79,6 +107,71 @@ func NewCmd() (*cobra.Command, error) {
 		return nil, fmt.Errorf("failed to build create command: %w", err)
 	}
 
+	validateService, err := buildValidateService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build validate service: %w", err)
+	}
+
+	validateCmd, err := validatecmd.NewCmd(validateService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build validate command: %w", err)
+	}
+
+	releaseMetaService, err := buildReleaseMetaService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build release-meta service: %w", err)
+	}
+
+	releaseMetaCmd, err := releasemetacmd.NewCmd(releaseMetaService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build release-meta command: %w", err)
+	}
+
+	pullService, err := buildPullService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pull service: %w", err)
+	}
+
+	pullCmd, err := pullcmd.NewCmd(pullService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pull command: %w", err)
+	}
+
+	pushService, err := buildPushService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build push service: %w", err)
+	}
+
+	pushCmd, err := pushcmd.NewCmd(pushService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build push command: %w", err)
+	}
+
+	verifyService, err := buildVerifyService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build verify service: %w", err)
+	}
+
+	verifyCmd, err := verifycmd.NewCmd(verifyService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build verify command: %w", err)
+	}
+
+	listService, err := buildListService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list service: %w", err)
+	}
+
+	listCmd, err := listcmd.NewCmd(listService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list command: %w", err)
+	}
+
+	schemaCmd, err := schemacmd.NewCmd(schema.NewService())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build schema command: %w", err)
+	}
+
 	versionCmd, err := version.NewCmd()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build version command: %w", err)
@@ -86,6 +179,13 @@ func NewCmd() (*cobra.Command, error) {
 
 	rootCmd.AddCommand(scaffoldCmd)
 	rootCmd.AddCommand(createCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(releaseMetaCmd)
+	rootCmd.AddCommand(pushCmd)
+	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	return rootCmd, nil
@@ -109,6 +209,11 @@ func buildModuleService() (*create.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create default CR file resolver: %w", err)
 	}
+	manifestRenderer, err := manifestrenderer.NewService("kyma-module-manifest-*.yaml", helm.NewRenderer(),
+		kustomize.NewBuilder(), tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest renderer: %w", err)
+	}
 
 	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil)
 	if err != nil {
@@ -119,6 +224,10 @@ func buildModuleService() (*create.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create git sources service: %w", err)
 	}
+	securityConfigService, err := componentdescriptor.NewSecurityConfigService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security config service: %w", err)
+	}
 
 	componentConstructorService := componentconstructor.NewService()
 
@@ -139,11 +248,17 @@ func buildModuleService() (*create.Service, error) {
 
 	imageVersionVerifierService := verifier.NewService(manifestParser)
 
+	credentialResolver := credential.NewResolver(newDebugOut())
 	ociRepo := &ocirepo.OCIRepo{}
-	registryService, err := registry.NewService(ociRepo, nil, credential.ResolveCredentials)
+	registryService, err := registry.NewService(ociRepo, nil, credentialResolver.ResolveCredentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create registry service: %w", err)
 	}
+	imageDigestService, err := imagedigest.NewService(credentialResolver.ResolveCredentials,
+		remote.WithTransport(credentialResolver.Transport(remote.DefaultTransport)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image digest service: %w", err)
+	}
 	moduleTemplateService, err := templategenerator.NewService(fileSystemUtil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module template service: %w", err)
@@ -152,17 +267,179 @@ func buildModuleService() (*create.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create crd parser service: %w", err)
 	}
-	moduleService, err := create.NewService(moduleConfigService, gitSourcesService,
+	provenanceService, err := provenance.NewService(version.Version, gitService, fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provenance service: %w", err)
+	}
+	signatureService, err := signature.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature service: %w", err)
+	}
+	moduleService, err := create.NewService(moduleConfigService, gitSourcesService, securityConfigService,
 		componentConstructorService, componentArchiveService, registryService,
 		moduleTemplateService,
 		crdParserService, moduleResourceService, imageVersionVerifierService, manifestService, manifestFileResolver,
-		defaultCRFileResolver, fileSystemUtil)
+		defaultCRFileResolver, manifestRenderer, imageDigestService, credentialResolver, provenanceService,
+		signatureService, fileSystemUtil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create module service: %w", err)
 	}
 	return moduleService, nil
 }
 
+func newDebugOut() iotools.Out {
+	if enabled, _ := strconv.ParseBool(os.Getenv(debugEnvVar)); enabled {
+		return iotools.NewDefaultOut(os.Stderr)
+	}
+	return iotools.NewDefaultOut(io.Discard)
+}
+
+func buildValidateService() (*validate.Service, error) {
+	fileSystemUtil := &filesystem.Helper{}
+	tmpFileSystem := filesystem.NewTempFileSystem()
+
+	manifestFileResolver, err := fileresolver.NewFileResolver("kyma-module-manifest-*.yaml", tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest file resolver: %w", err)
+	}
+	defaultCRFileResolver, err := fileresolver.NewFileResolver("kyma-module-default-cr-*.yaml", tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create default CR file resolver: %w", err)
+	}
+	manifestRenderer, err := manifestrenderer.NewService("kyma-module-manifest-*.yaml", helm.NewRenderer(),
+		kustomize.NewBuilder(), tmpFileSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest renderer: %w", err)
+	}
+
+	manifestParser := manifestparser.NewService()
+	manifestService, err := contentprovider.NewManifest(manifestParser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create manifest content provider: %w", err)
+	}
+
+	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module config service: %w", err)
+	}
+
+	imageVersionVerifierService := verifier.NewService(manifestParser)
+
+	crdParserService, err := crdparser.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crd parser service: %w", err)
+	}
+
+	securityConfigService, err := componentdescriptor.NewSecurityConfigService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security config service: %w", err)
+	}
+
+	validateService, err := validate.NewService(moduleConfigService, manifestService, imageVersionVerifierService,
+		crdParserService, securityConfigService, manifestFileResolver, defaultCRFileResolver, manifestRenderer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validate service: %w", err)
+	}
+	return validateService, nil
+}
+
+func buildReleaseMetaService() (*releasemeta.Service, error) {
+	fileSystemUtil := &filesystem.Helper{}
+
+	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module config service: %w", err)
+	}
+
+	releaseMetaService, err := releasemeta.NewService(moduleConfigService, fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create release-meta service: %w", err)
+	}
+	return releaseMetaService, nil
+}
+
+func buildPullService() (*pull.Service, error) {
+	fileSystemUtil := &filesystem.Helper{}
+
+	credentialResolver := credential.NewResolver(newDebugOut())
+	registryService, err := registry.NewService(&ocirepo.OCIRepo{}, nil, credentialResolver.ResolveCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry service: %w", err)
+	}
+	moduleConfigService, err := moduleconfigreader.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module config service: %w", err)
+	}
+	moduleTemplateService, err := templategenerator.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create module template service: %w", err)
+	}
+	crdParserService, err := crdparser.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crd parser service: %w", err)
+	}
+
+	pullService, err := pull.NewService(registryService, credentialResolver, moduleConfigService,
+		moduleTemplateService, crdParserService, fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull service: %w", err)
+	}
+	return pullService, nil
+}
+
+func buildPushService() (*push.Service, error) {
+	credentialResolver := credential.NewResolver(newDebugOut())
+	registryService, err := registry.NewService(&ocirepo.OCIRepo{}, nil, credentialResolver.ResolveCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry service: %w", err)
+	}
+
+	signatureService, err := signature.NewService(&filesystem.Helper{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature service: %w", err)
+	}
+
+	pushService, err := push.NewService(registryService, credentialResolver, signatureService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push service: %w", err)
+	}
+	return pushService, nil
+}
+
+func buildVerifyService() (*verify.Service, error) {
+	fileSystemUtil := &filesystem.Helper{}
+
+	credentialResolver := credential.NewResolver(newDebugOut())
+	registryService, err := registry.NewService(&ocirepo.OCIRepo{}, nil, credentialResolver.ResolveCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry service: %w", err)
+	}
+	signatureService, err := signature.NewService(fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signature service: %w", err)
+	}
+
+	verifyService, err := verify.NewService(registryService, credentialResolver, signatureService, fileSystemUtil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create verify service: %w", err)
+	}
+	return verifyService, nil
+}
+
+func buildListService() (*list.Service, error) {
+	credentialResolver := credential.NewResolver(newDebugOut())
+	registryService, err := registry.NewService(&ocirepo.OCIRepo{}, nil, credentialResolver.ResolveCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registry service: %w", err)
+	}
+
+	listService, err := list.NewService(registryService, credentialResolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list service: %w", err)
+	}
+	return listService, nil
+}
+
 func buildScaffoldService() (*scaffold.Service, error) {
 	fileSystemUtil := &filesystem.Helper{}
 	yamlConverter := &yaml.ObjectToYAMLConverter{}
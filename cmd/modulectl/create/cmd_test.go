@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	createcmd "github.com/kyma-project/modulectl/cmd/modulectl/create"
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/create"
 	"github.com/kyma-project/modulectl/internal/testutils"
 )
@@ -59,6 +61,22 @@ func Test_Execute_ParsesAllModuleOptions(t *testing.T) {
 		"--output", templateOutput,
 		"--registry", registryURL,
 		"--registry-credentials", credentials,
+		"--registry-credentials-file", "credentials",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+		"--registry-targets-file", "targets.yaml",
+		"--allow-unknown-fields",
+		"--pin-digests",
+		"--reproducible",
+		"--provenance-output", "provenance.json",
+		"--output-archive", "archive.tgz",
+		"--copy-resources",
+		"--signing-key", "private.pem",
+		"--signing-certificate", "chain.pem",
+		"--signature-name", "release",
+		"--reserved-key-prefix", "example.com/=warning,provenance.kyma-project.io/",
 	}
 
 	svc := &moduleServiceStub{}
@@ -72,9 +90,25 @@ func Test_Execute_ParsesAllModuleOptions(t *testing.T) {
 
 	assert.Equal(t, moduleConfigFile, svc.opts.ConfigFile)
 	assert.Equal(t, credentials, svc.opts.Credentials)
+	assert.Equal(t, "credentials", svc.opts.CredentialsFile)
+	assert.Equal(t, "token", svc.opts.RegistryTokenFile)
+	assert.Equal(t, "ca.crt", svc.opts.RegistryCAFile)
+	assert.Equal(t, "client.crt", svc.opts.RegistryClientCert)
+	assert.Equal(t, "client.key", svc.opts.RegistryClientKey)
+	assert.Equal(t, "targets.yaml", svc.opts.RegistryTargetsFile)
 	assert.Equal(t, insecureFlagSet, svc.opts.Insecure)
 	assert.Equal(t, templateOutput, svc.opts.TemplateOutput)
 	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.True(t, svc.opts.AllowUnknownFields)
+	assert.True(t, svc.opts.PinDigests)
+	assert.True(t, svc.opts.Reproducible)
+	assert.Equal(t, "provenance.json", svc.opts.ProvenanceOutput)
+	assert.Equal(t, "archive.tgz", svc.opts.OutputArchive)
+	assert.True(t, svc.opts.CopyResources)
+	assert.Equal(t, "private.pem", svc.opts.SigningKeyFile)
+	assert.Equal(t, "chain.pem", svc.opts.SigningCertificateFile)
+	assert.Equal(t, "release", svc.opts.SignatureName)
+	assert.Equal(t, []string{"example.com/=warning", "provenance.kyma-project.io/"}, svc.opts.ReservedKeyPrefixes)
 }
 
 func Test_Execute_ParsesModuleShortOptions(t *testing.T) {
@@ -112,10 +146,26 @@ func Test_Execute_ModuleParsesDefaults(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, createcmd.ConfigFileFlagDefault, svc.opts.ConfigFile)
-	assert.Equal(t, createcmd.CredentialsFlagDefault, svc.opts.Credentials)
-	assert.Equal(t, createcmd.InsecureFlagDefault, svc.opts.Insecure)
+	assert.Equal(t, registryflags.CredentialsFlagDefault, svc.opts.Credentials)
+	assert.Equal(t, registryflags.CredentialsFileFlagDefault, svc.opts.CredentialsFile)
+	assert.Equal(t, registryflags.RegistryTokenFileFlagDefault, svc.opts.RegistryTokenFile)
+	assert.Equal(t, registryflags.RegistryCAFileFlagDefault, svc.opts.RegistryCAFile)
+	assert.Equal(t, registryflags.RegistryClientCertFlagDefault, svc.opts.RegistryClientCert)
+	assert.Equal(t, registryflags.RegistryClientKeyFlagDefault, svc.opts.RegistryClientKey)
+	assert.Equal(t, createcmd.RegistryTargetsFileFlagDefault, svc.opts.RegistryTargetsFile)
+	assert.Equal(t, registryflags.InsecureFlagDefault, svc.opts.Insecure)
 	assert.Equal(t, createcmd.TemplateOutputFlagDefault, svc.opts.TemplateOutput)
-	assert.Equal(t, createcmd.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, registryflags.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, createcmd.AllowUnknownFieldsFlagDefault, svc.opts.AllowUnknownFields)
+	assert.Equal(t, createcmd.PinDigestsFlagDefault, svc.opts.PinDigests)
+	assert.Equal(t, createcmd.ReproducibleFlagDefault, svc.opts.Reproducible)
+	assert.Equal(t, createcmd.ProvenanceOutputFlagDefault, svc.opts.ProvenanceOutput)
+	assert.Equal(t, createcmd.OutputArchiveFlagDefault, svc.opts.OutputArchive)
+	assert.Equal(t, createcmd.CopyResourcesFlagDefault, svc.opts.CopyResources)
+	assert.Equal(t, createcmd.SigningKeyFlagDefault, svc.opts.SigningKeyFile)
+	assert.Equal(t, createcmd.SigningCertificateFlagDefault, svc.opts.SigningCertificateFile)
+	assert.Equal(t, createcmd.SignatureNameFlagDefault, svc.opts.SignatureName)
+	assert.Equal(t, validation.DefaultReservedKeyPrefixes(), svc.opts.ReservedKeyPrefixes)
 }
 
 // Test Stubs
@@ -3,41 +3,57 @@ package create
 import (
 	"github.com/spf13/pflag"
 
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/create"
+	"github.com/kyma-project/modulectl/internal/service/signature"
 )
 
 const (
+	registryURLFlagUsage = "Context URL of the repository. The repository URL will be automatically added to the repository contexts in the module descriptor."
+
 	ConfigFileFlagName    = "config-file"
 	configFileFlagShort   = "c"
 	ConfigFileFlagDefault = "module-config.yaml"
 	configFileFlagUsage   = "Specifies the path to the module configuration file."
 
-	CredentialsFlagName    = "registry-credentials" //nolint:gosec // Not hardcoded credentials, rather just flag name
-	CredentialsFlagDefault = ""
-	credentialsFlagUsage   = "Basic authentication credentials for the given repository in the <user:password> format."
-
-	InsecureFlagName    = "insecure"
-	InsecureFlagDefault = false
-	insecureFlagUsage   = "Allows to use a less secure (non-tls) connection for registry access, e.g. localhost when testing. Should only be used in dev scenarios."
+	RegistryTargetsFileFlagName    = "registry-targets-file"
+	RegistryTargetsFileFlagDefault = ""
+	registryTargetsFileFlagUsage   = "Path to a YAML file with the registries to push the component version to, each with its own authentication and TLS settings. The first registry is the primary one the ModuleTemplate is rendered from. Replaces --registry and the other registry flags."
 
 	TemplateOutputFlagName    = "output"
 	templateOutputFlagShort   = "o"
 	TemplateOutputFlagDefault = "template.yaml"
 	templateOutputFlagUsage   = `Path to write the ModuleTemplate file to, if the module is uploaded to a registry (default "template.yaml").`
 
-	RegistryURLFlagName    = "registry"
-	registryFlagShort      = "r"
-	RegistryURLFlagDefault = ""
-	registryURLFlagUsage   = "Context URL of the repository. The repository URL will be automatically added to the repository contexts in the module descriptor."
-
 	OverwriteComponentVersionFlagName    = "overwrite"
 	overwriteComponentVersionFlagUsage   = "Overwrites the pushed component version if it already exists in the OCI registry. Use the flag ONLY for testing purposes."
 	OverwriteComponentVersionFlagDefault = false
 
 	DryRunFlagName    = "dry-run"
-	dryRunFlagUsage   = "Skips the push of the module descriptor to the registry. Checks if the component version already exists in the registry and fails the command if it does and --overwrite is not set to true."
+	dryRunFlagUsage   = "Skips the push of the module descriptor to the registry. Checks if the component version already exists in the registry and fails the command if it does with different content and --overwrite is not set to true."
 	DryRunFlagDefault = false
 
+	OutputArchiveFlagName    = "output-archive"
+	OutputArchiveFlagDefault = ""
+	outputArchiveFlagUsage   = "Path to write the component version to as a Common Transport Format archive instead of pushing it to the registry. The archive is a directory, or a gzipped tar file if the path ends with .tgz or .tar.gz. Push it later with the push command."
+
+	CopyResourcesFlagName    = "copy-resources"
+	CopyResourcesFlagDefault = false
+	copyResourcesFlagUsage   = "Copies the OCI artifacts referenced by the resources, e.g. the images of the manifest, into the registry or the archive the component version is written to, so that the published component descriptor only points to the target."
+
+	SigningKeyFlagName    = "signing-key"
+	SigningKeyFlagDefault = ""
+	signingKeyFlagUsage   = "Path to a PEM file with the RSA private key to sign the component version before it is pushed or written to the archive with. The signature is embedded in the component descriptor."
+
+	SigningCertificateFlagName    = "signing-certificate"
+	SigningCertificateFlagDefault = ""
+	signingCertificateFlagUsage   = "Path to a PEM file with the certificate chain of the signing key, which is embedded in the signature. Must be set together with --signing-key."
+
+	SignatureNameFlagName    = "signature-name"
+	SignatureNameFlagDefault = signature.DefaultName
+	signatureNameFlagUsage   = "Name of the signature created with --signing-key."
+
 	ModuleSourcesGitDirectoryFlagName    = "module-sources-git-directory"
 	ModuleSourcesGitDirectoryFlagDefault = "."
 	ModuleSourcesGitDirectoryFlagUsage   = "Path to the directory containing the module sources. If not set, the current directory is used. The directory must contain a valid Git repository."
@@ -49,32 +65,43 @@ const (
 	OutputConstructorFileFlagName    = "output-constructor-file"
 	OutputConstructorFileFlagDefault = "component-constructor.yaml"
 	OutputConstructorFileFlagUsage   = "Path to write the component constructor file to (default \"component-constructor.yaml\")."
+
+	AllowUnknownFieldsFlagName    = "allow-unknown-fields"
+	AllowUnknownFieldsFlagDefault = false
+	allowUnknownFieldsFlagUsage   = "Allows keys in the module config file that are not part of the module config schema instead of failing. Should only be used to migrate legacy module configs."
+
+	PinDigestsFlagName    = "pin-digests"
+	PinDigestsFlagDefault = false
+	pinDigestsFlagUsage   = "Resolves the digest of every image referenced by tag only against its registry and references the image by tag and digest in the component."
+
+	ReproducibleFlagName    = "reproducible"
+	ReproducibleFlagDefault = false
+	reproducibleFlagUsage   = "Adds the digest of the generated module template content as the \"operator.kyma-project.io/content-digest\" annotation, so that builds of the same inputs can be compared."
+
+	ProvenanceOutputFlagName    = "provenance-output"
+	ProvenanceOutputFlagDefault = ""
+	provenanceOutputFlagUsage   = "Path to write an in-toto statement with the SLSA provenance of the generated module template and component constructor files to. If not set, no statement is written."
+
+	ReservedKeyPrefixFlagName  = "reserved-key-prefix"
+	reservedKeyPrefixFlagUsage = `Label and annotation key prefix in the <prefix>[=<error|warning>] format, can be repeated. Labels and annotations of the module configuration file with a reserved key prefix fail the command with the "error" severity, which is the default, or print a warning with the "warning" severity. Adds to the default prefixes "operator.kyma-project.io/=warning" and "provenance.kyma-project.io/=error" or changes their severities. The "provenance.kyma-project.io/" prefix and the prefixes it contains always have the "error" severity, as modulectl sets the provenance keys itself.`
 )
 
 func parseFlags(flags *pflag.FlagSet, opts *create.Options) {
+	registryflags.ParseFlags(flags, &opts.RegistryAccess, registryURLFlagUsage)
 	flags.StringVarP(&opts.ConfigFile,
 		ConfigFileFlagName,
 		configFileFlagShort,
 		ConfigFileFlagDefault,
 		configFileFlagUsage)
-	flags.StringVar(&opts.Credentials,
-		CredentialsFlagName,
-		CredentialsFlagDefault,
-		credentialsFlagUsage)
-	flags.BoolVar(&opts.Insecure,
-		InsecureFlagName,
-		InsecureFlagDefault,
-		insecureFlagUsage)
+	flags.StringVar(&opts.RegistryTargetsFile,
+		RegistryTargetsFileFlagName,
+		RegistryTargetsFileFlagDefault,
+		registryTargetsFileFlagUsage)
 	flags.StringVarP(&opts.TemplateOutput,
 		TemplateOutputFlagName,
 		templateOutputFlagShort,
 		TemplateOutputFlagDefault,
 		templateOutputFlagUsage)
-	flags.StringVarP(&opts.RegistryURL,
-		RegistryURLFlagName,
-		registryFlagShort,
-		RegistryURLFlagDefault,
-		registryURLFlagUsage)
 	flags.StringVar(&opts.ModuleSourcesGitDirectory,
 		ModuleSourcesGitDirectoryFlagName,
 		ModuleSourcesGitDirectoryFlagDefault,
@@ -87,6 +114,26 @@ func parseFlags(flags *pflag.FlagSet, opts *create.Options) {
 		DryRunFlagName,
 		DryRunFlagDefault,
 		dryRunFlagUsage)
+	flags.StringVar(&opts.OutputArchive,
+		OutputArchiveFlagName,
+		OutputArchiveFlagDefault,
+		outputArchiveFlagUsage)
+	flags.BoolVar(&opts.CopyResources,
+		CopyResourcesFlagName,
+		CopyResourcesFlagDefault,
+		copyResourcesFlagUsage)
+	flags.StringVar(&opts.SigningKeyFile,
+		SigningKeyFlagName,
+		SigningKeyFlagDefault,
+		signingKeyFlagUsage)
+	flags.StringVar(&opts.SigningCertificateFile,
+		SigningCertificateFlagName,
+		SigningCertificateFlagDefault,
+		signingCertificateFlagUsage)
+	flags.StringVar(&opts.SignatureName,
+		SignatureNameFlagName,
+		SignatureNameFlagDefault,
+		signatureNameFlagUsage)
 
 	// Feature toggle flag for skipping version validation, should be removed once all module confirmed in the internal backlog issue: 7573
 	flags.BoolVar(&opts.SkipVersionValidation,
@@ -103,4 +150,29 @@ func parseFlags(flags *pflag.FlagSet, opts *create.Options) {
 		OutputConstructorFileFlagName,
 		OutputConstructorFileFlagDefault,
 		OutputConstructorFileFlagUsage)
+
+	flags.BoolVar(&opts.AllowUnknownFields,
+		AllowUnknownFieldsFlagName,
+		AllowUnknownFieldsFlagDefault,
+		allowUnknownFieldsFlagUsage)
+
+	flags.BoolVar(&opts.PinDigests,
+		PinDigestsFlagName,
+		PinDigestsFlagDefault,
+		pinDigestsFlagUsage)
+
+	flags.BoolVar(&opts.Reproducible,
+		ReproducibleFlagName,
+		ReproducibleFlagDefault,
+		reproducibleFlagUsage)
+
+	flags.StringVar(&opts.ProvenanceOutput,
+		ProvenanceOutputFlagName,
+		ProvenanceOutputFlagDefault,
+		provenanceOutputFlagUsage)
+
+	flags.StringSliceVar(&opts.ReservedKeyPrefixes,
+		ReservedKeyPrefixFlagName,
+		validation.DefaultReservedKeyPrefixes(),
+		reservedKeyPrefixFlagUsage)
 }
@@ -18,21 +18,44 @@ func Test_CreateFlagsDefaults(t *testing.T) {
 			value:    createcmd.ConfigFileFlagDefault,
 			expected: "module-config.yaml",
 		},
-		{name: createcmd.CredentialsFlagName, value: createcmd.CredentialsFlagDefault, expected: ""},
-		{name: createcmd.InsecureFlagName, value: strconv.FormatBool(createcmd.InsecureFlagDefault), expected: "false"},
+		{name: createcmd.RegistryTargetsFileFlagName, value: createcmd.RegistryTargetsFileFlagDefault, expected: ""},
 		{name: createcmd.TemplateOutputFlagName, value: createcmd.TemplateOutputFlagDefault, expected: "template.yaml"},
-		{name: createcmd.RegistryURLFlagName, value: createcmd.RegistryURLFlagDefault, expected: ""},
 		{
 			name:     createcmd.OverwriteComponentVersionFlagName,
 			value:    strconv.FormatBool(createcmd.OverwriteComponentVersionFlagDefault),
 			expected: "false",
 		},
 		{name: createcmd.DryRunFlagName, value: strconv.FormatBool(createcmd.DryRunFlagDefault), expected: "false"},
+		{name: createcmd.OutputArchiveFlagName, value: createcmd.OutputArchiveFlagDefault, expected: ""},
+		{
+			name:     createcmd.CopyResourcesFlagName,
+			value:    strconv.FormatBool(createcmd.CopyResourcesFlagDefault),
+			expected: "false",
+		},
+		{name: createcmd.SigningKeyFlagName, value: createcmd.SigningKeyFlagDefault, expected: ""},
+		{name: createcmd.SigningCertificateFlagName, value: createcmd.SigningCertificateFlagDefault, expected: ""},
+		{name: createcmd.SignatureNameFlagName, value: createcmd.SignatureNameFlagDefault, expected: "kyma-module"},
 		{
 			name:     createcmd.ModuleSourcesGitDirectoryFlagName,
 			value:    createcmd.ModuleSourcesGitDirectoryFlagDefault,
 			expected: ".",
 		},
+		{
+			name:     createcmd.AllowUnknownFieldsFlagName,
+			value:    strconv.FormatBool(createcmd.AllowUnknownFieldsFlagDefault),
+			expected: "false",
+		},
+		{
+			name:     createcmd.PinDigestsFlagName,
+			value:    strconv.FormatBool(createcmd.PinDigestsFlagDefault),
+			expected: "false",
+		},
+		{
+			name:     createcmd.ReproducibleFlagName,
+			value:    strconv.FormatBool(createcmd.ReproducibleFlagDefault),
+			expected: "false",
+		},
+		{name: createcmd.ProvenanceOutputFlagName, value: createcmd.ProvenanceOutputFlagDefault, expected: ""},
 	}
 
 	for _, testcase := range tests {
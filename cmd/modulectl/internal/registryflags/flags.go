@@ -0,0 +1,79 @@
+package registryflags
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/internal/common/types"
+)
+
+const (
+	RegistryURLFlagName    = "registry"
+	registryFlagShort      = "r"
+	RegistryURLFlagDefault = ""
+
+	CredentialsFlagName    = "registry-credentials" //nolint:gosec // Not hardcoded credentials, rather just flag name
+	CredentialsFlagDefault = ""
+	credentialsFlagUsage   = "Basic authentication credentials for the given repository in the <user:password> format."
+
+	CredentialsFileFlagName    = "registry-credentials-file" //nolint:gosec // Not hardcoded credentials, rather just flag name
+	CredentialsFileFlagDefault = ""
+	credentialsFileFlagUsage   = "Path to a file containing the basic authentication credentials for the given repository in the <user:password> format. Preferred over --registry-credentials, which exposes the credentials in the shell history and process list."
+
+	RegistryTokenFileFlagName    = "registry-token-file" //nolint:gosec // Not a hardcoded token, rather just flag name
+	RegistryTokenFileFlagDefault = ""
+	registryTokenFileFlagUsage   = "Path to a file containing an identity token for the given repository, which the registry exchanges for a bearer token. Must not be combined with --registry-credentials or --registry-credentials-file."
+
+	RegistryCAFileFlagName    = "registry-ca-file"
+	RegistryCAFileFlagDefault = ""
+	registryCAFileFlagUsage   = "Path to a PEM file with the certificate authorities to trust, in addition to the system ones, for TLS connections to the given repository, e.g. when it uses a private CA or a self-signed certificate."
+
+	RegistryClientCertFlagName    = "registry-client-cert"
+	RegistryClientCertFlagDefault = ""
+	registryClientCertFlagUsage   = "Path to a PEM file with the client certificate for TLS connections to the given repository. Must be set together with --registry-client-key."
+
+	RegistryClientKeyFlagName    = "registry-client-key"
+	RegistryClientKeyFlagDefault = ""
+	registryClientKeyFlagUsage   = "Path to a PEM file with the private key of the client certificate for TLS connections to the given repository. Must be set together with --registry-client-cert."
+
+	InsecureFlagName    = "insecure"
+	InsecureFlagDefault = false
+	insecureFlagUsage   = "Allows to use a less secure (non-tls) connection for registry access, e.g. localhost when testing. Should only be used in dev scenarios."
+)
+
+// ParseFlags registers the flags to access the registry. The usage of the registry URL flag is given by the command,
+// as it describes what the command does with the registry.
+func ParseFlags(flags *pflag.FlagSet, access *types.RegistryAccess, registryURLUsage string) {
+	flags.StringVarP(&access.RegistryURL,
+		RegistryURLFlagName,
+		registryFlagShort,
+		RegistryURLFlagDefault,
+		registryURLUsage)
+	flags.StringVar(&access.Credentials,
+		CredentialsFlagName,
+		CredentialsFlagDefault,
+		credentialsFlagUsage)
+	flags.StringVar(&access.CredentialsFile,
+		CredentialsFileFlagName,
+		CredentialsFileFlagDefault,
+		credentialsFileFlagUsage)
+	flags.StringVar(&access.RegistryTokenFile,
+		RegistryTokenFileFlagName,
+		RegistryTokenFileFlagDefault,
+		registryTokenFileFlagUsage)
+	flags.StringVar(&access.RegistryCAFile,
+		RegistryCAFileFlagName,
+		RegistryCAFileFlagDefault,
+		registryCAFileFlagUsage)
+	flags.StringVar(&access.RegistryClientCert,
+		RegistryClientCertFlagName,
+		RegistryClientCertFlagDefault,
+		registryClientCertFlagUsage)
+	flags.StringVar(&access.RegistryClientKey,
+		RegistryClientKeyFlagName,
+		RegistryClientKeyFlagDefault,
+		registryClientKeyFlagUsage)
+	flags.BoolVar(&access.Insecure,
+		InsecureFlagName,
+		InsecureFlagDefault,
+		insecureFlagUsage)
+}
@@ -0,0 +1,80 @@
+package registryflags_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/common/types"
+)
+
+func Test_RegistryFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: registryflags.RegistryURLFlagName, value: registryflags.RegistryURLFlagDefault, expected: ""},
+		{name: registryflags.CredentialsFlagName, value: registryflags.CredentialsFlagDefault, expected: ""},
+		{name: registryflags.CredentialsFileFlagName, value: registryflags.CredentialsFileFlagDefault, expected: ""},
+		{
+			name:     registryflags.RegistryTokenFileFlagName,
+			value:    registryflags.RegistryTokenFileFlagDefault,
+			expected: "",
+		},
+		{name: registryflags.RegistryCAFileFlagName, value: registryflags.RegistryCAFileFlagDefault, expected: ""},
+		{
+			name:     registryflags.RegistryClientCertFlagName,
+			value:    registryflags.RegistryClientCertFlagDefault,
+			expected: "",
+		},
+		{
+			name:     registryflags.RegistryClientKeyFlagName,
+			value:    registryflags.RegistryClientKeyFlagDefault,
+			expected: "",
+		},
+		{
+			name:     registryflags.InsecureFlagName,
+			value:    strconv.FormatBool(registryflags.InsecureFlagDefault),
+			expected: "false",
+		},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
+
+func Test_ParseFlags_SetsRegistryAccess(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	access := types.RegistryAccess{}
+
+	registryflags.ParseFlags(flags, &access, "Context URL of the repository.")
+	err := flags.Parse([]string{
+		"-r", "https://registry.kyma.cx",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, types.RegistryAccess{
+		RegistryURL:        "https://registry.kyma.cx",
+		RegistryTokenFile:  "token",
+		RegistryCAFile:     "ca.crt",
+		RegistryClientCert: "client.crt",
+		RegistryClientKey:  "client.key",
+	}, access)
+	assert.Equal(t, "Context URL of the repository.", flags.Lookup(registryflags.RegistryURLFlagName).Usage)
+}
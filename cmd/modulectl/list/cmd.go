@@ -0,0 +1,36 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/list/versions"
+
+	_ "embed"
+)
+
+//go:embed use.txt
+var use string
+
+//go:embed short.txt
+var short string
+
+//go:embed long.txt
+var long string
+
+func NewCmd(versionsService versions.Service) (*cobra.Command, error) {
+	versionsCmd, err := versions.NewCmd(versionsService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build versions command: %w", err)
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+	}
+	cmd.AddCommand(versionsCmd)
+
+	return cmd, nil
+}
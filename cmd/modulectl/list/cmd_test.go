@@ -0,0 +1,35 @@
+package list_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	listcmd "github.com/kyma-project/modulectl/cmd/modulectl/list"
+	"github.com/kyma-project/modulectl/internal/service/list"
+)
+
+func Test_NewCmd_ReturnsError_WhenVersionsServiceIsNil(t *testing.T) {
+	_, err := listcmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_AddsVersionsCommand(t *testing.T) {
+	cmd, err := listcmd.NewCmd(&listServiceStub{})
+
+	require.NoError(t, err)
+	versionsCmd, _, err := cmd.Find([]string{"versions"})
+	require.NoError(t, err)
+	assert.Equal(t, "versions", versionsCmd.Name())
+}
+
+// Test Stubs
+
+type listServiceStub struct{}
+
+func (*listServiceStub) Run(_ list.Options) error {
+	return nil
+}
@@ -0,0 +1,156 @@
+package versions_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/cmd/modulectl/list/versions"
+	"github.com/kyma-project/modulectl/internal/service/list"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+const moduleName = "kyma-project.io/module/template-operator"
+
+func Test_NewCmd_ReturnsError_WhenListServiceIsNil(t *testing.T) {
+	_, err := versions.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := versions.NewCmd(&listServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsListService(t *testing.T) {
+	os.Args = []string{"versions", moduleName}
+	svc := &listServiceStub{}
+	cmd, _ := versions.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Equal(t, moduleName, svc.opts.ModuleName)
+}
+
+func Test_Execute_ReturnsError_WhenModuleNameIsMissing(t *testing.T) {
+	os.Args = []string{"versions"}
+	svc := &listServiceStub{}
+	cmd, _ := versions.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	require.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenListServiceReturnsError(t *testing.T) {
+	os.Args = []string{"versions", moduleName}
+	cmd, _ := versions.NewCmd(&listServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+	credentials := testutils.RandomName(10)
+
+	os.Args = []string{
+		"versions", moduleName,
+		"--registry", registryURL,
+		"--registry-credentials", credentials,
+		"--registry-credentials-file", "credentials",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+		"--insecure",
+		"--output", list.OutputJSON,
+	}
+
+	svc := &listServiceStub{}
+	cmd, _ := versions.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, credentials, svc.opts.Credentials)
+	assert.Equal(t, "credentials", svc.opts.CredentialsFile)
+	assert.Equal(t, "token", svc.opts.RegistryTokenFile)
+	assert.Equal(t, "ca.crt", svc.opts.RegistryCAFile)
+	assert.Equal(t, "client.crt", svc.opts.RegistryClientCert)
+	assert.Equal(t, "client.key", svc.opts.RegistryClientKey)
+	assert.True(t, svc.opts.Insecure)
+	assert.Equal(t, list.OutputJSON, svc.opts.Output)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+
+	os.Args = []string{
+		"versions", moduleName,
+		"-r", registryURL,
+		"-o", list.OutputJSON,
+	}
+
+	svc := &listServiceStub{}
+	cmd, _ := versions.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, list.OutputJSON, svc.opts.Output)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{"versions", moduleName}
+
+	svc := &listServiceStub{}
+	cmd, _ := versions.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryflags.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, registryflags.CredentialsFlagDefault, svc.opts.Credentials)
+	assert.Equal(t, registryflags.CredentialsFileFlagDefault, svc.opts.CredentialsFile)
+	assert.Equal(t, registryflags.RegistryTokenFileFlagDefault, svc.opts.RegistryTokenFile)
+	assert.Equal(t, registryflags.RegistryCAFileFlagDefault, svc.opts.RegistryCAFile)
+	assert.Equal(t, registryflags.RegistryClientCertFlagDefault, svc.opts.RegistryClientCert)
+	assert.Equal(t, registryflags.RegistryClientKeyFlagDefault, svc.opts.RegistryClientKey)
+	assert.Equal(t, registryflags.InsecureFlagDefault, svc.opts.Insecure)
+	assert.Equal(t, versions.OutputFlagDefault, svc.opts.Output)
+}
+
+// Test Stubs
+
+type listServiceStub struct {
+	called bool
+	opts   list.Options
+}
+
+func (s *listServiceStub) Run(opts list.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type listServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *listServiceErrorStub) Run(_ list.Options) error {
+	return errSomeTestError
+}
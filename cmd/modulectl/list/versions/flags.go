@@ -0,0 +1,26 @@
+package versions
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/service/list"
+)
+
+const (
+	registryURLFlagUsage = "Context URL of the repository the module versions were published to."
+
+	OutputFlagName    = "output"
+	outputFlagShort   = "o"
+	OutputFlagDefault = list.OutputText
+	outputFlagUsage   = "Output format of the versions, either text or json."
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *list.Options) {
+	registryflags.ParseFlags(flags, &opts.RegistryAccess, registryURLFlagUsage)
+	flags.StringVarP(&opts.Output,
+		OutputFlagName,
+		outputFlagShort,
+		OutputFlagDefault,
+		outputFlagUsage)
+}
@@ -0,0 +1,27 @@
+package versions_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/list/versions"
+)
+
+func Test_VersionsFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: versions.OutputFlagName, value: versions.OutputFlagDefault, expected: "text"},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package pull_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	pullcmd "github.com/kyma-project/modulectl/cmd/modulectl/pull"
+	"github.com/kyma-project/modulectl/internal/service/pull"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+func Test_NewCmd_ReturnsError_WhenPullServiceIsNil(t *testing.T) {
+	_, err := pullcmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := pullcmd.NewCmd(&pullServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsPullService(t *testing.T) {
+	os.Args = []string{"pull", "kyma-project.io/module/template-operator:1.0.0"}
+	svc := &pullServiceStub{}
+	cmd, _ := pullcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Equal(t, "kyma-project.io/module/template-operator:1.0.0", svc.opts.Component)
+}
+
+func Test_Execute_ReturnsError_WhenComponentIsMissing(t *testing.T) {
+	os.Args = []string{"pull"}
+	svc := &pullServiceStub{}
+	cmd, _ := pullcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	require.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenPullServiceReturnsError(t *testing.T) {
+	os.Args = []string{"pull", "kyma-project.io/module/template-operator:1.0.0"}
+	cmd, _ := pullcmd.NewCmd(&pullServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+	credentials := testutils.RandomName(10)
+	outputDirectory := testutils.RandomName(10)
+	configFile := testutils.RandomName(10)
+
+	os.Args = []string{
+		"pull", "kyma-project.io/module/template-operator:1.0.0",
+		"--registry", registryURL,
+		"--registry-credentials", credentials,
+		"--registry-credentials-file", "credentials",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+		"--insecure",
+		"--output-dir", outputDirectory,
+		"--config-file", configFile,
+		"--allow-unknown-fields",
+	}
+
+	svc := &pullServiceStub{}
+	cmd, _ := pullcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, credentials, svc.opts.Credentials)
+	assert.Equal(t, "credentials", svc.opts.CredentialsFile)
+	assert.Equal(t, "token", svc.opts.RegistryTokenFile)
+	assert.Equal(t, "ca.crt", svc.opts.RegistryCAFile)
+	assert.Equal(t, "client.crt", svc.opts.RegistryClientCert)
+	assert.Equal(t, "client.key", svc.opts.RegistryClientKey)
+	assert.True(t, svc.opts.Insecure)
+	assert.Equal(t, outputDirectory, svc.opts.OutputDirectory)
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+	assert.True(t, svc.opts.AllowUnknownFields)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+	outputDirectory := testutils.RandomName(10)
+	configFile := testutils.RandomName(10)
+
+	os.Args = []string{
+		"pull", "kyma-project.io/module/template-operator:1.0.0",
+		"-r", registryURL,
+		"-d", outputDirectory,
+		"-c", configFile,
+	}
+
+	svc := &pullServiceStub{}
+	cmd, _ := pullcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, outputDirectory, svc.opts.OutputDirectory)
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{"pull", "kyma-project.io/module/template-operator:1.0.0"}
+
+	svc := &pullServiceStub{}
+	cmd, _ := pullcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryflags.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, registryflags.CredentialsFlagDefault, svc.opts.Credentials)
+	assert.Equal(t, registryflags.CredentialsFileFlagDefault, svc.opts.CredentialsFile)
+	assert.Equal(t, registryflags.RegistryTokenFileFlagDefault, svc.opts.RegistryTokenFile)
+	assert.Equal(t, registryflags.RegistryCAFileFlagDefault, svc.opts.RegistryCAFile)
+	assert.Equal(t, registryflags.RegistryClientCertFlagDefault, svc.opts.RegistryClientCert)
+	assert.Equal(t, registryflags.RegistryClientKeyFlagDefault, svc.opts.RegistryClientKey)
+	assert.Equal(t, registryflags.InsecureFlagDefault, svc.opts.Insecure)
+	assert.Equal(t, pullcmd.OutputDirectoryFlagDefault, svc.opts.OutputDirectory)
+	assert.Equal(t, pullcmd.ConfigFileFlagDefault, svc.opts.ConfigFile)
+	assert.Equal(t, pullcmd.AllowUnknownFieldsFlagDefault, svc.opts.AllowUnknownFields)
+}
+
+// Test Stubs
+
+type pullServiceStub struct {
+	called bool
+	opts   pull.Options
+}
+
+func (s *pullServiceStub) Run(opts pull.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type pullServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *pullServiceErrorStub) Run(_ pull.Options) error {
+	return errSomeTestError
+}
@@ -0,0 +1,44 @@
+package pull
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/service/pull"
+)
+
+const (
+	registryURLFlagUsage = "Context URL of the repository the module was published to."
+
+	OutputDirectoryFlagName    = "output-dir"
+	outputDirectoryFlagShort   = "d"
+	OutputDirectoryFlagDefault = "."
+	outputDirectoryFlagUsage   = "Directory to write the component descriptor, the resources and the ModuleTemplate to. It is created if it does not exist."
+
+	ConfigFileFlagName    = "config-file"
+	configFileFlagShort   = "c"
+	ConfigFileFlagDefault = ""
+	configFileFlagUsage   = "Path to the module configuration file of the module version. If set, the ModuleTemplate is regenerated."
+
+	AllowUnknownFieldsFlagName    = "allow-unknown-fields"
+	AllowUnknownFieldsFlagDefault = false
+	allowUnknownFieldsFlagUsage   = "Allows keys in the module config file that are not part of the module config schema instead of failing. Should only be used to migrate legacy module configs."
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *pull.Options) {
+	registryflags.ParseFlags(flags, &opts.RegistryAccess, registryURLFlagUsage)
+	flags.StringVarP(&opts.OutputDirectory,
+		OutputDirectoryFlagName,
+		outputDirectoryFlagShort,
+		OutputDirectoryFlagDefault,
+		outputDirectoryFlagUsage)
+	flags.StringVarP(&opts.ConfigFile,
+		ConfigFileFlagName,
+		configFileFlagShort,
+		ConfigFileFlagDefault,
+		configFileFlagUsage)
+	flags.BoolVar(&opts.AllowUnknownFields,
+		AllowUnknownFieldsFlagName,
+		AllowUnknownFieldsFlagDefault,
+		allowUnknownFieldsFlagUsage)
+}
@@ -0,0 +1,34 @@
+package pull_test
+
+import (
+	"strconv"
+	"testing"
+
+	pullcmd "github.com/kyma-project/modulectl/cmd/modulectl/pull"
+)
+
+func Test_PullFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: pullcmd.OutputDirectoryFlagName, value: pullcmd.OutputDirectoryFlagDefault, expected: "."},
+		{name: pullcmd.ConfigFileFlagName, value: pullcmd.ConfigFileFlagDefault, expected: ""},
+		{
+			name:     pullcmd.AllowUnknownFieldsFlagName,
+			value:    strconv.FormatBool(pullcmd.AllowUnknownFieldsFlagDefault),
+			expected: "false",
+		},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
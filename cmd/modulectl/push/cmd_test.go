@@ -0,0 +1,164 @@
+package push_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	pushcmd "github.com/kyma-project/modulectl/cmd/modulectl/push"
+	"github.com/kyma-project/modulectl/internal/service/push"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+func Test_NewCmd_ReturnsError_WhenPushServiceIsNil(t *testing.T) {
+	_, err := pushcmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := pushcmd.NewCmd(&pushServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsPushService(t *testing.T) {
+	os.Args = []string{"push", "template-operator.tgz"}
+	svc := &pushServiceStub{}
+	cmd, _ := pushcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Equal(t, "template-operator.tgz", svc.opts.ArchivePath)
+}
+
+func Test_Execute_ReturnsError_WhenArchiveIsMissing(t *testing.T) {
+	os.Args = []string{"push"}
+	svc := &pushServiceStub{}
+	cmd, _ := pushcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	require.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenPushServiceReturnsError(t *testing.T) {
+	os.Args = []string{"push", "template-operator.tgz"}
+	cmd, _ := pushcmd.NewCmd(&pushServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+	credentials := testutils.RandomName(10)
+
+	os.Args = []string{
+		"push", "template-operator.tgz",
+		"--registry", registryURL,
+		"--registry-credentials", credentials,
+		"--registry-credentials-file", "credentials",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+		"--insecure",
+		"--overwrite",
+		"--copy-resources",
+		"--signing-key", "private.pem",
+		"--signing-certificate", "chain.pem",
+		"--signature-name", "release",
+	}
+
+	svc := &pushServiceStub{}
+	cmd, _ := pushcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, credentials, svc.opts.Credentials)
+	assert.Equal(t, "credentials", svc.opts.CredentialsFile)
+	assert.Equal(t, "token", svc.opts.RegistryTokenFile)
+	assert.Equal(t, "ca.crt", svc.opts.RegistryCAFile)
+	assert.Equal(t, "client.crt", svc.opts.RegistryClientCert)
+	assert.Equal(t, "client.key", svc.opts.RegistryClientKey)
+	assert.True(t, svc.opts.Insecure)
+	assert.True(t, svc.opts.OverwriteComponentVersion)
+	assert.True(t, svc.opts.CopyResources)
+	assert.Equal(t, "private.pem", svc.opts.SigningKeyFile)
+	assert.Equal(t, "chain.pem", svc.opts.SigningCertificateFile)
+	assert.Equal(t, "release", svc.opts.SignatureName)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+
+	os.Args = []string{
+		"push", "template-operator.tgz",
+		"-r", registryURL,
+	}
+
+	svc := &pushServiceStub{}
+	cmd, _ := pushcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{"push", "template-operator.tgz"}
+
+	svc := &pushServiceStub{}
+	cmd, _ := pushcmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryflags.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, registryflags.CredentialsFlagDefault, svc.opts.Credentials)
+	assert.Equal(t, registryflags.CredentialsFileFlagDefault, svc.opts.CredentialsFile)
+	assert.Equal(t, registryflags.RegistryTokenFileFlagDefault, svc.opts.RegistryTokenFile)
+	assert.Equal(t, registryflags.RegistryCAFileFlagDefault, svc.opts.RegistryCAFile)
+	assert.Equal(t, registryflags.RegistryClientCertFlagDefault, svc.opts.RegistryClientCert)
+	assert.Equal(t, registryflags.RegistryClientKeyFlagDefault, svc.opts.RegistryClientKey)
+	assert.Equal(t, registryflags.InsecureFlagDefault, svc.opts.Insecure)
+	assert.Equal(t, pushcmd.OverwriteComponentVersionFlagDefault, svc.opts.OverwriteComponentVersion)
+	assert.Equal(t, pushcmd.CopyResourcesFlagDefault, svc.opts.CopyResources)
+	assert.Equal(t, pushcmd.SigningKeyFlagDefault, svc.opts.SigningKeyFile)
+	assert.Equal(t, pushcmd.SigningCertificateFlagDefault, svc.opts.SigningCertificateFile)
+	assert.Equal(t, pushcmd.SignatureNameFlagDefault, svc.opts.SignatureName)
+}
+
+// Test Stubs
+
+type pushServiceStub struct {
+	called bool
+	opts   push.Options
+}
+
+func (s *pushServiceStub) Run(opts push.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type pushServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *pushServiceErrorStub) Run(_ push.Options) error {
+	return errSomeTestError
+}
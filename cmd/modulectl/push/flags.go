@@ -0,0 +1,57 @@
+package push
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/service/push"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+)
+
+const (
+	registryURLFlagUsage = "Context URL of the repository to push the archive to."
+
+	SigningKeyFlagName    = "signing-key"
+	SigningKeyFlagDefault = ""
+	signingKeyFlagUsage   = "Path to a PEM file with the RSA private key to sign the component versions of the archive before they are pushed with. The signature is embedded in the component descriptor."
+
+	SigningCertificateFlagName    = "signing-certificate"
+	SigningCertificateFlagDefault = ""
+	signingCertificateFlagUsage   = "Path to a PEM file with the certificate chain of the signing key, which is embedded in the signature. Must be set together with --signing-key."
+
+	SignatureNameFlagName    = "signature-name"
+	SignatureNameFlagDefault = signature.DefaultName
+	signatureNameFlagUsage   = "Name of the signature created with --signing-key."
+
+	CopyResourcesFlagName    = "copy-resources"
+	CopyResourcesFlagDefault = false
+	copyResourcesFlagUsage   = "Copies the OCI artifacts referenced by the resources, e.g. the images of the manifest, into the registry, so that the pushed component descriptors only point to the registry."
+
+	OverwriteComponentVersionFlagName    = "overwrite"
+	OverwriteComponentVersionFlagDefault = false
+	overwriteComponentVersionFlagUsage   = "Overwrites the pushed component versions if they already exist in the OCI registry. Use the flag ONLY for testing purposes."
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *push.Options) {
+	registryflags.ParseFlags(flags, &opts.RegistryAccess, registryURLFlagUsage)
+	flags.BoolVar(&opts.OverwriteComponentVersion,
+		OverwriteComponentVersionFlagName,
+		OverwriteComponentVersionFlagDefault,
+		overwriteComponentVersionFlagUsage)
+	flags.BoolVar(&opts.CopyResources,
+		CopyResourcesFlagName,
+		CopyResourcesFlagDefault,
+		copyResourcesFlagUsage)
+	flags.StringVar(&opts.SigningKeyFile,
+		SigningKeyFlagName,
+		SigningKeyFlagDefault,
+		signingKeyFlagUsage)
+	flags.StringVar(&opts.SigningCertificateFile,
+		SigningCertificateFlagName,
+		SigningCertificateFlagDefault,
+		signingCertificateFlagUsage)
+	flags.StringVar(&opts.SignatureName,
+		SignatureNameFlagName,
+		SignatureNameFlagDefault,
+		signatureNameFlagUsage)
+}
@@ -0,0 +1,40 @@
+package push_test
+
+import (
+	"strconv"
+	"testing"
+
+	pushcmd "github.com/kyma-project/modulectl/cmd/modulectl/push"
+)
+
+func Test_PushFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     pushcmd.CopyResourcesFlagName,
+			value:    strconv.FormatBool(pushcmd.CopyResourcesFlagDefault),
+			expected: "false",
+		},
+		{name: pushcmd.SigningKeyFlagName, value: pushcmd.SigningKeyFlagDefault, expected: ""},
+		{name: pushcmd.SigningCertificateFlagName, value: pushcmd.SigningCertificateFlagDefault, expected: ""},
+		{name: pushcmd.SignatureNameFlagName, value: pushcmd.SignatureNameFlagDefault, expected: "kyma-module"},
+		{
+			name:     pushcmd.OverwriteComponentVersionFlagName,
+			value:    strconv.FormatBool(pushcmd.OverwriteComponentVersionFlagDefault),
+			expected: "false",
+		},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package releasemeta_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	releasemetacmd "github.com/kyma-project/modulectl/cmd/modulectl/releasemeta"
+	"github.com/kyma-project/modulectl/internal/service/releasemeta"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+func Test_NewCmd_ReturnsError_WhenReleaseMetaServiceIsNil(t *testing.T) {
+	_, err := releasemetacmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := releasemetacmd.NewCmd(&releaseMetaServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsReleaseMetaService(t *testing.T) {
+	os.Args = []string{"release-meta"}
+	svc := &releaseMetaServiceStub{}
+	cmd, _ := releasemetacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenReleaseMetaServiceReturnsError(t *testing.T) {
+	os.Args = []string{"release-meta"}
+	cmd, _ := releasemetacmd.NewCmd(&releaseMetaServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	configFile := testutils.RandomName(10)
+	output := testutils.RandomName(10)
+
+	os.Args = []string{
+		"release-meta",
+		"--config-file", configFile,
+		"--channel", "regular",
+		"--channel", "fast,experimental",
+		"--output", output,
+		"--allow-unknown-fields",
+	}
+
+	svc := &releaseMetaServiceStub{}
+	cmd, _ := releasemetacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+	assert.Equal(t, []string{"regular", "fast", "experimental"}, svc.opts.Channels)
+	assert.Equal(t, output, svc.opts.Output)
+	assert.True(t, svc.opts.AllowUnknownFields)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	configFile := testutils.RandomName(10)
+	output := testutils.RandomName(10)
+
+	os.Args = []string{
+		"release-meta",
+		"-c", configFile,
+		"-o", output,
+	}
+
+	svc := &releaseMetaServiceStub{}
+	cmd, _ := releasemetacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+	assert.Equal(t, output, svc.opts.Output)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{
+		"release-meta",
+	}
+
+	svc := &releaseMetaServiceStub{}
+	cmd, _ := releasemetacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, releasemetacmd.ConfigFileFlagDefault, svc.opts.ConfigFile)
+	assert.Empty(t, svc.opts.Channels)
+	assert.Equal(t, releasemetacmd.OutputFlagDefault, svc.opts.Output)
+	assert.Equal(t, releasemetacmd.AllowUnknownFieldsFlagDefault, svc.opts.AllowUnknownFields)
+}
+
+// Test Stubs
+
+type releaseMetaServiceStub struct {
+	called bool
+	opts   releasemeta.Options
+}
+
+func (s *releaseMetaServiceStub) Run(opts releasemeta.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type releaseMetaServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *releaseMetaServiceErrorStub) Run(_ releasemeta.Options) error {
+	return errSomeTestError
+}
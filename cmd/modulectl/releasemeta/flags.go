@@ -0,0 +1,47 @@
+package releasemeta
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/internal/service/releasemeta"
+)
+
+const (
+	ConfigFileFlagName    = "config-file"
+	configFileFlagShort   = "c"
+	ConfigFileFlagDefault = "module-config.yaml"
+	configFileFlagUsage   = "Specifies the path to the module configuration file."
+
+	ChannelFlagName  = "channel"
+	channelFlagUsage = "Channel to assign the module version to, can be repeated. Overrides the channels of the module configuration file."
+
+	OutputFlagName    = "output"
+	outputFlagShort   = "o"
+	OutputFlagDefault = "module-release-meta.yaml"
+	outputFlagUsage   = "File to write the ModuleReleaseMeta to. An existing ModuleReleaseMeta in this file is updated."
+
+	AllowUnknownFieldsFlagName    = "allow-unknown-fields"
+	AllowUnknownFieldsFlagDefault = false
+	allowUnknownFieldsFlagUsage   = "Allows keys in the module config file that are not part of the module config schema instead of failing. Should only be used to migrate legacy module configs."
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *releasemeta.Options) {
+	flags.StringVarP(&opts.ConfigFile,
+		ConfigFileFlagName,
+		configFileFlagShort,
+		ConfigFileFlagDefault,
+		configFileFlagUsage)
+	flags.StringSliceVar(&opts.Channels,
+		ChannelFlagName,
+		nil,
+		channelFlagUsage)
+	flags.StringVarP(&opts.Output,
+		OutputFlagName,
+		outputFlagShort,
+		OutputFlagDefault,
+		outputFlagUsage)
+	flags.BoolVar(&opts.AllowUnknownFields,
+		AllowUnknownFieldsFlagName,
+		AllowUnknownFieldsFlagDefault,
+		allowUnknownFieldsFlagUsage)
+}
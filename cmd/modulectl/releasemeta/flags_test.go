@@ -0,0 +1,42 @@
+package releasemeta_test
+
+import (
+	"strconv"
+	"testing"
+
+	releasemetacmd "github.com/kyma-project/modulectl/cmd/modulectl/releasemeta"
+)
+
+func Test_ReleaseMetaFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     releasemetacmd.ConfigFileFlagName,
+			value:    releasemetacmd.ConfigFileFlagDefault,
+			expected: "module-config.yaml",
+		},
+		{
+			name:     releasemetacmd.OutputFlagName,
+			value:    releasemetacmd.OutputFlagDefault,
+			expected: "module-release-meta.yaml",
+		},
+		{
+			name:     releasemetacmd.AllowUnknownFieldsFlagName,
+			value:    strconv.FormatBool(releasemetacmd.AllowUnknownFieldsFlagDefault),
+			expected: "false",
+		},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/schema"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+
+	_ "embed"
+)
+
+//go:embed use.txt
+var use string
+
+//go:embed short.txt
+var short string
+
+//go:embed long.txt
+var long string
+
+//go:embed example.txt
+var example string
+
+type Service interface {
+	Run(opts schema.Options) error
+}
+
+func NewCmd(service Service) (*cobra.Command, error) {
+	if service == nil {
+		return nil, fmt.Errorf("service must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	opts := schema.Options{}
+
+	cmd := &cobra.Command{
+		Use:       use,
+		Short:     short,
+		Long:      long,
+		Example:   example,
+		ValidArgs: schema.Kinds(),
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(_ *cobra.Command, args []string) error {
+			opts.Kind = args[0]
+			return service.Run(opts)
+		},
+	}
+
+	opts.Out = iotools.NewDefaultOut(cmd.OutOrStdout())
+
+	return cmd, nil
+}
@@ -0,0 +1,91 @@
+package schema_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	schemacmd "github.com/kyma-project/modulectl/cmd/modulectl/schema"
+	"github.com/kyma-project/modulectl/internal/service/schema"
+)
+
+func Test_NewCmd_ReturnsError_WhenSchemaServiceIsNil(t *testing.T) {
+	_, err := schemacmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := schemacmd.NewCmd(&schemaServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsSchemaService_WithKind(t *testing.T) {
+	os.Args = []string{"schema", schema.KindSecurityConfig}
+	svc := &schemaServiceStub{}
+	cmd, _ := schemacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Equal(t, schema.KindSecurityConfig, svc.opts.Kind)
+	assert.NotNil(t, svc.opts.Out)
+}
+
+func Test_Execute_ReturnsError_WhenKindIsMissing(t *testing.T) {
+	os.Args = []string{"schema"}
+	svc := &schemaServiceStub{}
+	cmd, _ := schemacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	assert.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenKindIsUnknown(t *testing.T) {
+	os.Args = []string{"schema", "manifest"}
+	svc := &schemaServiceStub{}
+	cmd, _ := schemacmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.ErrorContains(t, err, `invalid argument "manifest"`)
+	assert.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenSchemaServiceReturnsError(t *testing.T) {
+	os.Args = []string{"schema", schema.KindModuleConfig}
+	cmd, _ := schemacmd.NewCmd(&schemaServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+// Test Stubs
+
+type schemaServiceStub struct {
+	called bool
+	opts   schema.Options
+}
+
+func (s *schemaServiceStub) Run(opts schema.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type schemaServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *schemaServiceErrorStub) Run(_ schema.Options) error {
+	return errSomeTestError
+}
@@ -0,0 +1,127 @@
+package validate_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	validatecmd "github.com/kyma-project/modulectl/cmd/modulectl/validate"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/validate"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+func Test_NewCmd_ReturnsError_WhenValidateServiceIsNil(t *testing.T) {
+	_, err := validatecmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := validatecmd.NewCmd(&validateServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsValidateService(t *testing.T) {
+	os.Args = []string{"validate"}
+	svc := &validateServiceStub{}
+	cmd, _ := validatecmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenValidateServiceReturnsError(t *testing.T) {
+	os.Args = []string{"validate"}
+	cmd, _ := validatecmd.NewCmd(&validateServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	configFile := testutils.RandomName(10)
+
+	os.Args = []string{
+		"validate",
+		"--config-file", configFile,
+		"--skip-version-validation=false",
+		"--allow-unknown-fields",
+		"--reserved-key-prefix", "example.com/=warning",
+		"--reserved-key-prefix", "provenance.kyma-project.io/",
+	}
+
+	svc := &validateServiceStub{}
+	cmd, _ := validatecmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+	assert.False(t, svc.opts.SkipVersionValidation)
+	assert.True(t, svc.opts.AllowUnknownFields)
+	assert.Equal(t, []string{"example.com/=warning", "provenance.kyma-project.io/"}, svc.opts.ReservedKeyPrefixes)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	configFile := testutils.RandomName(10)
+
+	os.Args = []string{
+		"validate",
+		"-c", configFile,
+	}
+
+	svc := &validateServiceStub{}
+	cmd, _ := validatecmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, configFile, svc.opts.ConfigFile)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{
+		"validate",
+	}
+
+	svc := &validateServiceStub{}
+	cmd, _ := validatecmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, validatecmd.ConfigFileFlagDefault, svc.opts.ConfigFile)
+	assert.Equal(t, validatecmd.SkipVersionValidationFlagDefault, svc.opts.SkipVersionValidation)
+	assert.Equal(t, validatecmd.AllowUnknownFieldsFlagDefault, svc.opts.AllowUnknownFields)
+	assert.Equal(t, validation.DefaultReservedKeyPrefixes(), svc.opts.ReservedKeyPrefixes)
+}
+
+// Test Stubs
+
+type validateServiceStub struct {
+	called bool
+	opts   validate.Options
+}
+
+func (s *validateServiceStub) Run(opts validate.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type validateServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *validateServiceErrorStub) Run(_ validate.Options) error {
+	return errSomeTestError
+}
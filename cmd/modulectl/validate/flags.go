@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/validate"
+)
+
+const (
+	ConfigFileFlagName    = "config-file"
+	configFileFlagShort   = "c"
+	ConfigFileFlagDefault = "module-config.yaml"
+	configFileFlagUsage   = "Specifies the path to the module configuration file."
+
+	SkipVersionValidationFlagName    = "skip-version-validation"
+	SkipVersionValidationFlagDefault = true
+	skipVersionValidationFlagUsage   = "Skipping image and ocm version validation"
+
+	AllowUnknownFieldsFlagName    = "allow-unknown-fields"
+	AllowUnknownFieldsFlagDefault = false
+	allowUnknownFieldsFlagUsage   = "Allows keys in the module config file that are not part of the module config schema instead of failing. Should only be used to migrate legacy module configs."
+
+	ReservedKeyPrefixFlagName  = "reserved-key-prefix"
+	reservedKeyPrefixFlagUsage = `Label and annotation key prefix in the <prefix>[=<error|warning>] format, can be repeated. Labels and annotations of the module configuration file with a reserved key prefix fail the command with the "error" severity, which is the default, or print a warning with the "warning" severity. Adds to the default prefixes "operator.kyma-project.io/=warning" and "provenance.kyma-project.io/=error" or changes their severities. The "provenance.kyma-project.io/" prefix and the prefixes it contains always have the "error" severity, as modulectl sets the provenance keys itself.`
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *validate.Options) {
+	flags.StringVarP(&opts.ConfigFile,
+		ConfigFileFlagName,
+		configFileFlagShort,
+		ConfigFileFlagDefault,
+		configFileFlagUsage)
+
+	// Aligned with the feature toggle of the create command, should be removed together with it
+	flags.BoolVar(&opts.SkipVersionValidation,
+		SkipVersionValidationFlagName,
+		SkipVersionValidationFlagDefault,
+		skipVersionValidationFlagUsage)
+	flags.BoolVar(&opts.AllowUnknownFields,
+		AllowUnknownFieldsFlagName,
+		AllowUnknownFieldsFlagDefault,
+		allowUnknownFieldsFlagUsage)
+	flags.StringSliceVar(&opts.ReservedKeyPrefixes,
+		ReservedKeyPrefixFlagName,
+		validation.DefaultReservedKeyPrefixes(),
+		reservedKeyPrefixFlagUsage)
+}
@@ -0,0 +1,38 @@
+package validate_test
+
+import (
+	"strconv"
+	"testing"
+
+	validatecmd "github.com/kyma-project/modulectl/cmd/modulectl/validate"
+)
+
+func Test_ValidateFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: validatecmd.ConfigFileFlagName, value: validatecmd.ConfigFileFlagDefault, expected: "module-config.yaml"},
+		{
+			name:     validatecmd.SkipVersionValidationFlagName,
+			value:    strconv.FormatBool(validatecmd.SkipVersionValidationFlagDefault),
+			expected: "true",
+		},
+		{
+			name:     validatecmd.AllowUnknownFieldsFlagName,
+			value:    strconv.FormatBool(validatecmd.AllowUnknownFieldsFlagDefault),
+			expected: "false",
+		},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package verify_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	verifycmd "github.com/kyma-project/modulectl/cmd/modulectl/verify"
+	"github.com/kyma-project/modulectl/internal/service/verify"
+	"github.com/kyma-project/modulectl/internal/testutils"
+)
+
+func Test_NewCmd_ReturnsError_WhenVerifyServiceIsNil(t *testing.T) {
+	_, err := verifycmd.NewCmd(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service must not be nil")
+}
+
+func Test_NewCmd_Succeeds(t *testing.T) {
+	_, err := verifycmd.NewCmd(&verifyServiceStub{})
+
+	require.NoError(t, err)
+}
+
+func Test_Execute_CallsVerifyService(t *testing.T) {
+	os.Args = []string{"verify", "kyma-project.io/module/template-operator:1.0.0"}
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Equal(t, "kyma-project.io/module/template-operator:1.0.0", svc.opts.Component)
+}
+
+func Test_Execute_CallsVerifyService_WithoutComponent(t *testing.T) {
+	os.Args = []string{"verify", "--module-template", "template.yaml"}
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	require.True(t, svc.called)
+	assert.Empty(t, svc.opts.Component)
+	assert.Equal(t, "template.yaml", svc.opts.ModuleTemplateFile)
+}
+
+func Test_Execute_ReturnsError_WhenTooManyArgumentsAreGiven(t *testing.T) {
+	os.Args = []string{"verify", "kyma-project.io/module/template-operator:1.0.0", "1.0.1"}
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+
+	require.Error(t, err)
+	require.False(t, svc.called)
+}
+
+func Test_Execute_ReturnsError_WhenVerifyServiceReturnsError(t *testing.T) {
+	os.Args = []string{"verify", "kyma-project.io/module/template-operator:1.0.0"}
+	cmd, _ := verifycmd.NewCmd(&verifyServiceErrorStub{})
+
+	err := cmd.Execute()
+
+	require.ErrorIs(t, err, errSomeTestError)
+}
+
+func Test_Execute_ParsesAllOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+	credentials := testutils.RandomName(10)
+
+	os.Args = []string{
+		"verify", "kyma-project.io/module/template-operator:1.0.0",
+		"--registry", registryURL,
+		"--registry-credentials", credentials,
+		"--registry-credentials-file", "credentials",
+		"--registry-token-file", "token",
+		"--registry-ca-file", "ca.crt",
+		"--registry-client-cert", "client.crt",
+		"--registry-client-key", "client.key",
+		"--insecure",
+		"--public-key", "public.pem",
+		"--signature-name", "release",
+		"--module-template", "template.yaml",
+	}
+
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+	assert.Equal(t, credentials, svc.opts.Credentials)
+	assert.Equal(t, "credentials", svc.opts.CredentialsFile)
+	assert.Equal(t, "token", svc.opts.RegistryTokenFile)
+	assert.Equal(t, "ca.crt", svc.opts.RegistryCAFile)
+	assert.Equal(t, "client.crt", svc.opts.RegistryClientCert)
+	assert.Equal(t, "client.key", svc.opts.RegistryClientKey)
+	assert.True(t, svc.opts.Insecure)
+	assert.Equal(t, "public.pem", svc.opts.PublicKeyFile)
+	assert.Equal(t, "release", svc.opts.SignatureName)
+	assert.Equal(t, "template.yaml", svc.opts.ModuleTemplateFile)
+}
+
+func Test_Execute_ParsesShortOptions(t *testing.T) {
+	registryURL := testutils.RandomName(10)
+
+	os.Args = []string{
+		"verify", "kyma-project.io/module/template-operator:1.0.0",
+		"-r", registryURL,
+	}
+
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryURL, svc.opts.RegistryURL)
+}
+
+func Test_Execute_ParsesDefaults(t *testing.T) {
+	os.Args = []string{"verify", "kyma-project.io/module/template-operator:1.0.0"}
+
+	svc := &verifyServiceStub{}
+	cmd, _ := verifycmd.NewCmd(svc)
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	assert.Equal(t, registryflags.RegistryURLFlagDefault, svc.opts.RegistryURL)
+	assert.Equal(t, registryflags.CredentialsFlagDefault, svc.opts.Credentials)
+	assert.Equal(t, registryflags.CredentialsFileFlagDefault, svc.opts.CredentialsFile)
+	assert.Equal(t, registryflags.RegistryTokenFileFlagDefault, svc.opts.RegistryTokenFile)
+	assert.Equal(t, registryflags.RegistryCAFileFlagDefault, svc.opts.RegistryCAFile)
+	assert.Equal(t, registryflags.RegistryClientCertFlagDefault, svc.opts.RegistryClientCert)
+	assert.Equal(t, registryflags.RegistryClientKeyFlagDefault, svc.opts.RegistryClientKey)
+	assert.Equal(t, registryflags.InsecureFlagDefault, svc.opts.Insecure)
+	assert.Equal(t, verifycmd.PublicKeyFlagDefault, svc.opts.PublicKeyFile)
+	assert.Equal(t, verifycmd.SignatureNameFlagDefault, svc.opts.SignatureName)
+	assert.Equal(t, verifycmd.ModuleTemplateFlagDefault, svc.opts.ModuleTemplateFile)
+}
+
+// Test Stubs
+
+type verifyServiceStub struct {
+	called bool
+	opts   verify.Options
+}
+
+func (s *verifyServiceStub) Run(opts verify.Options) error {
+	s.called = true
+	s.opts = opts
+	return nil
+}
+
+type verifyServiceErrorStub struct{}
+
+var errSomeTestError = errors.New("some test error")
+
+func (s *verifyServiceErrorStub) Run(_ verify.Options) error {
+	return errSomeTestError
+}
@@ -0,0 +1,41 @@
+package verify
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/kyma-project/modulectl/cmd/modulectl/internal/registryflags"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	"github.com/kyma-project/modulectl/internal/service/verify"
+)
+
+const (
+	registryURLFlagUsage = "Context URL of the repository the module was published to. Required to verify a published component version."
+
+	PublicKeyFlagName    = "public-key"
+	PublicKeyFlagDefault = ""
+	publicKeyFlagUsage   = "Path to a PEM file with the RSA public key, or the certificate of the signing key, to verify the signature with."
+
+	SignatureNameFlagName    = "signature-name"
+	SignatureNameFlagDefault = signature.DefaultName
+	signatureNameFlagUsage   = "Name of the signature to verify."
+
+	ModuleTemplateFlagName    = "module-template"
+	ModuleTemplateFlagDefault = ""
+	moduleTemplateFlagUsage   = "Path to a ModuleTemplate file to verify the embedded component descriptor of, instead of a published component version."
+)
+
+func parseFlags(flags *pflag.FlagSet, opts *verify.Options) {
+	registryflags.ParseFlags(flags, &opts.RegistryAccess, registryURLFlagUsage)
+	flags.StringVar(&opts.PublicKeyFile,
+		PublicKeyFlagName,
+		PublicKeyFlagDefault,
+		publicKeyFlagUsage)
+	flags.StringVar(&opts.SignatureName,
+		SignatureNameFlagName,
+		SignatureNameFlagDefault,
+		signatureNameFlagUsage)
+	flags.StringVar(&opts.ModuleTemplateFile,
+		ModuleTemplateFlagName,
+		ModuleTemplateFlagDefault,
+		moduleTemplateFlagUsage)
+}
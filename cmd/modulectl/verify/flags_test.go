@@ -0,0 +1,29 @@
+package verify_test
+
+import (
+	"testing"
+
+	verifycmd "github.com/kyma-project/modulectl/cmd/modulectl/verify"
+)
+
+func Test_VerifyFlagsDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: verifycmd.PublicKeyFlagName, value: verifycmd.PublicKeyFlagDefault, expected: ""},
+		{name: verifycmd.SignatureNameFlagName, value: verifycmd.SignatureNameFlagDefault, expected: "kyma-module"},
+		{name: verifycmd.ModuleTemplateFlagName, value: verifycmd.ModuleTemplateFlagDefault, expected: ""},
+	}
+
+	for _, testcase := range tests {
+		testName := "TestFlagHasCorrectDefault_" + testcase.name
+		t.Run(testName, func(t *testing.T) {
+			if testcase.value != testcase.expected {
+				t.Errorf("Flag '%s' has different default: expected = '%s', got = '%s'",
+					testcase.name, testcase.expected, testcase.value)
+			}
+		})
+	}
+}
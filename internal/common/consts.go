@@ -12,6 +12,7 @@ const (
 	SecurityScanLabelKey      = "security.kyma-project.io/scan"
 	SecurityScanEnabledValue  = "enabled"
 	SecScanBaseLabelKey       = "scan.security.kyma-project.io"
+	ProvenanceBaseLabelKey    = "provenance.kyma-project.io"
 	TypeLabelKey              = "type"
 	ThirdPartyImageLabelValue = "third-party-image"
 
@@ -19,4 +20,7 @@ const (
 	RawManifestResourceName    = "raw-manifest"
 	DefaultCRResourceName      = "default-cr"
 	ModuleTemplateResourceName = "moduletemplate"
+
+	// ContentDigestAnnotationKey holds the digest of the ModuleTemplate content in reproducible mode.
+	ContentDigestAnnotationKey = "operator.kyma-project.io/content-digest"
 )
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+// RegistryAccess holds the options to access the registry a command reads from or writes to.
+type RegistryAccess struct {
+	RegistryURL        string
+	Insecure           bool
+	Credentials        string
+	CredentialsFile    string
+	RegistryTokenFile  string
+	RegistryCAFile     string
+	RegistryClientCert string
+	RegistryClientKey  string
+}
+
+// Validate validates the registry URL and the authentication and TLS settings of the registry.
+func (access RegistryAccess) Validate() error {
+	if access.RegistryURL == "" {
+		return fmt.Errorf("opts.RegistryURL must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !strings.HasPrefix(access.RegistryURL, "http") {
+		return fmt.Errorf("opts.RegistryURL does not start with http(s): %w", commonerrors.ErrInvalidOption)
+	}
+
+	return access.ValidateAuth()
+}
+
+// ValidateAuth validates the authentication and TLS settings of the registry only, e.g. for a command that does not
+// access the registry in every mode.
+func (access RegistryAccess) ValidateAuth() error {
+	if access.Credentials != "" && access.CredentialsFile != "" {
+		return fmt.Errorf("opts.Credentials and opts.CredentialsFile must not be set together: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if access.RegistryTokenFile != "" && (access.Credentials != "" || access.CredentialsFile != "") {
+		return fmt.Errorf("opts.RegistryTokenFile must not be set together with opts.Credentials or "+
+			"opts.CredentialsFile: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if (access.RegistryClientCert == "") != (access.RegistryClientKey == "") {
+		return fmt.Errorf("opts.RegistryClientCert and opts.RegistryClientKey must be set together: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if access.RegistryCAFile != "" && access.Insecure {
+		return fmt.Errorf("opts.RegistryCAFile must not be set together with opts.Insecure: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// SplitComponent splits a component reference in the <name>:<version> format.
+func SplitComponent(component string) (string, string, error) {
+	name, version, found := strings.Cut(component, ":")
+	if !found || name == "" || version == "" {
+		return "", "", fmt.Errorf("opts.Component %q must be in the <name>:<version> format: %w", component,
+			commonerrors.ErrInvalidOption)
+	}
+	return name, version, nil
+}
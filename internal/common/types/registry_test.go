@@ -0,0 +1,122 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+)
+
+func Test_RegistryAccess_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		access  types.RegistryAccess
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "RegistryURL is empty",
+			access:  types.RegistryAccess{},
+			wantErr: true,
+			errMsg:  "opts.RegistryURL must not be empty",
+		},
+		{
+			name:    "RegistryURL without http scheme",
+			access:  types.RegistryAccess{RegistryURL: "registry.kyma.cx"},
+			wantErr: true,
+			errMsg:  "opts.RegistryURL does not start with http(s)",
+		},
+		{
+			name: "Credentials and CredentialsFile set together",
+			access: types.RegistryAccess{
+				RegistryURL:     "https://registry.kyma.cx",
+				Credentials:     "user:password",
+				CredentialsFile: "credentials",
+			},
+			wantErr: true,
+			errMsg:  "opts.Credentials and opts.CredentialsFile must not be set together",
+		},
+		{
+			name: "RegistryTokenFile set together with CredentialsFile",
+			access: types.RegistryAccess{
+				RegistryURL:       "https://registry.kyma.cx",
+				CredentialsFile:   "credentials",
+				RegistryTokenFile: "token",
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryTokenFile must not be set together with opts.Credentials",
+		},
+		{
+			name: "RegistryClientCert without RegistryClientKey",
+			access: types.RegistryAccess{
+				RegistryURL:        "https://registry.kyma.cx",
+				RegistryClientCert: "client.crt",
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryClientCert and opts.RegistryClientKey must be set together",
+		},
+		{
+			name: "RegistryCAFile set together with Insecure",
+			access: types.RegistryAccess{
+				RegistryURL:    "http://localhost:5001",
+				Insecure:       true,
+				RegistryCAFile: "ca.crt",
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryCAFile must not be set together with opts.Insecure",
+		},
+		{
+			name: "All options valid",
+			access: types.RegistryAccess{
+				RegistryURL:        "https://registry.kyma.cx",
+				RegistryTokenFile:  "token",
+				RegistryCAFile:     "ca.crt",
+				RegistryClientCert: "client.crt",
+				RegistryClientKey:  "client.key",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.access.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+				require.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_RegistryAccess_ValidateAuth_DoesNotRequireRegistryURL(t *testing.T) {
+	err := types.RegistryAccess{RegistryTokenFile: "token"}.ValidateAuth()
+
+	require.NoError(t, err)
+}
+
+func Test_RegistryAccess_ValidateAuth_ReturnsError_WhenCredentialsAndTokenAreSet(t *testing.T) {
+	err := types.RegistryAccess{Credentials: "user:password", RegistryTokenFile: "token"}.ValidateAuth()
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "opts.RegistryTokenFile must not be set together with opts.Credentials")
+}
+
+func Test_SplitComponent_ReturnsNameAndVersion(t *testing.T) {
+	name, version, err := types.SplitComponent("kyma-project.io/module/template-operator:1.0.0")
+
+	require.NoError(t, err)
+	require.Equal(t, "kyma-project.io/module/template-operator", name)
+	require.Equal(t, "1.0.0", version)
+}
+
+func Test_SplitComponent_ReturnsError_WhenVersionIsMissing(t *testing.T) {
+	_, _, err := types.SplitComponent("kyma-project.io/module/template-operator")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "<name>:<version>")
+}
@@ -1,5 +1,8 @@
 package slices
 
+import "sort"
+
+// MergeAndDeduplicate merges the slices into a sorted slice without duplicates and empty items.
 func MergeAndDeduplicate(slices ...[]string) []string {
 	itemSet := make(map[string]struct{})
 	for _, slice := range slices {
@@ -9,17 +12,15 @@ func MergeAndDeduplicate(slices ...[]string) []string {
 			}
 		}
 	}
-	result := make([]string, 0, len(itemSet))
-	for item := range itemSet {
-		result = append(result, item)
-	}
-	return result
+	return SetToSlice(itemSet)
 }
 
+// SetToSlice returns the items of the set sorted, so that the result does not depend on the map iteration order.
 func SetToSlice(itemSet map[string]struct{}) []string {
 	items := make([]string, 0, len(itemSet))
 	for item := range itemSet {
 		items = append(items, item)
 	}
+	sort.Strings(items)
 	return items
 }
@@ -21,7 +21,7 @@ func TestMergeAndDeduplicateSlices(t *testing.T) {
 		},
 		{
 			name:     "Unique elements",
-			input:    [][]string{{"x"}, {"y"}, {"z"}},
+			input:    [][]string{{"z"}, {"x"}, {"y"}},
 			expected: []string{"x", "y", "z"},
 		},
 		{
@@ -49,7 +49,7 @@ func TestMergeAndDeduplicateSlices(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := slices.MergeAndDeduplicate(tt.input...)
-			require.ElementsMatch(t, tt.expected, got)
+			require.Equal(t, tt.expected, got)
 		})
 	}
 }
@@ -72,7 +72,7 @@ func TestSetToSlice(t *testing.T) {
 		},
 		{
 			name:     "Multiple items",
-			input:    map[string]struct{}{"a": {}, "b": {}, "c": {}},
+			input:    map[string]struct{}{"c": {}, "a": {}, "b": {}},
 			expected: []string{"a", "b", "c"},
 		},
 	}
@@ -80,7 +80,7 @@ func TestSetToSlice(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := slices.SetToSlice(tt.input)
-			require.ElementsMatch(t, tt.expected, got)
+			require.Equal(t, tt.expected, got)
 		})
 	}
 }
@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+// ReservedKeySeverity defines if a label or annotation key with a reserved prefix fails the validation or only
+// results in a warning.
+type ReservedKeySeverity string
+
+const (
+	ReservedKeySeverityError   ReservedKeySeverity = "error"
+	ReservedKeySeverityWarning ReservedKeySeverity = "warning"
+
+	reservedKeyPrefixSeparator = "="
+	provenanceKeyPrefix        = "provenance.kyma-project.io/"
+)
+
+// ReservedKeyPrefix is a label or annotation key prefix that module configs should not use.
+type ReservedKeyPrefix struct {
+	Prefix   string
+	Severity ReservedKeySeverity
+}
+
+// DefaultReservedKeyPrefixes returns the reserved key prefixes in the "<prefix>=<severity>" format.
+// The provenance keys are set by modulectl, the keys of the operator group are evaluated by lifecycle-manager.
+func DefaultReservedKeyPrefixes() []string {
+	return []string{
+		"operator.kyma-project.io/" + reservedKeyPrefixSeparator + string(ReservedKeySeverityWarning),
+		provenanceKeyPrefix + reservedKeyPrefixSeparator + string(ReservedKeySeverityError),
+	}
+}
+
+// ParseReservedKeyPrefixes parses entries in the "<prefix>[=<error|warning>]" format.
+// Entries without a severity are reserved with error severity. The entries add to the default reserved key
+// prefixes or change their severities. The provenance prefix is always reserved with error severity, as modulectl
+// sets the provenance keys itself.
+func ParseReservedKeyPrefixes(entries []string) ([]ReservedKeyPrefix, error) {
+	entries = append(DefaultReservedKeyPrefixes(), entries...)
+	prefixes := make([]ReservedKeyPrefix, 0, len(entries))
+	for _, entry := range entries {
+		prefix, severity, found := strings.Cut(entry, reservedKeyPrefixSeparator)
+		if !found {
+			severity = string(ReservedKeySeverityError)
+		}
+
+		if prefix == "" {
+			return nil, fmt.Errorf("reserved key prefix %q must not have an empty prefix: %w", entry,
+				commonerrors.ErrInvalidOption)
+		}
+
+		switch ReservedKeySeverity(severity) {
+		case ReservedKeySeverityError, ReservedKeySeverityWarning:
+		default:
+			return nil, fmt.Errorf("reserved key prefix %q must have the severity %q or %q: %w", entry,
+				ReservedKeySeverityError, ReservedKeySeverityWarning, commonerrors.ErrInvalidOption)
+		}
+
+		if strings.HasPrefix(prefix, provenanceKeyPrefix) && ReservedKeySeverity(severity) != ReservedKeySeverityError {
+			return nil, fmt.Errorf("reserved key prefix %q must have the severity %q: %w", entry,
+				ReservedKeySeverityError, commonerrors.ErrInvalidOption)
+		}
+
+		reserved := ReservedKeyPrefix{Prefix: prefix, Severity: ReservedKeySeverity(severity)}
+		if i := slices.IndexFunc(prefixes, func(p ReservedKeyPrefix) bool { return p.Prefix == prefix }); i >= 0 {
+			prefixes[i] = reserved
+			continue
+		}
+		prefixes = append(prefixes, reserved)
+	}
+
+	return prefixes, nil
+}
+
+// MatchReservedKeyPrefix returns the longest of the reserved prefixes the key starts with.
+func MatchReservedKeyPrefix(key string, prefixes []ReservedKeyPrefix) (ReservedKeyPrefix, bool) {
+	var match ReservedKeyPrefix
+	found := false
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix.Prefix) && (!found || len(prefix.Prefix) > len(match.Prefix)) {
+			match = prefix
+			found = true
+		}
+	}
+
+	return match, found
+}
+
+// ValidateLabel validates the key and value of a label with the rules the Kubernetes API server applies.
+func ValidateLabel(key, value string) error {
+	if errs := k8svalidation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid label key: %s: %w", strings.Join(errs, "; "), commonerrors.ErrInvalidOption)
+	}
+
+	if errs := k8svalidation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("invalid label value: %s: %w", strings.Join(errs, "; "), commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateAnnotationKey validates the key of an annotation with the rules the Kubernetes API server applies.
+func ValidateAnnotationKey(key string) error {
+	if errs := k8svalidation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+		return fmt.Errorf("invalid annotation key: %s: %w", strings.Join(errs, "; "), commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// ValidateAnnotationsSize validates that the keys and values of the annotations do not exceed the total size the
+// Kubernetes API server accepts.
+func ValidateAnnotationsSize(annotations map[string]string) error {
+	totalSize := 0
+	for key, value := range annotations {
+		totalSize += len(key) + len(value)
+	}
+
+	if totalSize > apivalidation.TotalAnnotationSizeLimitB {
+		return fmt.Errorf("total size %d must not exceed %d bytes: %w", totalSize,
+			apivalidation.TotalAnnotationSizeLimitB, commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
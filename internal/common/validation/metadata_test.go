@@ -0,0 +1,247 @@
+package validation_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/modulectl/internal/common/validation"
+)
+
+func TestParseReservedKeyPrefixes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []validation.ReservedKeyPrefix
+		wantErr bool
+	}{
+		{
+			name:    "prefixes with severity",
+			entries: []string{"operator.kyma-project.io/=warning", "provenance.kyma-project.io/=error"},
+			want: []validation.ReservedKeyPrefix{
+				{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+				{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+			},
+		},
+		{
+			name:    "prefix without severity",
+			entries: []string{"example.com/"},
+			want: []validation.ReservedKeyPrefix{
+				{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+				{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+				{Prefix: "example.com/", Severity: validation.ReservedKeySeverityError},
+			},
+		},
+		{
+			name:    "changed severity of a default prefix",
+			entries: []string{"operator.kyma-project.io/"},
+			want: []validation.ReservedKeyPrefix{
+				{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+				{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+			},
+		},
+		{
+			name:    "no prefixes",
+			entries: nil,
+			want: []validation.ReservedKeyPrefix{
+				{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+				{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+			},
+		},
+		{
+			name:    "provenance prefix with warning severity",
+			entries: []string{"provenance.kyma-project.io/=warning"},
+			wantErr: true,
+		},
+		{
+			name:    "prefix within the provenance prefix with warning severity",
+			entries: []string{"provenance.kyma-project.io/commit-=warning"},
+			wantErr: true,
+		},
+		{
+			name:    "empty prefix",
+			entries: []string{"=warning"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown severity",
+			entries: []string{"example.com/=info"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validation.ParseReservedKeyPrefixes(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReservedKeyPrefixes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReservedKeyPrefixes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultReservedKeyPrefixes(t *testing.T) {
+	got, err := validation.ParseReservedKeyPrefixes(validation.DefaultReservedKeyPrefixes())
+	if err != nil {
+		t.Fatalf("ParseReservedKeyPrefixes() error = %v", err)
+	}
+
+	want := []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+		{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultReservedKeyPrefixes() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchReservedKeyPrefix(t *testing.T) {
+	prefixes := []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+		{Prefix: "operator.kyma-project.io/doc-", Severity: validation.ReservedKeySeverityError},
+	}
+	tests := []struct {
+		name      string
+		key       string
+		want      string
+		wantFound bool
+	}{
+		{
+			name:      "matches prefix",
+			key:       "operator.kyma-project.io/channel",
+			want:      "operator.kyma-project.io/",
+			wantFound: true,
+		},
+		{
+			name:      "matches longest prefix",
+			key:       "operator.kyma-project.io/doc-url",
+			want:      "operator.kyma-project.io/doc-",
+			wantFound: true,
+		},
+		{
+			name:      "matches no prefix",
+			key:       "example.com/team",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := validation.MatchReservedKeyPrefix(tt.key, prefixes)
+			if found != tt.wantFound || got.Prefix != tt.want {
+				t.Errorf("MatchReservedKeyPrefix() = %v, %v, want %v, %v", got.Prefix, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:  "valid label",
+			key:   "example.com/team",
+			value: "kyma_team-1.0",
+		},
+		{
+			name:  "valid label with empty value",
+			key:   "team",
+			value: "",
+		},
+		{
+			name:    "invalid key - illegal characters",
+			key:     "example.com/team name",
+			value:   "kyma",
+			wantErr: true,
+		},
+		{
+			name:    "invalid key - name too long",
+			key:     "example.com/" + strings.Repeat("a", 64),
+			value:   "kyma",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value - illegal characters",
+			key:     "example.com/team",
+			value:   "kyma team",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value - too long",
+			key:     "example.com/team",
+			value:   strings.Repeat("a", 64),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateLabel(tt.key, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAnnotationKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{
+			name: "valid key",
+			key:  "operator.kyma-project.io/doc-url",
+		},
+		{
+			name: "valid key with uppercase letters",
+			key:  "example.com/DocURL",
+		},
+		{
+			name:    "invalid key - illegal characters",
+			key:     "example.com/doc url",
+			wantErr: true,
+		},
+		{
+			name:    "invalid key - empty",
+			key:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateAnnotationKey(tt.key); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAnnotationKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAnnotationsSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "annotations within limit",
+			annotations: map[string]string{"example.com/doc": strings.Repeat("a", 1024)},
+		},
+		{
+			name:        "annotations exceeding limit",
+			annotations: map[string]string{"example.com/doc": strings.Repeat("a", 256*1024)},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateAnnotationsSize(tt.annotations); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAnnotationsSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
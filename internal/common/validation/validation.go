@@ -13,33 +13,37 @@ import (
 
 const (
 	//nolint:revive // taken from "https://github.com/open-component-model/ocm/blob/4473dacca406e4c84c0ac5e6e14393c659384afc/resources/component-descriptor-v2-schema.yaml#L40"
-	moduleNamePattern   = "^[a-z][-a-z0-9]*([.][a-z][-a-z0-9]*)*[.][a-z]{2,}(/[a-z][-a-z0-9_]*([.][a-z][-a-z0-9_]*)*)+$" //nolint:revive // for readability
-	moduleNameMaxLength = 255
-	namespaceMaxLength  = 253
-	namespacePattern    = "^[a-z0-9]+(?:-[a-z0-9]+)*$"
+	ModuleNamePattern   = "^[a-z][-a-z0-9]*([.][a-z][-a-z0-9]*)*[.][a-z]{2,}(/[a-z][-a-z0-9_]*([.][a-z][-a-z0-9_]*)*)+$" //nolint:revive // for readability
+	ModuleNameMaxLength = 255
+	NamespaceMaxLength  = 253
+	NamespacePattern    = "^[a-z0-9]+(?:-[a-z0-9]+)*$"
+	// ChannelPattern matches the channel names accepted by the ModuleReleaseMeta CRD.
+	ChannelPattern = "^[a-z]{3,32}$"
+	// ChannelVersionPattern matches the versions the ModuleReleaseMeta CRD accepts for a channel.
+	ChannelVersionPattern = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[a-zA-Z-][0-9a-zA-Z-]*)?$`
 )
 
 func ValidateModuleName(name string) error {
 	if name == "" {
-		return fmt.Errorf("opts.ModuleName must not be empty: %w", commonerrors.ErrInvalidOption)
+		return fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption)
 	}
 
-	if len(name) > moduleNameMaxLength {
+	if len(name) > ModuleNameMaxLength {
 		return fmt.Errorf(
-			"opts.ModuleName length must not exceed %q characters: %w",
-			moduleNameMaxLength,
+			"length must not exceed %d characters: %w",
+			ModuleNameMaxLength,
 			commonerrors.ErrInvalidOption,
 		)
 	}
 
 	if name != strings.ToLower(name) {
-		return fmt.Errorf("opts.ModuleName must not contain uppercase letters: %w", commonerrors.ErrInvalidOption)
+		return fmt.Errorf("must not contain uppercase letters: %w", commonerrors.ErrInvalidOption)
 	}
 
-	if matched, err := regexp.MatchString(moduleNamePattern, name); err != nil {
-		return fmt.Errorf("failed to evaluate regex pattern for opts.ModuleName: %w", commonerrors.ErrInvalidOption)
+	if matched, err := regexp.MatchString(ModuleNamePattern, name); err != nil {
+		return fmt.Errorf("failed to evaluate regex pattern for module name: %w", commonerrors.ErrInvalidOption)
 	} else if !matched {
-		return fmt.Errorf("opts.ModuleName must match the required pattern, e.g: 'github.com/path-to/your-repo': %w",
+		return fmt.Errorf("must match the required pattern, e.g: 'github.com/path-to/your-repo': %w",
 			commonerrors.ErrInvalidOption)
 	}
 
@@ -48,7 +52,7 @@ func ValidateModuleName(name string) error {
 
 func ValidateModuleVersion(version string) error {
 	if version == "" {
-		return fmt.Errorf("opts.ModuleVersion must not be empty: %w", commonerrors.ErrInvalidOption)
+		return fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption)
 	}
 
 	if err := validateSemanticVersion(version); err != nil {
@@ -59,13 +63,13 @@ func ValidateModuleVersion(version string) error {
 }
 
 func ValidateNamespace(namespace string) error {
-	if len(namespace) > namespaceMaxLength {
-		return fmt.Errorf("opts.ModuleNamespace length must not exceed %q characters: %w",
-			namespaceMaxLength,
+	if len(namespace) > NamespaceMaxLength {
+		return fmt.Errorf("length must not exceed %d characters: %w",
+			NamespaceMaxLength,
 			commonerrors.ErrInvalidOption)
 	}
 
-	if matched, err := regexp.MatchString(namespacePattern, namespace); err != nil {
+	if matched, err := regexp.MatchString(NamespacePattern, namespace); err != nil {
 		return fmt.Errorf("failed to evaluate regex pattern for module namespace: %w", err)
 	} else if !matched {
 		return fmt.Errorf("namespace must match the required pattern, "+
@@ -76,19 +80,37 @@ func ValidateNamespace(namespace string) error {
 	return nil
 }
 
+func ValidateChannel(channel string) error {
+	if matched, err := regexp.MatchString(ChannelPattern, channel); err != nil {
+		return fmt.Errorf("failed to evaluate regex pattern for channel: %w", err)
+	} else if !matched {
+		return fmt.Errorf("channel must consist of 3 to 32 small letters: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func ValidateMapEntries(nameLinkMap map[string]string) error {
 	for name, link := range nameLinkMap {
-		if name == "" {
-			return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+		if err := ValidateMapEntry(name, link); err != nil {
+			return err
 		}
+	}
 
-		if link == "" {
-			return fmt.Errorf("link must not be empty: %w", commonerrors.ErrInvalidOption)
-		}
+	return nil
+}
 
-		if err := ValidateIsValidHTTPSURL(link); err != nil {
-			return fmt.Errorf("failed to validate link: %w", err)
-		}
+func ValidateMapEntry(name, link string) error {
+	if name == "" {
+		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if link == "" {
+		return fmt.Errorf("link must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if err := ValidateIsValidHTTPSURL(link); err != nil {
+		return fmt.Errorf("failed to validate link: %w", err)
 	}
 
 	return nil
@@ -114,7 +136,7 @@ func ValidateIsValidHTTPSURL(input string) error {
 func validateSemanticVersion(version string) error {
 	_, err := semver.StrictNewVersion(strings.TrimSpace(version))
 	if err != nil {
-		return fmt.Errorf("opts.ModuleVersion failed to be parsed as semantic version: %w",
+		return fmt.Errorf("failed to be parsed as semantic version: %w",
 			commonerrors.ErrInvalidOption)
 	}
 
@@ -1,6 +1,7 @@
 package validation_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/modulectl/internal/common/validation"
@@ -98,6 +99,27 @@ func TestValidateModuleVersion(t *testing.T) {
 	}
 }
 
+func TestValidateChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		wantErr bool
+	}{
+		{name: "regular channel", channel: "regular", wantErr: false},
+		{name: "too short", channel: "ab", wantErr: true},
+		{name: "too long", channel: strings.Repeat("a", 33), wantErr: true},
+		{name: "contains digits", channel: "fast2", wantErr: true},
+		{name: "contains capital letters", channel: "Fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validation.ValidateChannel(tt.channel); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateChannel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateNamespace(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -0,0 +1,158 @@
+package componentdescriptor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+)
+
+// NormalizedDescriptor is the content of a component descriptor that identifies a component version, keyed by
+// readable field paths, e.g. "resource raw-manifest content". Fields that change when a component version is
+// pushed or signed, like the repository contexts, the signatures and the access of local blobs, are left out.
+// The normalization of OCM signatures is not used, as it only covers the labels marked for signing and requires
+// the digests of the resources, which are only calculated when signing, and as its result cannot be diffed.
+type NormalizedDescriptor map[string]string
+
+// volatileLabels are the component labels that describe the build rather than the content, so that a retry of
+// the same build with another modulectl version is still identical.
+var volatileLabels = []string{provenance.ModulectlVersionKey}
+
+// Normalize returns the normalized content of the descriptor. The content digests replace the access of the
+// resources with the same index, e.g. the digest of a local blob, whose access differs between repositories.
+// Resources without a content digest are compared by their access.
+func Normalize(descriptor *compdesc.ComponentDescriptor, contentDigests []string) (NormalizedDescriptor, error) {
+	normalized := NormalizedDescriptor{
+		"name":     descriptor.Name,
+		"version":  descriptor.Version,
+		"provider": string(descriptor.Provider.Name),
+	}
+	if err := normalized.addLabels("", descriptor.Labels); err != nil {
+		return nil, err
+	}
+
+	for i, resource := range descriptor.Resources {
+		key := "resource " + elementKey(resource.ElementMeta)
+		normalized[key+" type"] = resource.Type
+		normalized[key+" version"] = resource.Version
+		normalized[key+" relation"] = string(resource.Relation)
+		if len(resource.SourceRefs) > 0 {
+			sourceRefs, err := json.Marshal(resource.SourceRefs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal source refs of %s: %w", key, err)
+			}
+			normalized[key+" sourceRefs"] = string(sourceRefs)
+		}
+		if err := normalized.addLabels(key+" ", resource.Labels); err != nil {
+			return nil, err
+		}
+
+		if i < len(contentDigests) && contentDigests[i] != "" {
+			normalized[key+" content"] = contentDigests[i]
+			continue
+		}
+		access, err := json.Marshal(resource.Access)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal access of %s: %w", key, err)
+		}
+		normalized[key+" access"] = string(access)
+	}
+
+	for _, source := range descriptor.Sources {
+		content, err := json.Marshal(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal source %s: %w", source.Name, err)
+		}
+		normalized["source "+elementKey(source.ElementMeta)] = string(content)
+	}
+
+	for _, reference := range descriptor.References {
+		reference.Digest = nil
+		content, err := json.Marshal(reference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal reference %s: %w", reference.Name, err)
+		}
+		normalized["reference "+elementKey(reference.ElementMeta)] = string(content)
+	}
+
+	return normalized, nil
+}
+
+// Digest returns the SHA-256 digest of the normalized content.
+func (n NormalizedDescriptor) Digest() (string, error) {
+	// encoding/json writes the map sorted by key, so the same content always results in the same digest.
+	content, err := json.Marshal(n)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal normalized descriptor: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Diff returns the fields that differ from the other normalized content, sorted by their path. A field of this
+// content is prefixed with "-", a field of the other content with "+".
+func (n NormalizedDescriptor) Diff(other NormalizedDescriptor) []string {
+	keys := make([]string, 0, len(n)+len(other))
+	for key := range n {
+		keys = append(keys, key)
+	}
+	for key := range other {
+		if _, ok := n[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var diff []string
+	for _, key := range keys {
+		value, ok := n[key]
+		otherValue, otherOk := other[key]
+		if ok == otherOk && value == otherValue {
+			continue
+		}
+		if ok {
+			diff = append(diff, fmt.Sprintf("- %s: %s", key, value))
+		}
+		if otherOk {
+			diff = append(diff, fmt.Sprintf("+ %s: %s", key, otherValue))
+		}
+	}
+	return diff
+}
+
+func (n NormalizedDescriptor) addLabels(prefix string, labels ocmv1.Labels) error {
+	for _, label := range labels {
+		if prefix == "" && slices.Contains(volatileLabels, label.Name) {
+			continue
+		}
+		// The label values are compacted, as a descriptor read from a registry may be formatted differently.
+		var value bytes.Buffer
+		if err := json.Compact(&value, label.Value); err != nil {
+			return fmt.Errorf("failed to compact value of label %s: %w", label.Name, err)
+		}
+		n[prefix+"label "+label.Name] = value.String()
+	}
+	return nil
+}
+
+// elementKey identifies an element by its name and, if set, its extra identity.
+func elementKey(meta compdesc.ElementMeta) string {
+	if len(meta.ExtraIdentity) == 0 {
+		return meta.Name
+	}
+
+	identity := make([]string, 0, len(meta.ExtraIdentity))
+	for name, value := range meta.ExtraIdentity {
+		identity = append(identity, name+"="+value)
+	}
+	slices.Sort(identity)
+	return meta.Name + "[" + strings.Join(identity, ",") + "]"
+}
@@ -0,0 +1,129 @@
+package componentdescriptor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+
+	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+)
+
+func Test_Normalize_ReturnsSameDigest_ForSameContent(t *testing.T) {
+	descriptor := createNormalizeTestDescriptor(t)
+	other := createNormalizeTestDescriptor(t)
+	other.RepositoryContexts = nil
+	other.Labels[1].Value = json.RawMessage(`{ "enabled": true }`)
+
+	normalized, err := componentdescriptor.Normalize(descriptor, []string{"", "sha256:raw"})
+	require.NoError(t, err)
+	otherNormalized, err := componentdescriptor.Normalize(other, []string{"", "sha256:raw"})
+	require.NoError(t, err)
+
+	digest, err := normalized.Digest()
+	require.NoError(t, err)
+	otherDigest, err := otherNormalized.Digest()
+	require.NoError(t, err)
+	require.Equal(t, digest, otherDigest)
+	require.Empty(t, normalized.Diff(otherNormalized))
+}
+
+func Test_Normalize_IgnoresVolatileProvenanceLabels(t *testing.T) {
+	descriptor := createNormalizeTestDescriptor(t)
+	err := componentdescriptor.AddProvenanceLabels(descriptor, []provenance.Label{
+		{Name: provenance.ModulectlVersionKey, Value: "1.0.0"},
+		{Name: provenance.CommitTimeKey, Value: "2024-05-01T12:00:00Z"},
+	})
+	require.NoError(t, err)
+	other := createNormalizeTestDescriptor(t)
+	err = componentdescriptor.AddProvenanceLabels(other, []provenance.Label{
+		{Name: provenance.ModulectlVersionKey, Value: "1.1.0"},
+		{Name: provenance.CommitTimeKey, Value: "2024-05-01T12:00:00Z"},
+	})
+	require.NoError(t, err)
+
+	normalized, err := componentdescriptor.Normalize(descriptor, []string{"", "sha256:raw"})
+	require.NoError(t, err)
+	otherNormalized, err := componentdescriptor.Normalize(other, []string{"", "sha256:raw"})
+	require.NoError(t, err)
+
+	digest, err := normalized.Digest()
+	require.NoError(t, err)
+	otherDigest, err := otherNormalized.Digest()
+	require.NoError(t, err)
+	require.Equal(t, digest, otherDigest)
+	require.Empty(t, normalized.Diff(otherNormalized))
+}
+
+func Test_Normalize_ReturnsDiff_ForChangedContent(t *testing.T) {
+	descriptor := createNormalizeTestDescriptor(t)
+	other := createNormalizeTestDescriptor(t)
+	other.Resources[0].Access = ociartifact.New("europe-docker.pkg.dev/kyma/template-operator:1.0.1")
+	other.Labels = other.Labels[:1]
+
+	normalized, err := componentdescriptor.Normalize(descriptor, []string{"", "sha256:raw"})
+	require.NoError(t, err)
+	otherNormalized, err := componentdescriptor.Normalize(other, []string{"", "sha256:changed"})
+	require.NoError(t, err)
+
+	digest, err := normalized.Digest()
+	require.NoError(t, err)
+	otherDigest, err := otherNormalized.Digest()
+	require.NoError(t, err)
+	require.NotEqual(t, digest, otherDigest)
+	require.Equal(t, []string{
+		`- label security.kyma-project.io/scan: {"enabled":true}`,
+		`- resource raw-manifest content: sha256:raw`,
+		`+ resource raw-manifest content: sha256:changed`,
+		`- resource template-operator access: ` +
+			`{"type":"ociArtifact","imageReference":"europe-docker.pkg.dev/kyma/template-operator:1.0.0"}`,
+		`+ resource template-operator access: ` +
+			`{"type":"ociArtifact","imageReference":"europe-docker.pkg.dev/kyma/template-operator:1.0.1"}`,
+	}, normalized.Diff(otherNormalized))
+}
+
+func Test_Normalize_KeysResourcesByExtraIdentity(t *testing.T) {
+	descriptor := createNormalizeTestDescriptor(t)
+	descriptor.Resources[0].ExtraIdentity = ocmv1.Identity{"platform": "linux", "arch": "amd64"}
+
+	normalized, err := componentdescriptor.Normalize(descriptor, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, "ociArtifact", normalized["resource template-operator[arch=amd64,platform=linux] type"])
+	require.Contains(t, normalized["resource raw-manifest access"], "localBlob")
+}
+
+func createNormalizeTestDescriptor(t *testing.T) *compdesc.ComponentDescriptor {
+	t.Helper()
+
+	descriptor, err := componentdescriptor.InitializeComponentDescriptor("github.com/test-module", "0.0.1", true)
+	require.NoError(t, err)
+	descriptor.Labels = append(descriptor.Labels, ocmv1.Label{
+		Name:  "security.kyma-project.io/scan",
+		Value: json.RawMessage(`{"enabled":true}`),
+	})
+	descriptor.Resources = []compdesc.Resource{
+		{
+			ResourceMeta: compdesc.ResourceMeta{
+				ElementMeta: compdesc.ElementMeta{Name: "template-operator", Version: "1.0.0"},
+				Type:        "ociArtifact",
+				Relation:    ocmv1.ExternalRelation,
+			},
+			Access: ociartifact.New("europe-docker.pkg.dev/kyma/template-operator:1.0.0"),
+		},
+		{
+			ResourceMeta: compdesc.ResourceMeta{
+				ElementMeta: compdesc.ElementMeta{Name: "raw-manifest", Version: "0.0.1"},
+				Type:        "directoryTree",
+				Relation:    ocmv1.LocalRelation,
+			},
+			Access: localblob.New("sha256.raw", "", "application/x-tar", nil),
+		},
+	}
+	return descriptor
+}
@@ -0,0 +1,31 @@
+package componentdescriptor
+
+import (
+	"fmt"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+
+	"github.com/kyma-project/modulectl/internal/common"
+	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+)
+
+// AddProvenanceLabels adds the provenance entries as labels to the component descriptor.
+func AddProvenanceLabels(descriptor *compdesc.ComponentDescriptor, labels []provenance.Label) error {
+	for _, label := range labels {
+		provenanceLabel, err := ocmv1.NewLabel(label.Name, label.Value, ocmv1.WithVersion(common.VersionV1))
+		if err != nil {
+			return fmt.Errorf("failed to create provenance label %s: %w", label.Name, err)
+		}
+		descriptor.Labels = append(descriptor.Labels, *provenanceLabel)
+	}
+	return nil
+}
+
+// AddProvenanceLabelsToConstructor adds the provenance entries as labels to the component of the constructor.
+func AddProvenanceLabelsToConstructor(constructor *component.Constructor, labels []provenance.Label) {
+	for _, label := range labels {
+		constructor.AddLabel(label.Name, label.Value, common.VersionV1)
+	}
+}
@@ -0,0 +1,43 @@
+package componentdescriptor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+)
+
+var provenanceLabels = []provenance.Label{
+	{Name: provenance.ModulectlVersionKey, Value: "1.2.0"},
+	{Name: provenance.GitCommitKey, Value: "4c2e3f1d"},
+}
+
+func Test_AddProvenanceLabels_AddsLabelsToComponent(t *testing.T) {
+	descriptor, err := componentdescriptor.InitializeComponentDescriptor("github.com/test-module", "0.0.1", true)
+	require.NoError(t, err)
+
+	err = componentdescriptor.AddProvenanceLabels(descriptor, provenanceLabels)
+
+	require.NoError(t, err)
+	require.Len(t, descriptor.Labels, 3)
+	require.Equal(t, "provenance.kyma-project.io/modulectl-version", descriptor.Labels[1].Name)
+	require.Equal(t, json.RawMessage(`"1.2.0"`), descriptor.Labels[1].Value)
+	require.Equal(t, "v1", descriptor.Labels[1].Version)
+	require.Equal(t, "provenance.kyma-project.io/git-commit", descriptor.Labels[2].Name)
+	require.Equal(t, json.RawMessage(`"4c2e3f1d"`), descriptor.Labels[2].Value)
+}
+
+func Test_AddProvenanceLabelsToConstructor_AddsLabelsToComponent(t *testing.T) {
+	constructor := component.NewConstructor("github.com/test-module", "0.0.1")
+
+	componentdescriptor.AddProvenanceLabelsToConstructor(constructor, provenanceLabels)
+
+	require.Equal(t, []component.Label{
+		{Name: "provenance.kyma-project.io/modulectl-version", Value: "1.2.0", Version: "v1"},
+		{Name: "provenance.kyma-project.io/git-commit", Value: "4c2e3f1d", Version: "v1"},
+	}, constructor.Components[0].Labels)
+}
@@ -13,18 +13,22 @@ const tarMediaType = "application/x-tar"
 var ErrNilFileSystem = errors.New("file system must not be nil")
 
 type TarGenerator interface {
-	ArchiveFile(filePath string) ([]byte, error)
+	ArchiveFile(filePath, entryName string) ([]byte, error)
 }
 
 type Tar struct {
 	generator TarGenerator
 	path      string
+	entryName string
 }
 
-func NewTar(fs TarGenerator, path string) *Tar {
+// NewTar returns an access handler archiving the file of the path under the entry name, which is independent of
+// where the file is stored, e.g. in a random temporary file.
+func NewTar(fs TarGenerator, path, entryName string) *Tar {
 	return &Tar{
 		generator: fs,
 		path:      path,
+		entryName: entryName,
 	}
 }
 
@@ -32,12 +36,16 @@ func (tarAccessHandler *Tar) GetPath() string {
 	return tarAccessHandler.path
 }
 
+func (tarAccessHandler *Tar) GetEntryName() string {
+	return tarAccessHandler.entryName
+}
+
 func (tarAccessHandler *Tar) GenerateBlobAccess() (cpi.BlobAccess, error) {
 	if tarAccessHandler.generator == nil {
 		return nil, ErrNilFileSystem
 	}
 
-	tarData, err := tarAccessHandler.generator.ArchiveFile(tarAccessHandler.path)
+	tarData, err := tarAccessHandler.generator.ArchiveFile(tarAccessHandler.path, tarAccessHandler.entryName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tar file access, %w", err)
 	}
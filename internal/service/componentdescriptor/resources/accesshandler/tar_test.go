@@ -16,15 +16,17 @@ func TestTar_GenerateBlobAccess(t *testing.T) {
 		// given
 		expectedBytes := []byte{1, 2, 3, 4, 5, 0, 0, 0, 0}
 		mockFS := &mockArchiveFileSystem{
-			generateTarFunc: func(path string) ([]byte, error) {
+			generateTarFunc: func(path, entryName string) ([]byte, error) {
 				assert.Equal(t, "test/path", path)
+				assert.Equal(t, "file.yaml", entryName)
 				return expectedBytes, nil
 			},
 		}
 
-		tar := accesshandler.NewTar(mockFS, "test/path")
+		tar := accesshandler.NewTar(mockFS, "test/path", "file.yaml")
 
 		assert.Equal(t, "test/path", tar.GetPath())
+		assert.Equal(t, "file.yaml", tar.GetEntryName())
 
 		// when
 		blobAccess, err := tar.GenerateBlobAccess()
@@ -41,7 +43,7 @@ func TestTar_GenerateBlobAccess(t *testing.T) {
 
 	t.Run("should return error when file system is nil", func(t *testing.T) {
 		// given
-		tar := accesshandler.NewTar(nil, "test/path")
+		tar := accesshandler.NewTar(nil, "test/path", "file.yaml")
 
 		// when
 		blobAccess, err := tar.GenerateBlobAccess()
@@ -56,12 +58,12 @@ func TestTar_GenerateBlobAccess(t *testing.T) {
 		// given
 		expectedError := errors.New("generation failed")
 		mockFS := &mockArchiveFileSystem{
-			generateTarFunc: func(path string) ([]byte, error) {
+			generateTarFunc: func(path, entryName string) ([]byte, error) {
 				return nil, expectedError
 			},
 		}
 
-		tar := accesshandler.NewTar(mockFS, "test/path")
+		tar := accesshandler.NewTar(mockFS, "test/path", "file.yaml")
 
 		// when
 		blobAccess, err := tar.GenerateBlobAccess()
@@ -75,9 +77,9 @@ func TestTar_GenerateBlobAccess(t *testing.T) {
 }
 
 type mockArchiveFileSystem struct {
-	generateTarFunc func(path string) ([]byte, error)
+	generateTarFunc func(path, entryName string) ([]byte, error)
 }
 
-func (m *mockArchiveFileSystem) ArchiveFile(filePath string) ([]byte, error) {
-	return m.generateTarFunc(filePath)
+func (m *mockArchiveFileSystem) ArchiveFile(filePath, entryName string) ([]byte, error) {
+	return m.generateTarFunc(filePath, entryName)
 }
@@ -15,6 +15,12 @@ import (
 
 var ErrNilTarGenerator = errors.New("tarGenerator must not be nil")
 
+// The files are archived under fixed names, as their paths may be random temporary files.
+const (
+	rawManifestEntryName = common.RawManifestResourceName + ".yaml"
+	defaultCREntryName   = common.DefaultCRResourceName + ".yaml"
+)
+
 type Service struct {
 	tarGenerator accesshandler.TarGenerator
 }
@@ -80,7 +86,7 @@ func GenerateRawManifestResource(tarGen accesshandler.TarGenerator, manifestPath
 				Relation: ocmv1.LocalRelation,
 			},
 		},
-		AccessHandler: accesshandler.NewTar(tarGen, manifestPath),
+		AccessHandler: accesshandler.NewTar(tarGen, manifestPath, rawManifestEntryName),
 	}
 }
 
@@ -95,6 +101,6 @@ func GenerateDefaultCRResource(tarGen accesshandler.TarGenerator, defaultCRPath
 				Relation: ocmv1.LocalRelation,
 			},
 		},
-		AccessHandler: accesshandler.NewTar(tarGen, defaultCRPath),
+		AccessHandler: accesshandler.NewTar(tarGen, defaultCRPath, defaultCREntryName),
 	}
 }
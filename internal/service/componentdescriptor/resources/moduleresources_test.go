@@ -39,6 +39,7 @@ func TestGenerateModuleResources_ReturnCorrectResourcesWithDefaultCRPath(t *test
 	manifestResourceHandler, ok := res[1].AccessHandler.(*accesshandler.Tar)
 	require.True(t, ok)
 	require.Equal(t, "path/to/manifest", manifestResourceHandler.GetPath())
+	require.Equal(t, "raw-manifest.yaml", manifestResourceHandler.GetEntryName())
 
 	require.Equal(t, "default-cr", res[2].Name)
 	require.Equal(t, "directoryTree", res[2].Type)
@@ -46,6 +47,7 @@ func TestGenerateModuleResources_ReturnCorrectResourcesWithDefaultCRPath(t *test
 	defaultCRResourceHandler, ok := res[2].AccessHandler.(*accesshandler.Tar)
 	require.True(t, ok)
 	require.Equal(t, "path/to/defaultCR", defaultCRResourceHandler.GetPath())
+	require.Equal(t, "default-cr.yaml", defaultCRResourceHandler.GetEntryName())
 
 	for _, resource := range res {
 		require.Equal(t, "1.0.0", resource.Version)
@@ -74,6 +76,7 @@ func TestGenerateModuleResources_ReturnCorrectResourcesWithoutDefaultCRPath(t *t
 	manifestResourceHandler, ok := res[1].AccessHandler.(*accesshandler.Tar)
 	require.True(t, ok)
 	require.Equal(t, "path/to/manifest", manifestResourceHandler.GetPath())
+	require.Equal(t, "raw-manifest.yaml", manifestResourceHandler.GetEntryName())
 
 	for _, resource := range res {
 		require.Equal(t, "1.0.0", resource.Version)
@@ -103,6 +106,7 @@ func TestGenerateModuleResources_ReturnCorrectResources(t *testing.T) {
 	manifestResourceHandler, ok := res[1].AccessHandler.(*accesshandler.Tar)
 	require.True(t, ok)
 	require.Equal(t, "path/to/manifest", manifestResourceHandler.GetPath())
+	require.Equal(t, "raw-manifest.yaml", manifestResourceHandler.GetEntryName())
 
 	require.Equal(t, "default-cr", res[2].Name)
 	require.Equal(t, "directoryTree", res[2].Type)
@@ -110,6 +114,7 @@ func TestGenerateModuleResources_ReturnCorrectResources(t *testing.T) {
 	defaultCRResourceHandler, ok := res[2].AccessHandler.(*accesshandler.Tar)
 	require.True(t, ok)
 	require.Equal(t, "path/to/defaultCR", defaultCRResourceHandler.GetPath())
+	require.Equal(t, "default-cr.yaml", defaultCRResourceHandler.GetEntryName())
 
 	for _, resource := range res {
 		require.Equal(t, "1.0.0", resource.Version)
@@ -155,6 +160,6 @@ func TestResourceGenerators(t *testing.T) {
 
 type fileSystemStub struct{}
 
-func (m fileSystemStub) ArchiveFile(_ string) ([]byte, error) {
+func (m fileSystemStub) ArchiveFile(_, _ string) ([]byte, error) {
 	return nil, nil
 }
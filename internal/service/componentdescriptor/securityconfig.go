@@ -3,13 +3,27 @@ package componentdescriptor
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"gopkg.in/yaml.v3"
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 )
 
+const (
+	rcTagLabelKey       = "rc-tag"
+	languageLabelKey    = "language"
+	devBranchLabelKey   = "dev-branch"
+	subProjectsLabelKey = "subprojects"
+	excludeLabelKey     = "exclude"
+	bdbaLabelKey        = "bdba"
+)
+
 var ErrSecurityConfigFileDoesNotExist = errors.New("security config file does not exist")
 
 type FileReader interface {
@@ -55,3 +69,60 @@ func (s *SecurityConfigService) ParseSecurityConfigData(securityConfigFile strin
 
 	return securityConfig, nil
 }
+
+// AppendSecurityLabelsToSources adds the security scanner settings as labels to all sources of the descriptor.
+func (s *SecurityConfigService) AppendSecurityLabelsToSources(descriptor *compdesc.ComponentDescriptor,
+	securityConfig *contentprovider.SecurityScanConfig,
+) error {
+	for index := range descriptor.Sources {
+		source := &descriptor.Sources[index]
+		for _, label := range securityLabels(securityConfig) {
+			ocmLabel, err := ocmv1.NewLabel(label.Name, label.Value, ocmv1.WithVersion(label.Version))
+			if err != nil {
+				return fmt.Errorf("failed to create security label %s: %w", label.Name, err)
+			}
+			source.Labels = append(source.Labels, *ocmLabel)
+		}
+	}
+
+	return nil
+}
+
+// AppendSecurityLabelsToConstructorSources adds the security scanner settings as labels to all sources of the
+// component constructor.
+func (s *SecurityConfigService) AppendSecurityLabelsToConstructorSources(constructor *component.Constructor,
+	securityConfig *contentprovider.SecurityScanConfig,
+) {
+	for _, label := range securityLabels(securityConfig) {
+		constructor.AddLabelToSources(label.Name, label.Value, label.Version)
+	}
+}
+
+// securityLabels returns the labels for all non-empty Mend and BDBA settings in a stable order.
+func securityLabels(securityConfig *contentprovider.SecurityScanConfig) []component.Label {
+	values := []struct {
+		key   string
+		value string
+	}{
+		{rcTagLabelKey, securityConfig.RcTag},
+		{languageLabelKey, securityConfig.Mend.Language},
+		{devBranchLabelKey, securityConfig.DevBranch},
+		{subProjectsLabelKey, securityConfig.Mend.SubProjects},
+		{excludeLabelKey, strings.Join(securityConfig.Mend.Exclude, ",")},
+		{bdbaLabelKey, strings.Join(securityConfig.BDBA, ",")},
+	}
+
+	labels := make([]component.Label, 0, len(values))
+	for _, entry := range values {
+		if entry.value == "" {
+			continue
+		}
+		labels = append(labels, component.Label{
+			Name:    fmt.Sprintf("%s/%s", common.SecScanBaseLabelKey, entry.key),
+			Value:   entry.value,
+			Version: common.OCMVersion,
+		})
+	}
+
+	return labels
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+	"ocm.software/ocm/api/ocm/compdesc"
 
+	"github.com/kyma-project/modulectl/internal/common/types/component"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 )
@@ -54,6 +56,45 @@ func TestSecurityConfigService_ParseSecurityConfigData_ReturnErrOnFileDoesNotExi
 	require.ErrorContains(t, err, "security config file does not exist")
 }
 
+func TestSecurityConfigService_AppendSecurityLabelsToSources_AddsNonEmptyLabels(t *testing.T) {
+	securityConfigService, err := componentdescriptor.NewSecurityConfigService(&fileReaderStub{})
+	require.NoError(t, err)
+	descriptor := &compdesc.ComponentDescriptor{}
+	descriptor.Sources = append(descriptor.Sources, compdesc.Source{
+		SourceMeta: compdesc.SourceMeta{ElementMeta: compdesc.ElementMeta{Name: "module-sources"}},
+	})
+
+	err = securityConfigService.AppendSecurityLabelsToSources(descriptor, &securityScanConfig)
+	require.NoError(t, err)
+
+	labels := descriptor.Sources[0].Labels
+	require.Len(t, labels, 4)
+	require.Equal(t, "scan.security.kyma-project.io/rc-tag", labels[0].Name)
+	require.JSONEq(t, `"1.2.3"`, string(labels[0].Value))
+	require.Equal(t, "scan.security.kyma-project.io/language", labels[1].Name)
+	require.Equal(t, "scan.security.kyma-project.io/exclude", labels[2].Name)
+	require.JSONEq(t, `"**/test/**,**/*_test.go"`, string(labels[2].Value))
+	require.Equal(t, "scan.security.kyma-project.io/bdba", labels[3].Name)
+	require.JSONEq(t, `"image1:1.2.3,image2:4.5.6"`, string(labels[3].Value))
+}
+
+func TestSecurityConfigService_AppendSecurityLabelsToConstructorSources_AddsNonEmptyLabels(t *testing.T) {
+	securityConfigService, err := componentdescriptor.NewSecurityConfigService(&fileReaderStub{})
+	require.NoError(t, err)
+	constructor := component.NewConstructor("kyma-project.io/module/test", "1.2.3")
+	constructor.AddGitSource("https://github.com/kyma-project/test", "abc123")
+
+	securityConfigService.AppendSecurityLabelsToConstructorSources(constructor, &securityScanConfig)
+
+	labels := constructor.Components[0].Sources[0].Labels
+	require.Len(t, labels, 4)
+	require.Equal(t, component.Label{
+		Name:    "scan.security.kyma-project.io/language",
+		Value:   "golang-mod",
+		Version: "v1",
+	}, labels[1])
+}
+
 type fileReaderStub struct{}
 
 func (*fileReaderStub) FileExists(_ string) (bool, error) {
@@ -65,6 +106,15 @@ func (*fileReaderStub) ReadFile(_ string) ([]byte, error) {
 	return securityConfigBytes, nil
 }
 
+var securityScanConfig = contentprovider.SecurityScanConfig{
+	RcTag: "1.2.3",
+	BDBA:  []string{"image1:1.2.3", "image2:4.5.6"},
+	Mend: contentprovider.MendSecConfig{
+		Language: "golang-mod",
+		Exclude:  []string{"**/test/**", "**/*_test.go"},
+	},
+}
+
 var securityConfig = contentprovider.SecurityScanConfig{
 	ModuleName: "test-module",
 	BDBA:       []string{"image1", "image2"},
@@ -0,0 +1,193 @@
+package contentprovider
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/image"
+)
+
+// ImageLocation declares where images are referenced in resources of a kind that modulectl does not know,
+// e.g. the image fields of an operator CR.
+type ImageLocation struct {
+	metav1.GroupVersionKind `comment:"required, the GVK of the resources containing the images" yaml:",inline"`
+
+	Paths []string `comment:"required, JSONPath expressions selecting the image references, e.g. .spec.image or .spec.components[*].image" yaml:"paths"`
+}
+
+// ImageExtractor collects the image references of a resource into the image set.
+type ImageExtractor interface {
+	ExtractImages(resource *unstructured.Unstructured, imageSet map[string]struct{}) error
+}
+
+// ImageExtractorRegistry selects the image extractors of a resource by its GroupVersionKind. The extractors
+// of the built-in workload kinds apply to all versions of the kind.
+type ImageExtractorRegistry struct {
+	kindExtractors map[schema.GroupKind][]ImageExtractor
+	extractors     map[schema.GroupVersionKind][]ImageExtractor
+}
+
+// NewImageExtractorRegistry returns a registry that extracts the images of all built-in workload kinds.
+func NewImageExtractorRegistry() *ImageExtractorRegistry {
+	registry := &ImageExtractorRegistry{
+		kindExtractors: make(map[schema.GroupKind][]ImageExtractor),
+		extractors:     make(map[schema.GroupVersionKind][]ImageExtractor),
+	}
+	for groupKind, podSpecPath := range workloadPodSpecPaths() {
+		registry.RegisterKind(groupKind, &PodSpecImageExtractor{podSpecPath: podSpecPath})
+	}
+	return registry
+}
+
+// workloadPodSpecPaths is keyed by group and kind, so that older API versions still served by a cluster,
+// e.g. batch/v1beta1 CronJobs, are not skipped.
+func workloadPodSpecPaths() map[schema.GroupKind][]string {
+	templatePodSpec := []string{"spec", "template", "spec"}
+	return map[schema.GroupKind][]string{
+		{Group: "apps", Kind: KindDeployment}:  templatePodSpec,
+		{Group: "apps", Kind: KindStatefulSet}: templatePodSpec,
+		{Group: "apps", Kind: "DaemonSet"}:     templatePodSpec,
+		{Group: "apps", Kind: "ReplicaSet"}:    templatePodSpec,
+		{Group: "batch", Kind: "Job"}:          templatePodSpec,
+		{Group: "batch", Kind: "CronJob"}:      {"spec", "jobTemplate", "spec", "template", "spec"},
+		{Group: "", Kind: "Pod"}:               {"spec"},
+	}
+}
+
+// Register adds an extractor for resources of the given kind, in addition to the already registered ones.
+func (r *ImageExtractorRegistry) Register(gvk schema.GroupVersionKind, extractor ImageExtractor) {
+	r.extractors[gvk] = append(r.extractors[gvk], extractor)
+}
+
+// RegisterKind adds an extractor for resources of the given kind in any version.
+func (r *ImageExtractorRegistry) RegisterKind(groupKind schema.GroupKind, extractor ImageExtractor) {
+	r.kindExtractors[groupKind] = append(r.kindExtractors[groupKind], extractor)
+}
+
+// RegisterImageLocations adds a JSONPath extractor for each of the image locations.
+func (r *ImageExtractorRegistry) RegisterImageLocations(imageLocations []ImageLocation) error {
+	for _, location := range imageLocations {
+		extractor, err := NewJSONPathImageExtractor(location.Paths)
+		if err != nil {
+			return fmt.Errorf("invalid image location for %s: %w", location.GroupVersionKind.String(), err)
+		}
+		r.Register(schema.GroupVersionKind(location.GroupVersionKind), extractor)
+	}
+	return nil
+}
+
+// ExtractImages runs all extractors registered for the kind of the resource, other resources are ignored.
+func (r *ImageExtractorRegistry) ExtractImages(resource *unstructured.Unstructured,
+	imageSet map[string]struct{},
+) error {
+	gvk := resource.GroupVersionKind()
+	extractors := append(append([]ImageExtractor{}, r.kindExtractors[gvk.GroupKind()]...), r.extractors[gvk]...)
+	for _, extractor := range extractors {
+		if err := extractor.ExtractImages(resource, imageSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PodSpecImageExtractor extracts the images of the containers, init containers and ephemeral containers
+// of the pod spec at the given path, including image references in the container env values.
+type PodSpecImageExtractor struct {
+	podSpecPath []string
+}
+
+func (e *PodSpecImageExtractor) ExtractImages(resource *unstructured.Unstructured,
+	imageSet map[string]struct{},
+) error {
+	for _, containersField := range []string{"containers", "initContainers", "ephemeralContainers"} {
+		path := append(append([]string{}, e.podSpecPath...), containersField)
+		if err := extractFromContainers(resource, imageSet, path...); err != nil {
+			return fmt.Errorf("failed to extract from %s: %w", containersField, err)
+		}
+	}
+	return nil
+}
+
+// JSONPathImageExtractor extracts the images selected by JSONPath expressions.
+// Every selected value must be a valid image reference.
+type JSONPathImageExtractor struct {
+	paths       []string
+	expressions []*jsonpath.JSONPath
+}
+
+// NewJSONPathImageExtractor parses the paths, which may be given with or without the surrounding braces,
+// e.g. "{.spec.image}" or ".spec.image".
+func NewJSONPathImageExtractor(paths []string) (*JSONPathImageExtractor, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("paths must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	extractor := &JSONPathImageExtractor{paths: paths}
+	for _, path := range paths {
+		expression, err := ParseImageJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		extractor.expressions = append(extractor.expressions, expression)
+	}
+	return extractor, nil
+}
+
+// ParseImageJSONPath parses a JSONPath expression selecting image references.
+func ParseImageJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("JSONPath must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	expression := jsonpath.New(path).AllowMissingKeys(true)
+	if err := expression.Parse(relaxedJSONPath(path)); err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q: %v: %w", path, err, commonerrors.ErrInvalidOption)
+	}
+	return expression, nil
+}
+
+func relaxedJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+func (e *JSONPathImageExtractor) ExtractImages(resource *unstructured.Unstructured,
+	imageSet map[string]struct{},
+) error {
+	for i, expression := range e.expressions {
+		results, err := expression.FindResults(resource.Object)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate JSONPath %q: %w", e.paths[i], err)
+		}
+
+		for _, result := range results {
+			for _, value := range result {
+				img, ok := value.Interface().(string)
+				if !ok {
+					return fmt.Errorf("JSONPath %q selects a %T instead of an image reference: %w",
+						e.paths[i], value.Interface(), commonerrors.ErrInvalidOption)
+				}
+				if img == "" {
+					continue
+				}
+				if _, err := image.ValidateAndParseImageInfo(img); err != nil {
+					return fmt.Errorf("invalid image %q at JSONPath %q: %w", img, e.paths[i], err)
+				}
+				imageSet[img] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
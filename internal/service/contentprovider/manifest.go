@@ -37,7 +37,14 @@ func (m *Manifest) GetDefaultContent(_ types.KeyValueArgs) (string, error) {
 `, nil
 }
 
-func (m *Manifest) ExtractImagesFromManifest(manifestPath string) ([]string, error) {
+// ExtractImagesFromManifest extracts the images of all workloads of the manifest and of the additional
+// image locations, e.g. the image fields of custom resources.
+func (m *Manifest) ExtractImagesFromManifest(manifestPath string, imageLocations []ImageLocation) ([]string, error) {
+	extractors := NewImageExtractorRegistry()
+	if err := extractors.RegisterImageLocations(imageLocations); err != nil {
+		return nil, fmt.Errorf("failed to register image locations: %w", err)
+	}
+
 	manifests, err := m.manifestParser.Parse(manifestPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse manifest at %q: %w", manifestPath, err)
@@ -45,7 +52,7 @@ func (m *Manifest) ExtractImagesFromManifest(manifestPath string) ([]string, err
 
 	imageSet := make(map[string]struct{})
 	for _, manifest := range manifests {
-		if err = extractImages(manifest, imageSet); err != nil {
+		if err = extractors.ExtractImages(manifest, imageSet); err != nil {
 			return nil, fmt.Errorf("failed to extract images from %q kind: %w", manifest.GetKind(), err)
 		}
 	}
@@ -53,23 +60,6 @@ func (m *Manifest) ExtractImagesFromManifest(manifestPath string) ([]string, err
 	return slices.SetToSlice(imageSet), nil
 }
 
-func extractImages(manifest *unstructured.Unstructured, imageSet map[string]struct{}) error {
-	kind := manifest.GetKind()
-	if kind != KindDeployment && kind != KindStatefulSet {
-		return nil
-	}
-
-	if err := extractFromContainers(manifest, imageSet, "spec", "template", "spec", "containers"); err != nil {
-		return fmt.Errorf("failed to extract from containers: %w", err)
-	}
-
-	if err := extractFromContainers(manifest, imageSet, "spec", "template", "spec", "initContainers"); err != nil {
-		return fmt.Errorf("failed to extract from initContainers: %w", err)
-	}
-
-	return nil
-}
-
 func extractFromContainers(manifest *unstructured.Unstructured, imageSet map[string]struct{}, path ...string) error {
 	containers, found, _ := unstructured.NestedSlice(manifest.Object, path...)
 	if !found {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/kyma-project/modulectl/internal/common/types"
@@ -72,7 +73,7 @@ func TestExtractImagesFromManifest_Deployment(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 2)
 	require.Contains(t, images, "app:v1.0.0")
@@ -90,7 +91,7 @@ func TestExtractImagesFromManifest_StatefulSet(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 1)
 	require.Contains(t, images, "postgres:13")
@@ -109,7 +110,7 @@ func TestExtractImagesFromManifest_InitContainers(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 2)
 	require.Contains(t, images, "app:v1.0.0")
@@ -134,7 +135,7 @@ func TestExtractImagesFromManifest_EnvImages(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 3)
 	require.Contains(t, images, "app:v1.0.0")
@@ -152,7 +153,7 @@ func TestExtractImagesFromManifest_DisallowedTag(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.Error(t, err)
 	require.Nil(t, images)
 	require.Contains(t, err.Error(), "image tag is disallowed")
@@ -171,7 +172,7 @@ func TestExtractImagesFromManifest_DuplicateImages(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 1)
 	require.Contains(t, images, "shared:v1.0.0")
@@ -183,7 +184,7 @@ func TestExtractImagesFromManifest_ParserError(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.Error(t, err)
 	require.Nil(t, images)
 	require.Contains(t, err.Error(), "failed to parse manifest")
@@ -198,7 +199,7 @@ func TestExtractImagesFromManifest_EmptyContainers(t *testing.T) {
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Empty(t, images)
 }
@@ -215,7 +216,7 @@ func TestExtractImagesFromManifest_UnsupportedResourceType_IgnoresResource(t *te
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
 
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Len(t, images, 1)
 	require.Contains(t, images, "app:v1.0.0")
@@ -226,7 +227,8 @@ func TestExtractImagesFromManifest_MalformedContainer(t *testing.T) {
 		manifests: []*unstructured.Unstructured{
 			{
 				Object: map[string]interface{}{
-					"kind": "Deployment",
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
 					"spec": map[string]interface{}{
 						"template": map[string]interface{}{
 							"spec": map[string]interface{}{
@@ -239,11 +241,156 @@ func TestExtractImagesFromManifest_MalformedContainer(t *testing.T) {
 		},
 	}
 	manifest, _ := contentprovider.NewManifest(mockParser)
-	images, err := manifest.ExtractImagesFromManifest("test.yaml")
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
 	require.NoError(t, err)
 	require.Empty(t, images)
 }
 
+func TestExtractImagesFromManifest_AllWorkloadKinds(t *testing.T) {
+	templatePodSpec := []string{"spec", "template", "spec"}
+	tests := []struct {
+		name        string
+		apiVersion  string
+		kind        string
+		podSpecPath []string
+	}{
+		{name: "DaemonSet", apiVersion: "apps/v1", kind: "DaemonSet", podSpecPath: templatePodSpec},
+		{name: "ReplicaSet", apiVersion: "apps/v1", kind: "ReplicaSet", podSpecPath: templatePodSpec},
+		{name: "Job", apiVersion: "batch/v1", kind: "Job", podSpecPath: templatePodSpec},
+		{
+			name:        "CronJob",
+			apiVersion:  "batch/v1",
+			kind:        "CronJob",
+			podSpecPath: []string{"spec", "jobTemplate", "spec", "template", "spec"},
+		},
+		{
+			name:        "CronJob of an older API version",
+			apiVersion:  "batch/v1beta1",
+			kind:        "CronJob",
+			podSpecPath: []string{"spec", "jobTemplate", "spec", "template", "spec"},
+		},
+		{name: "Deployment of an older API version", apiVersion: "apps/v1beta2", kind: "Deployment",
+			podSpecPath: templatePodSpec},
+		{name: "Pod", apiVersion: "v1", kind: "Pod", podSpecPath: []string{"spec"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := &mockManifestParser{
+				manifests: []*unstructured.Unstructured{
+					createWorkload(tt.apiVersion, tt.kind, tt.podSpecPath, map[string]interface{}{
+						"containers": createContainers([]containerSpec{{name: "app", image: "app:v1.0.0"}}),
+						"initContainers": createContainers([]containerSpec{
+							{name: "init", image: "init:v1.0.0"},
+						}),
+						"ephemeralContainers": createContainers([]containerSpec{
+							{name: "debug", image: "busybox:1.36.1"},
+						}),
+					}),
+				},
+			}
+			manifest, _ := contentprovider.NewManifest(mockParser)
+
+			images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"app:v1.0.0", "init:v1.0.0", "busybox:1.36.1"}, images)
+		})
+	}
+}
+
+func TestExtractImagesFromManifest_UnknownAPIGroup_IgnoresResource(t *testing.T) {
+	mockParser := &mockManifestParser{
+		manifests: []*unstructured.Unstructured{
+			createWorkload("example.com/v1", "Deployment", []string{"spec", "template", "spec"},
+				map[string]interface{}{
+					"containers": createContainers([]containerSpec{{name: "app", image: "app:v1.0.0"}}),
+				}),
+		},
+	}
+	manifest, _ := contentprovider.NewManifest(mockParser)
+
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", nil)
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func TestExtractImagesFromManifest_ImageLocations(t *testing.T) {
+	mockParser := &mockManifestParser{
+		manifests: []*unstructured.Unstructured{
+			createCustomResource(map[string]interface{}{
+				"image": "europe-docker.pkg.dev/kyma-project/prod/operator:1.0.0",
+				"components": []interface{}{
+					map[string]interface{}{"image": "component-a:2.0.0"},
+					map[string]interface{}{"image": "component-b:3.0.0"},
+				},
+			}),
+			createDeployment("app", []containerSpec{{name: "app", image: "app:v1.0.0"}}),
+		},
+	}
+	manifest, _ := contentprovider.NewManifest(mockParser)
+
+	images, err := manifest.ExtractImagesFromManifest("test.yaml", []contentprovider.ImageLocation{
+		{
+			GroupVersionKind: customResourceGVK(),
+			Paths:            []string{"{.spec.image}", ".spec.components[*].image", "spec.missing"},
+		},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		"europe-docker.pkg.dev/kyma-project/prod/operator:1.0.0",
+		"component-a:2.0.0",
+		"component-b:3.0.0",
+		"app:v1.0.0",
+	}, images)
+}
+
+func TestExtractImagesFromManifest_ImageLocations_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   map[string]interface{}
+		paths  []string
+		errMsg string
+	}{
+		{
+			name:   "invalid JSONPath",
+			spec:   map[string]interface{}{"image": "operator:1.0.0"},
+			paths:  []string{".spec.image["},
+			errMsg: `invalid JSONPath ".spec.image["`,
+		},
+		{
+			name:   "no paths",
+			spec:   map[string]interface{}{"image": "operator:1.0.0"},
+			paths:  nil,
+			errMsg: "paths must not be empty",
+		},
+		{
+			name:   "value is not a string",
+			spec:   map[string]interface{}{"image": map[string]interface{}{"repository": "operator"}},
+			paths:  []string{".spec.image"},
+			errMsg: `JSONPath ".spec.image" selects a map[string]interface {} instead of an image reference`,
+		},
+		{
+			name:   "value is not a valid image",
+			spec:   map[string]interface{}{"image": "operator:latest"},
+			paths:  []string{".spec.image"},
+			errMsg: `invalid image "operator:latest" at JSONPath ".spec.image"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockParser := &mockManifestParser{
+				manifests: []*unstructured.Unstructured{createCustomResource(tt.spec)},
+			}
+			manifest, _ := contentprovider.NewManifest(mockParser)
+
+			images, err := manifest.ExtractImagesFromManifest("test.yaml", []contentprovider.ImageLocation{
+				{GroupVersionKind: customResourceGVK(), Paths: tt.paths},
+			})
+			require.ErrorContains(t, err, tt.errMsg)
+			require.Nil(t, images)
+		})
+	}
+}
+
 type mockManifestParser struct {
 	manifests []*unstructured.Unstructured
 	err       error
@@ -271,7 +418,8 @@ type envVar struct {
 func createDeployment(name string, containers []containerSpec) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
-			"kind": "Deployment",
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
 			"metadata": map[string]interface{}{
 				"name": name,
 			},
@@ -289,7 +437,8 @@ func createDeployment(name string, containers []containerSpec) *unstructured.Uns
 func createStatefulSet(name string, containers []containerSpec) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
-			"kind": "StatefulSet",
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
 			"metadata": map[string]interface{}{
 				"name": name,
 			},
@@ -311,7 +460,8 @@ func createDeploymentWithInitContainers(
 ) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
-			"kind": "Deployment",
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
 			"metadata": map[string]interface{}{
 				"name": name,
 			},
@@ -330,7 +480,8 @@ func createDeploymentWithInitContainers(
 func createDeploymentWithEnvImages(containers []containerSpec) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
-			"kind": "Deployment",
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
 			"metadata": map[string]interface{}{
 				"name": "app",
 			},
@@ -377,3 +528,38 @@ func createUnsupportedResource(kind, name string) *unstructured.Unstructured {
 		},
 	}
 }
+
+func createWorkload(apiVersion, kind string, podSpecPath []string,
+	podSpec map[string]interface{},
+) *unstructured.Unstructured {
+	workload := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": "workload",
+			},
+		},
+	}
+	if err := unstructured.SetNestedField(workload.Object, podSpec, podSpecPath...); err != nil {
+		panic(err)
+	}
+	return workload
+}
+
+func customResourceGVK() metav1.GroupVersionKind {
+	return metav1.GroupVersionKind{Group: "operator.kyma-project.io", Version: "v1alpha1", Kind: "Sample"}
+}
+
+func createCustomResource(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1alpha1",
+			"kind":       "Sample",
+			"metadata": map[string]interface{}{
+				"name": "sample",
+			},
+			"spec": spec,
+		},
+	}
+}
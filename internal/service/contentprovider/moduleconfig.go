@@ -3,6 +3,8 @@ package contentprovider
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -53,7 +55,7 @@ func (s *ModuleConfigProvider) getModuleConfig(args types.KeyValueArgs) (*Module
 	return &ModuleConfig{
 		Name:      args[ArgModuleName],
 		Version:   args[ArgModuleVersion],
-		Manifest:  manifest,
+		Manifest:  NewUrlOrLocalFiles(manifest),
 		Security:  args[ArgSecurityConfigFile],
 		DefaultCR: defaultCR,
 	}, nil
@@ -80,23 +82,27 @@ func (s *ModuleConfigProvider) validateArgs(args types.KeyValueArgs) error {
 }
 
 type ModuleConfig struct {
-	Name                string                     `comment:"required, the name of the module"                                                                                                  yaml:"name"`
-	Version             string                     `comment:"required, the version of the module"                                                                                               yaml:"version"`
-	Manifest            UrlOrLocalFile             `comment:"required, reference to the manifest, must be a URL or a local file path"                                                           yaml:"manifest"`
-	Repository          string                     `comment:"required, reference to the repository, must be a URL"                                                                              yaml:"repository"`
-	Documentation       string                     `comment:"required, reference to the documentation, must be a URL"                                                                           yaml:"documentation"`
-	Icons               Icons                      `comment:"required, icons used for UI"                                                                                                       yaml:"icons,omitempty"`
-	DefaultCR           UrlOrLocalFile             `comment:"optional, reference to a YAML file containing the default CR for the module, must be a URL or a local file path"                   yaml:"defaultCR"` //nolint:tagliatelle // prefer defaultCR over defaultCr
-	Security            string                     `comment:"optional, reference to a YAML file containing the security scanners config, must be a local file path"                             yaml:"security"`
-	SecurityScanEnabled *bool                      `comment:"optional, default=true, indicates whether security scanning labels should be added to the OCM descriptor"                          yaml:"securityScanEnabled"`
-	Labels              map[string]string          `comment:"optional, additional labels for the generated ModuleTemplate CR"                                                                   yaml:"labels"`
-	Annotations         map[string]string          `comment:"optional, additional annotations for the generated ModuleTemplate CR"                                                              yaml:"annotations"`
-	Manager             *Manager                   `comment:"optional, module resource that indicates the installation readiness of the module, typically the manager deployment of the module" yaml:"manager"`
-	AssociatedResources []*metav1.GroupVersionKind `comment:"optional, optional, resources that should be cleaned up with the module deletion"                                                  yaml:"associatedResources"`
-	Resources           Resources                  `comment:"optional, additional resources of the module that may be fetched"                                                                  yaml:"resources,omitempty"`
-	RequiresDowntime    bool                       `comment:"optional, default=false, indicates whether the module requires downtime to support maintenance windows during module upgrades"     yaml:"requiresDowntime"`
-	Internal            bool                       `comment:"optional, default=false, indicates whether the module is internal"                                                                 yaml:"internal"`
-	Beta                bool                       `comment:"optional, default=false, indicates whether the module is beta"                                                                     yaml:"beta"`
+	Name                string                     `comment:"required, the name of the module"                                                                                                                           yaml:"name"`
+	Version             string                     `comment:"required, the version of the module"                                                                                                                        yaml:"version"`
+	Manifest            UrlOrLocalFiles            `comment:"required unless chart or kustomization is set, reference to the manifest, must be a URL or a local file path, directory or glob pattern, or a list of them" yaml:"manifest"`
+	Chart               *Chart                     `comment:"optional, local Helm chart rendered as the manifest, mutually exclusive with manifest and kustomization"                                                    yaml:"chart"`
+	Kustomization       string                     `comment:"optional, local kustomization directory built as the manifest, mutually exclusive with manifest and chart"                                                  yaml:"kustomization"`
+	Repository          string                     `comment:"required, reference to the repository, must be a URL"                                                                                                       yaml:"repository"`
+	Documentation       string                     `comment:"required, reference to the documentation, must be a URL"                                                                                                    yaml:"documentation"`
+	Icons               Icons                      `comment:"required, icons used for UI"                                                                                                                                yaml:"icons,omitempty"`
+	DefaultCR           UrlOrLocalFile             `comment:"optional, reference to a YAML file containing the default CR for the module, must be a URL or a local file path"                                            yaml:"defaultCR"` //nolint:tagliatelle // prefer defaultCR over defaultCr
+	Security            string                     `comment:"optional, reference to a YAML file containing the security scanners config, must be a local file path"                                                      yaml:"security"`
+	SecurityScanEnabled *bool                      `comment:"optional, default=true, indicates whether security scanning labels should be added to the OCM descriptor"                                                   yaml:"securityScanEnabled"`
+	Labels              map[string]string          `comment:"optional, additional labels for the generated ModuleTemplate CR"                                                                                            yaml:"labels"`
+	Annotations         map[string]string          `comment:"optional, additional annotations for the generated ModuleTemplate CR"                                                                                       yaml:"annotations"`
+	Manager             *Manager                   `comment:"optional, module resource that indicates the installation readiness of the module, typically the manager deployment of the module"                          yaml:"manager"`
+	AssociatedResources []*metav1.GroupVersionKind `comment:"optional, optional, resources that should be cleaned up with the module deletion"                                                                           yaml:"associatedResources"`
+	Resources           Resources                  `comment:"optional, additional resources of the module that may be fetched"                                                                                           yaml:"resources,omitempty"`
+	ImageLocations      []ImageLocation            `comment:"optional, additional locations of images in resources of the manifest, e.g. the image fields of a custom resource"                                          yaml:"imageLocations"`
+	RequiresDowntime    bool                       `comment:"optional, default=false, indicates whether the module requires downtime to support maintenance windows during module upgrades"                              yaml:"requiresDowntime"`
+	Internal            bool                       `comment:"optional, default=false, indicates whether the module is internal"                                                                                          yaml:"internal"`
+	Beta                bool                       `comment:"optional, default=false, indicates whether the module is beta"                                                                                              yaml:"beta"`
+	Channels            []string                   `comment:"optional, channels the module version is assigned to in the ModuleReleaseMeta, e.g. regular, fast or experimental"                                          yaml:"channels"`
 }
 
 type Manager struct {
@@ -106,6 +112,14 @@ type Manager struct {
 	Namespace string `comment:"optional, the path to the manager" yaml:"namespace"`
 }
 
+// Chart references a local Helm chart that is rendered into the manifest of the module.
+type Chart struct {
+	Path        string   `comment:"required, local path to the chart directory or packaged .tgz chart" yaml:"path"`
+	ValuesFiles []string `comment:"optional, local paths to values files, later files take precedence" yaml:"valuesFiles"`
+	ReleaseName string   `comment:"required, the release name used to render the chart"                yaml:"releaseName"`
+	Namespace   string   `comment:"required, the release namespace used to render the chart"           yaml:"namespace"`
+}
+
 // Icons represents a map of icon names to links.
 type Icons map[string]string
 
@@ -163,10 +177,11 @@ func unmarshalToMap(unmarshal func(interface{}) error) (map[string]string, error
 	return resultMap, nil
 }
 
+// marshalFromMap returns the entries of the map as name and link items sorted by name.
 func marshalFromMap(dataMap map[string]string) (interface{}, error) {
 	items := make([]nameLinkItem, 0, len(dataMap))
-	for name, link := range dataMap {
-		items = append(items, nameLinkItem{Name: name, Link: link})
+	for _, name := range slices.Sorted(maps.Keys(dataMap)) {
+		items = append(items, nameLinkItem{Name: name, Link: dataMap[name]})
 	}
 	return items, nil
 }
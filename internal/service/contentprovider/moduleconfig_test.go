@@ -194,6 +194,25 @@ icons:
 	assert.Equal(t, expectedModuleConfig.Icons, marshalledModuleConfig.Icons)
 }
 
+func Test_ModuleConfig_Marshal_Icons_SortsByName(t *testing.T) {
+	icons := contentprovider.Icons{
+		"icon3": "https://example.com/icon3",
+		"icon1": "https://example.com/icon1",
+		"icon2": "https://example.com/icon2",
+	}
+
+	marshalledIcons, err := yaml.Marshal(&icons)
+
+	require.NoError(t, err)
+	assert.Equal(t, `- name: icon1
+  link: https://example.com/icon1
+- name: icon2
+  link: https://example.com/icon2
+- name: icon3
+  link: https://example.com/icon3
+`, string(marshalledIcons))
+}
+
 func Test_ModuleConfig_Unmarshall_Resources_Success(t *testing.T) {
 	moduleConfigData := `
 resources:
@@ -3,8 +3,10 @@ package contentprovider
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/name"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
@@ -103,6 +105,22 @@ func (s *SecurityScanConfig) ValidateBDBAImageTags(moduleVersion string) error {
 	return nil
 }
 
+// ValidateBDBAImagesInManifest checks that every BDBA image is part of the given manifest images.
+// Digests are ignored when comparing, so a tagged BDBA entry matches a pinned manifest image.
+func (s *SecurityScanConfig) ValidateBDBAImagesInManifest(manifestImages []string) error {
+	missingImages := diffImages(s.BDBA, manifestImages)
+	if len(missingImages) > 0 {
+		return fmt.Errorf("BDBA images [%s] are not used in the manifest: %w",
+			strings.Join(missingImages, ", "), commonerrors.ErrInvalidOption)
+	}
+	return nil
+}
+
+// UnscannedImages returns the manifest images that are not listed in the BDBA images.
+func (s *SecurityScanConfig) UnscannedImages(manifestImages []string) []string {
+	return diffImages(manifestImages, s.BDBA)
+}
+
 type MendSecConfig struct {
 	Language    string   `comment:"string, indicating the programming language the scanner has to analyze" json:"language"    yaml:"language"`
 	SubProjects string   `comment:"string, specifying any subprojects"                                     json:"subprojects" yaml:"subprojects"`
@@ -120,3 +138,40 @@ func isCorrectManagerVersion(image, moduleVersion string) bool {
 	}
 	return matched
 }
+
+// diffImages returns the images of source which are not contained in target. Images are compared by their
+// normalized repository and tag, ignoring digests.
+func diffImages(source, target []string) []string {
+	targetImages := make(map[string]struct{}, len(target))
+	for _, img := range target {
+		targetImages[normalizeImage(img)] = struct{}{}
+	}
+
+	diff := make([]string, 0)
+	for _, img := range source {
+		if _, found := targetImages[normalizeImage(img)]; !found {
+			diff = append(diff, img)
+		}
+	}
+	return diff
+}
+
+// normalizeImage returns the fully qualified repository of the image together with its tag, so that e.g.
+// "nginx:1.25" and "docker.io/library/nginx:1.25" are considered the same image. The digest is only kept
+// for images without a tag. References that cannot be parsed are compared as they are.
+func normalizeImage(img string) string {
+	imageWithoutDigest, digest, hasDigest := strings.Cut(img, "@")
+	ref, err := name.ParseReference(imageWithoutDigest, name.WithDefaultTag(""))
+	if err != nil {
+		return imageWithoutDigest
+	}
+
+	repository := ref.Context().Name()
+	if tag := ref.Identifier(); tag != "" {
+		return repository + ":" + tag
+	}
+	if hasDigest {
+		return repository + "@" + digest
+	}
+	return repository
+}
@@ -185,6 +185,66 @@ func Test_SecurityConfig_ValidateBDBAImageTags_FoundCorrectManagerVersionEarlyEx
 	require.Len(t, config.BDBA, 2)
 }
 
+func Test_SecurityScanConfig_ValidateBDBAImagesInManifest_ReturnsError_WhenImageNotInManifest(t *testing.T) {
+	config := contentprovider.SecurityScanConfig{
+		BDBA: []string{
+			"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3",
+			"europe-docker.pkg.dev/kyma-project/prod/stale-image:1.0.0",
+		},
+	}
+
+	err := config.ValidateBDBAImagesInManifest([]string{"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3"})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "[europe-docker.pkg.dev/kyma-project/prod/stale-image:1.0.0]")
+}
+
+func Test_SecurityScanConfig_ValidateBDBAImagesInManifest_IgnoresDigests(t *testing.T) {
+	config := contentprovider.SecurityScanConfig{
+		BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3"},
+	}
+
+	err := config.ValidateBDBAImagesInManifest([]string{
+		"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3@sha256:" +
+			"c7742da01aa7ee169d59e58a91c35da9c13e67f555dcd8b2ada15887aa619e6c",
+	})
+
+	require.NoError(t, err)
+}
+
+func Test_SecurityScanConfig_ValidateBDBAImagesInManifest_MatchesNormalizedReferences(t *testing.T) {
+	config := contentprovider.SecurityScanConfig{
+		BDBA: []string{"docker.io/library/postgres:15.3", "ghcr.io/kyma-project/test-image:1.2.3"},
+	}
+
+	err := config.ValidateBDBAImagesInManifest([]string{"postgres:15.3", "ghcr.io/kyma-project/test-image:1.2.3"})
+
+	require.NoError(t, err)
+}
+
+func Test_SecurityScanConfig_ValidateBDBAImagesInManifest_ReturnsError_WhenTagDiffers(t *testing.T) {
+	config := contentprovider.SecurityScanConfig{
+		BDBA: []string{"docker.io/library/postgres:15.3"},
+	}
+
+	err := config.ValidateBDBAImagesInManifest([]string{"postgres:15.4"})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+}
+
+func Test_SecurityScanConfig_UnscannedImages_ReturnsManifestImagesNotInBDBA(t *testing.T) {
+	config := contentprovider.SecurityScanConfig{
+		BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3"},
+	}
+
+	unscanned := config.UnscannedImages([]string{
+		"europe-docker.pkg.dev/kyma-project/prod/test-image:1.2.3",
+		"postgres:15.3",
+	})
+
+	require.Equal(t, []string{"postgres:15.3"}, unscanned)
+}
+
 func Test_isCorrectManagerVersion_EdgeCases(t *testing.T) {
 	testCases := []struct {
 		name          string
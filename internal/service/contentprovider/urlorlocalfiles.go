@@ -0,0 +1,81 @@
+package contentprovider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UrlOrLocalFiles is a list of references that can be written in YAML either as a single reference or as a list.
+// A local reference may also be a directory or a glob pattern.
+//
+//nolint:recvcheck // This is a value type, not a pointer type.
+type UrlOrLocalFiles struct {
+	entries []UrlOrLocalFile
+}
+
+// MustUrlOrLocalFiles is a helper function that parses strings into UrlOrLocalFiles. Use only in tests!
+func MustUrlOrLocalFiles(vals ...string) UrlOrLocalFiles {
+	entries := make([]UrlOrLocalFile, 0, len(vals))
+	for _, val := range vals {
+		entries = append(entries, MustUrlOrLocalFile(val))
+	}
+	return NewUrlOrLocalFiles(entries...)
+}
+
+// NewUrlOrLocalFiles returns UrlOrLocalFiles holding the given references, empty references are dropped.
+func NewUrlOrLocalFiles(entries ...UrlOrLocalFile) UrlOrLocalFiles {
+	var res UrlOrLocalFiles
+	for _, entry := range entries {
+		if !entry.IsEmpty() {
+			res.entries = append(res.entries, entry)
+		}
+	}
+	return res
+}
+
+func (u UrlOrLocalFiles) Entries() []UrlOrLocalFile {
+	return u.entries
+}
+
+func (u UrlOrLocalFiles) IsEmpty() bool {
+	return len(u.entries) == 0
+}
+
+func (u UrlOrLocalFiles) String() string {
+	values := make([]string, 0, len(u.entries))
+	for _, entry := range u.entries {
+		values = append(values, entry.String())
+	}
+	return strings.Join(values, ", ")
+}
+
+func (u *UrlOrLocalFiles) UnmarshalYAML(unmarshal func(any) error) error {
+	var single UrlOrLocalFile
+	if err := unmarshal(&single); err == nil {
+		*u = NewUrlOrLocalFiles(single)
+		return nil
+	}
+
+	var list []UrlOrLocalFile
+	if err := unmarshal(&list); err != nil {
+		return fmt.Errorf("must be a reference or a list of references: %w", err)
+	}
+	*u = UrlOrLocalFiles{entries: list}
+	return nil
+}
+
+func (u UrlOrLocalFiles) MarshalYAML() (any, error) {
+	switch len(u.entries) {
+	case 0:
+		return "", nil
+	case 1:
+		return u.entries[0].value, nil
+	default:
+	}
+
+	values := make([]string, 0, len(u.entries))
+	for _, entry := range u.entries {
+		values = append(values, entry.value)
+	}
+	return values, nil
+}
@@ -0,0 +1,52 @@
+package contentprovider_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+func Test_UrlOrLocalFiles_UnmarshalYAML_Succeeds_WhenSingleReference(t *testing.T) {
+	var res contentprovider.UrlOrLocalFiles
+	err := yaml.Unmarshal([]byte("https://example.com/manifest.yaml"), &res)
+
+	require.NoError(t, err)
+	require.Len(t, res.Entries(), 1)
+	require.True(t, res.Entries()[0].IsURL())
+}
+
+func Test_UrlOrLocalFiles_UnmarshalYAML_Succeeds_WhenList(t *testing.T) {
+	var res contentprovider.UrlOrLocalFiles
+	err := yaml.Unmarshal([]byte("- crds\n- manifests/*.yaml\n- https://example.com/rbac.yaml\n"), &res)
+
+	require.NoError(t, err)
+	require.Equal(t, "crds, manifests/*.yaml, https://example.com/rbac.yaml", res.String())
+}
+
+func Test_UrlOrLocalFiles_UnmarshalYAML_Fails_WhenMapping(t *testing.T) {
+	var res contentprovider.UrlOrLocalFiles
+	err := yaml.Unmarshal([]byte("path: manifest.yaml\n"), &res)
+
+	require.ErrorContains(t, err, "must be a reference or a list of references")
+}
+
+func Test_UrlOrLocalFiles_MarshalYAML_KeepsSingleReferenceScalar(t *testing.T) {
+	single, err := yaml.Marshal(contentprovider.MustUrlOrLocalFiles("manifest.yaml"))
+	require.NoError(t, err)
+	list, err := yaml.Marshal(contentprovider.MustUrlOrLocalFiles("crds", "manifests"))
+	require.NoError(t, err)
+
+	require.Equal(t, "manifest.yaml\n", string(single))
+	require.Equal(t, "- crds\n- manifests\n", string(list))
+}
+
+func Test_UrlOrLocalFiles_NewUrlOrLocalFiles_DropsEmptyReferences(t *testing.T) {
+	res := contentprovider.NewUrlOrLocalFiles(contentprovider.UrlOrLocalFile{},
+		contentprovider.MustUrlOrLocalFile("manifest.yaml"))
+
+	require.False(t, res.IsEmpty())
+	require.Len(t, res.Entries(), 1)
+}
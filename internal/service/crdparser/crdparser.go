@@ -2,14 +2,24 @@ package crdparser
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8syaml "sigs.k8s.io/yaml"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/crdschema"
+)
+
+var (
+	ErrCRDNotFound      = errors.New("CRD of the default CR not found in the manifest")
+	ErrInvalidDefaultCR = errors.New("invalid default CR")
 )
 
 type FileSystem interface {
@@ -42,53 +52,122 @@ type Resource struct {
 	} `yaml:"spec"`
 }
 
+// IsCRDClusterScoped returns whether the CRD of the default CR is cluster scoped.
+// It fails if the manifest does not contain the CRD of the default CR.
 func (s *Service) IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error) {
 	if paths.DefaultCR == "" {
 		return false, nil
 	}
 
+	_, crd, err := s.findCRD(paths)
+	if err != nil {
+		return false, err
+	}
+
+	return crd.Spec.Scope == apiextensionsv1.ClusterScoped, nil
+}
+
+// ValidateDefaultCR validates the default CR against the schema of the CRD version it uses.
+// The CRD must be part of the manifest and serve the version of the default CR.
+func (s *Service) ValidateDefaultCR(paths *types.ResourcePaths) error {
+	if paths.DefaultCR == "" {
+		return nil
+	}
+
+	customResource, crd, err := s.findCRD(paths)
+	if err != nil {
+		return err
+	}
+
+	apiVersion, _ := customResource["apiVersion"].(string)
+	_, version, _ := strings.Cut(apiVersion, "/")
+	validator, err := crdschema.NewValidator(crd, version)
+	if err != nil {
+		return fmt.Errorf("%w: apiVersion %s can not be validated: %w", ErrInvalidDefaultCR, apiVersion, err)
+	}
+
+	if err = validator.Validate(customResource); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidDefaultCR, err)
+	}
+
+	return nil
+}
+
+// findCRD returns the default CR and the CRD of its group and kind from the manifest.
+func (s *Service) findCRD(paths *types.ResourcePaths,
+) (map[string]any, *apiextensionsv1.CustomResourceDefinition, error) {
 	crData, err := s.fileSystem.ReadFile(paths.DefaultCR)
 	if err != nil {
-		return false, fmt.Errorf("error reading default CR file: %w", err)
+		return nil, nil, fmt.Errorf("error reading default CR file: %w", err)
 	}
 
-	var customResource Resource
-	if err := yaml.Unmarshal(crData, &customResource); err != nil {
-		return false, fmt.Errorf("error parsing default CR: %w", err)
+	var customResource map[string]any
+	if err = k8syaml.Unmarshal(crData, &customResource); err != nil {
+		return nil, nil, fmt.Errorf("error parsing default CR: %w", err)
 	}
 
-	group := strings.Split(customResource.APIVersion, "/")[0]
+	apiVersion, _ := customResource["apiVersion"].(string)
+	kind, _ := customResource["kind"].(string)
+	group, _, found := strings.Cut(apiVersion, "/")
+	if !found || kind == "" {
+		return nil, nil, fmt.Errorf("%w: apiVersion must be of the form group/version and kind must be set",
+			ErrInvalidDefaultCR)
+	}
 
 	manifestData, err := s.fileSystem.ReadFile(paths.RawManifest)
 	if err != nil {
-		return false, fmt.Errorf("error reading manifest file: %w", err)
+		return nil, nil, fmt.Errorf("error reading manifest file: %w", err)
 	}
 
-	crdScope, err := getCrdScopeFromManifest(manifestData, group, customResource.Kind)
+	crd, err := getCrdFromManifest(manifestData, group, kind)
 	if err != nil {
-		return false, fmt.Errorf("error finding CRD file in the %q file: %w", paths.RawManifest, err)
+		return nil, nil, fmt.Errorf("error finding CRD file in the %q file: %w", paths.RawManifest, err)
 	}
 
-	return crdScope == apiextensions.ClusterScoped, nil
+	if crd == nil {
+		return nil, nil, fmt.Errorf("%w: no CRD for kind %s of group %s in the %q file", ErrCRDNotFound, kind,
+			group, paths.RawManifest)
+	}
+
+	return customResource, crd, nil
 }
 
-func getCrdScopeFromManifest(manifestData []byte, group, kind string) (apiextensions.ResourceScope, error) {
+func getCrdFromManifest(manifestData []byte, group, kind string,
+) (*apiextensionsv1.CustomResourceDefinition, error) {
 	decoder := yaml.NewDecoder(bytes.NewReader(manifestData))
 
 	for {
-		var res Resource
-		err := decoder.Decode(&res)
+		var document yaml.Node
+		err := decoder.Decode(&document)
 		if err != nil {
-			if err.Error() == "EOF" {
+			if errors.Is(err, io.EOF) {
 				break
 			}
-			return "", fmt.Errorf("failed to parse YAML document: %w", err)
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
+		}
+
+		var res Resource
+		if err = document.Decode(&res); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document: %w", err)
 		}
 
 		if res.Kind == "CustomResourceDefinition" && res.Spec.Group == group && res.Spec.Names.Kind == kind {
-			return res.Spec.Scope, nil
+			return parseCRD(&document)
 		}
 	}
 
-	return "", nil
+	return nil, nil //nolint:nilnil // no CRD is not an error of the manifest
+}
+
+func parseCRD(document *yaml.Node) (*apiextensionsv1.CustomResourceDefinition, error) {
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CRD: %w", err)
+	}
+
+	crd, err := crdschema.ParseCRD(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CRD: %w", err)
+	}
+	return crd, nil
 }
@@ -52,6 +52,154 @@ func TestService_IsCRDClusterScoped_ReturnsErrorWhenFileReadingRetrievalError(t
 	require.ErrorContains(t, err, "error reading default CR file")
 }
 
+func TestService_IsCRDClusterScoped_ReturnsErrorWhenCRDIsMissing(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemStub{files: map[string]string{
+		defaultCRPath:   sampleDefaultCR,
+		rawManifestPath: "apiVersion: v1\nkind: Namespace\n",
+	}})
+
+	resourcePaths := types.NewResourcePaths(defaultCRPath, rawManifestPath, "")
+	_, err := crdParserService.IsCRDClusterScoped(resourcePaths)
+
+	require.ErrorIs(t, err, crdparser.ErrCRDNotFound)
+	require.ErrorContains(t, err, "no CRD for kind Sample of group operator.kyma-project.io")
+}
+
+func TestService_ValidateDefaultCR_ReturnsNilWhenThereIsNoDefaultCR(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemNotExistStub{})
+
+	err := crdParserService.ValidateDefaultCR(types.NewResourcePaths("", rawManifestPath, ""))
+
+	require.NoError(t, err)
+}
+
+func TestService_ValidateDefaultCR_AcceptsValidDefaultCR(t *testing.T) {
+	crdParserService, _ := crdparser.NewService(&fileSystemStub{files: map[string]string{
+		defaultCRPath:   sampleDefaultCR,
+		rawManifestPath: sampleManifest,
+	}})
+
+	err := crdParserService.ValidateDefaultCR(types.NewResourcePaths(defaultCRPath, rawManifestPath, ""))
+
+	require.NoError(t, err)
+}
+
+func TestService_ValidateDefaultCR_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaultCR string
+		manifest  string
+		wantErr   error
+		errMsg    string
+	}{
+		{
+			name:      "schema violation",
+			defaultCR: strings.Replace(sampleDefaultCR, "replicas: 1", "replicas: \"1\"", 1),
+			manifest:  sampleManifest,
+			wantErr:   crdparser.ErrInvalidDefaultCR,
+			errMsg:    "spec.replicas in body must be of type integer",
+		},
+		{
+			name:      "version not served",
+			defaultCR: strings.Replace(sampleDefaultCR, "v1alpha1", "v1alpha2", 1),
+			manifest:  sampleManifest,
+			wantErr:   crdparser.ErrInvalidDefaultCR,
+			errMsg:    "version v1alpha2 of CRD samples.operator.kyma-project.io is not served",
+		},
+		{
+			name:      "version unknown",
+			defaultCR: strings.Replace(sampleDefaultCR, "v1alpha1", "v1", 1),
+			manifest:  sampleManifest,
+			wantErr:   crdparser.ErrInvalidDefaultCR,
+			errMsg:    "CRD samples.operator.kyma-project.io has no version v1",
+		},
+		{
+			name:      "apiVersion without group",
+			defaultCR: "apiVersion: v1\nkind: Sample\n",
+			manifest:  sampleManifest,
+			wantErr:   crdparser.ErrInvalidDefaultCR,
+			errMsg:    "apiVersion must be of the form group/version",
+		},
+		{
+			name:      "CRD missing",
+			defaultCR: sampleDefaultCR,
+			manifest:  "apiVersion: v1\nkind: Namespace\n",
+			wantErr:   crdparser.ErrCRDNotFound,
+			errMsg:    "no CRD for kind Sample",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			crdParserService, _ := crdparser.NewService(&fileSystemStub{files: map[string]string{
+				defaultCRPath:   test.defaultCR,
+				rawManifestPath: test.manifest,
+			}})
+
+			err := crdParserService.ValidateDefaultCR(types.NewResourcePaths(defaultCRPath, rawManifestPath, ""))
+
+			require.ErrorIs(t, err, test.wantErr)
+			require.ErrorContains(t, err, test.errMsg)
+		})
+	}
+}
+
+const (
+	sampleDefaultCR = `apiVersion: operator.kyma-project.io/v1alpha1
+kind: Sample
+metadata:
+  name: sample
+spec:
+  replicas: 1
+`
+	sampleManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: sample-system
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: samples.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Sample
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required:
+            - replicas
+            - mode
+            properties:
+              replicas:
+                type: integer
+              mode:
+                type: string
+                default: fast
+                enum:
+                - fast
+                - slow
+  - name: v1alpha2
+    served: false
+`
+)
+
+type fileSystemStub struct {
+	files map[string]string
+}
+
+func (s *fileSystemStub) ReadFile(path string) ([]byte, error) {
+	return []byte(s.files[path]), nil
+}
+
 type fileSystemClusterScopedExistsStub struct{}
 
 func (*fileSystemClusterScopedExistsStub) ReadFile(path string) ([]byte, error) {
@@ -0,0 +1,139 @@
+package crdschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	apiservervalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	ErrInvalidCRD      = errors.New("invalid CRD")
+	ErrSchemaViolation = errors.New("object does not match the CRD schema")
+)
+
+// Validator validates objects against the structural schema of a single served version of a CRD.
+// It uses the defaulting and the schema validation of the API server, so it applies the defaults of the schema
+// and then checks types, required fields, patterns, formats and enums. CEL validation rules are not evaluated.
+type Validator struct {
+	name      string
+	schema    *structuralschema.Structural
+	validator apiservervalidation.SchemaValidator
+}
+
+// ParseCRD parses a CustomResourceDefinition from YAML or JSON.
+func ParseCRD(data []byte) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse CRD: %w", ErrInvalidCRD, err)
+	}
+
+	if crd.Kind != "CustomResourceDefinition" {
+		return nil, fmt.Errorf("%w: expected kind CustomResourceDefinition, got %q", ErrInvalidCRD, crd.Kind)
+	}
+
+	return crd, nil
+}
+
+// NewValidator returns a Validator for the given version of the CRD. The version must be served and its schema
+// must be structural.
+func NewValidator(crd *apiextensionsv1.CustomResourceDefinition, version string) (*Validator, error) {
+	if crd == nil {
+		return nil, fmt.Errorf("%w: CRD must not be nil", ErrInvalidCRD)
+	}
+
+	for _, crdVersion := range crd.Spec.Versions {
+		if crdVersion.Name != version {
+			continue
+		}
+
+		if !crdVersion.Served {
+			return nil, fmt.Errorf("%w: version %s of CRD %s is not served", ErrInvalidCRD, version, crd.Name)
+		}
+
+		if crdVersion.Schema == nil || crdVersion.Schema.OpenAPIV3Schema == nil {
+			return nil, fmt.Errorf("%w: version %s of CRD %s has no schema", ErrInvalidCRD, version, crd.Name)
+		}
+
+		internalProps, schema, err := newStructuralSchema(crdVersion.Schema.OpenAPIV3Schema)
+		if err != nil {
+			return nil, fmt.Errorf("%w: schema of version %s of CRD %s: %w", ErrInvalidCRD, version, crd.Name, err)
+		}
+
+		validator, _, err := apiservervalidation.NewSchemaValidator(internalProps)
+		if err != nil {
+			return nil, fmt.Errorf("%w: schema of version %s of CRD %s: %w", ErrInvalidCRD, version, crd.Name, err)
+		}
+
+		return &Validator{
+			name:      fmt.Sprintf("%s/%s", crd.Name, version),
+			schema:    schema,
+			validator: validator,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: CRD %s has no version %s", ErrInvalidCRD, crd.Name, version)
+}
+
+// Validate validates the object, which must consist of JSON compatible values, e.g. as decoded from YAML or JSON.
+// All violations are reported in a single error.
+// The object is not modified, the defaults are applied to a copy.
+func (v *Validator) Validate(object map[string]any) error {
+	defaulted := runtime.DeepCopyJSON(object)
+	defaulting.Default(defaulted, v.schema)
+
+	result := v.validator.Validate(defaulted)
+	if result.IsValid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		violations = append(violations, err.Error())
+	}
+	return fmt.Errorf("%w %s: %s", ErrSchemaViolation, v.name, strings.Join(violations, "; "))
+}
+
+// ValidateJSON validates the JSON representation of a value, e.g. of a typed API object.
+func (v *Validator) ValidateJSON(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	var object map[string]any
+	if err = json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+
+	return v.Validate(object)
+}
+
+// newStructuralSchema converts the CRD schema into the internal and the structural schema and rejects schemas
+// the API server would not accept, e.g. because a type is missing.
+func newStructuralSchema(props *apiextensionsv1.JSONSchemaProps,
+) (*apiextensions.JSONSchemaProps, *structuralschema.Structural, error) {
+	internalProps := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(props, internalProps,
+		nil); err != nil {
+		return nil, nil, fmt.Errorf("failed to convert schema: %w", err)
+	}
+
+	schema, err := structuralschema.NewStructural(internalProps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schema is not structural: %w", err)
+	}
+
+	if errs := structuralschema.ValidateStructural(nil, schema); len(errs) > 0 {
+		return nil, nil, fmt.Errorf("schema is not structural: %w", errs.ToAggregate())
+	}
+	return internalProps, schema, nil
+}
@@ -0,0 +1,213 @@
+package crdschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/crdschema"
+)
+
+const sampleCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: samples.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Sample
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required:
+            - replicas
+            - mode
+            properties:
+              replicas:
+                type: integer
+                minimum: 1
+              mode:
+                type: string
+                default: fast
+                enum:
+                - fast
+                - slow
+              link:
+                type: string
+                format: uri
+              ports:
+                type: array
+                items:
+                  type: object
+                  required:
+                  - protocol
+                  properties:
+                    protocol:
+                      type: string
+                      default: TCP
+              config:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+  - name: v1alpha2
+    served: true
+  - name: v1beta1
+    served: false
+    schema:
+      openAPIV3Schema:
+        type: object
+  - name: v1beta2
+    served: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            properties:
+              replicas:
+                type: integer
+`
+
+func Test_ParseCRD_ReturnsError_WhenKindIsNotCRD(t *testing.T) {
+	_, err := crdschema.ParseCRD([]byte("kind: Deployment\n"))
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+	assert.Contains(t, err.Error(), `got "Deployment"`)
+}
+
+func Test_ParseCRD_ReturnsError_WhenDataIsNotYAML(t *testing.T) {
+	_, err := crdschema.ParseCRD([]byte("kind: [CustomResourceDefinition"))
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+}
+
+func Test_NewValidator_ReturnsError_WhenCRDIsNil(t *testing.T) {
+	_, err := crdschema.NewValidator(nil, "v1alpha1")
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+}
+
+func Test_NewValidator_ReturnsError_WhenVersionDoesNotExist(t *testing.T) {
+	crd, err := crdschema.ParseCRD([]byte(sampleCRD))
+	require.NoError(t, err)
+
+	_, err = crdschema.NewValidator(crd, "v1")
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+	assert.Contains(t, err.Error(), "CRD samples.operator.kyma-project.io has no version v1")
+}
+
+func Test_NewValidator_ReturnsError_WhenVersionHasNoSchema(t *testing.T) {
+	crd, err := crdschema.ParseCRD([]byte(sampleCRD))
+	require.NoError(t, err)
+
+	_, err = crdschema.NewValidator(crd, "v1alpha2")
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+	assert.Contains(t, err.Error(), "has no schema")
+}
+
+func Test_NewValidator_ReturnsError_WhenVersionIsNotServed(t *testing.T) {
+	crd, err := crdschema.ParseCRD([]byte(sampleCRD))
+	require.NoError(t, err)
+
+	_, err = crdschema.NewValidator(crd, "v1beta1")
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+	assert.Contains(t, err.Error(), "version v1beta1 of CRD samples.operator.kyma-project.io is not served")
+}
+
+func Test_NewValidator_ReturnsError_WhenSchemaIsNotStructural(t *testing.T) {
+	crd, err := crdschema.ParseCRD([]byte(sampleCRD))
+	require.NoError(t, err)
+
+	_, err = crdschema.NewValidator(crd, "v1beta2")
+
+	require.ErrorIs(t, err, crdschema.ErrInvalidCRD)
+	assert.Contains(t, err.Error(), "schema is not structural")
+	assert.Contains(t, err.Error(), "properties[spec].type: Required value")
+}
+
+func Test_Validate_AppliesDefaultsBeforeValidation(t *testing.T) {
+	validator := newSampleValidator(t)
+	object := map[string]any{
+		"spec": map[string]any{
+			"replicas": float64(1),
+			"ports":    []any{map[string]any{}},
+		},
+	}
+
+	err := validator.Validate(object)
+
+	require.NoError(t, err)
+	assert.NotContains(t, object["spec"], "mode")
+}
+
+func Test_Validate_AcceptsValidObject(t *testing.T) {
+	validator := newSampleValidator(t)
+
+	err := validator.Validate(map[string]any{
+		"spec": map[string]any{
+			"replicas": float64(2),
+			"mode":     "fast",
+			"link":     "https://example.com/docs",
+			"config":   map[string]any{"anything": true},
+		},
+	})
+
+	require.NoError(t, err)
+}
+
+func Test_Validate_ReportsAllViolations(t *testing.T) {
+	validator := newSampleValidator(t)
+
+	err := validator.Validate(map[string]any{
+		"spec": map[string]any{
+			"mode": "medium",
+		},
+	})
+
+	require.ErrorIs(t, err, crdschema.ErrSchemaViolation)
+	assert.Contains(t, err.Error(), "samples.operator.kyma-project.io/v1alpha1")
+	assert.Contains(t, err.Error(), "spec.replicas in body is required")
+	assert.Contains(t, err.Error(), "spec.mode in body should be one of [fast slow]")
+}
+
+func Test_ValidateJSON_ValidatesTypedValues(t *testing.T) {
+	type sampleSpec struct {
+		Replicas int    `json:"replicas"`
+		Mode     string `json:"mode,omitempty"`
+	}
+	type sample struct {
+		Spec sampleSpec `json:"spec"`
+	}
+	validator := newSampleValidator(t)
+
+	require.NoError(t, validator.ValidateJSON(sample{Spec: sampleSpec{Replicas: 1}}))
+	require.ErrorIs(t, validator.ValidateJSON(sample{Spec: sampleSpec{Replicas: 0}}), crdschema.ErrSchemaViolation)
+}
+
+func Test_ValidateJSON_ReturnsError_WhenValueIsNotAnObject(t *testing.T) {
+	validator := newSampleValidator(t)
+
+	err := validator.ValidateJSON([]string{"sample"})
+
+	require.ErrorContains(t, err, "failed to unmarshal object")
+}
+
+func newSampleValidator(t *testing.T) *crdschema.Validator {
+	t.Helper()
+
+	crd, err := crdschema.ParseCRD([]byte(sampleCRD))
+	require.NoError(t, err)
+	validator, err := crdschema.NewValidator(crd, "v1alpha1")
+	require.NoError(t, err)
+	return validator
+}
@@ -3,7 +3,7 @@ package create
 import (
 	"errors"
 	"fmt"
-	"path"
+	"strings"
 
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/cpi"
@@ -12,16 +12,25 @@ import (
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/componentarchive"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor/resources"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/modulecheck"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/signature"
 )
 
 var ErrComponentVersionExists = errors.New("component version already exists")
 
 type ModuleConfigService interface {
-	ParseAndValidateModuleConfig(moduleConfigFile string) (*contentprovider.ModuleConfig, error)
+	ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool) (*contentprovider.ModuleConfig, error)
+	ValidateReservedKeys(moduleConfig *contentprovider.ModuleConfig,
+		reservedKeyPrefixes []validation.ReservedKeyPrefix,
+	) ([]moduleconfigreader.Violation, error)
 }
 
 type FileSystem interface {
@@ -35,6 +44,23 @@ type FileResolver interface {
 	CleanupTempFiles() []error
 }
 
+type ManifestFileResolver interface {
+	// ResolveAll resolves a list of file references, which can be URLs, local file paths, directories or glob
+	// patterns, into a single file. Several files are concatenated in a deterministic order.
+	ResolveAll(fileRefs contentprovider.UrlOrLocalFiles, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestRenderer interface {
+	// RenderChart renders a local Helm chart into a temp file and returns its path.
+	// The chart and values file paths are resolved relative to the provided basePath.
+	RenderChart(chart *contentprovider.Chart, basePath string) (string, error)
+	// RenderKustomization builds a local kustomization directory into a temp file and returns its path.
+	// The directory is resolved relative to the provided basePath.
+	RenderKustomization(kustomizationDir string, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
 type GitSourcesService interface {
 	AddGitSources(componentDescriptor *compdesc.ComponentDescriptor,
 		gitRepoPath, gitRepoURL, moduleVersion string,
@@ -42,6 +68,16 @@ type GitSourcesService interface {
 	AddGitSourcesToConstructor(constructor *component.Constructor, gitRepoPath, gitRepoURL string) error
 }
 
+type SecurityConfigService interface {
+	ParseSecurityConfigData(securityConfigFile string) (*contentprovider.SecurityScanConfig, error)
+	AppendSecurityLabelsToSources(descriptor *compdesc.ComponentDescriptor,
+		securityConfig *contentprovider.SecurityScanConfig,
+	) error
+	AppendSecurityLabelsToConstructorSources(constructor *component.Constructor,
+		securityConfig *contentprovider.SecurityScanConfig,
+	)
+}
+
 type ComponentConstructorService interface {
 	AddImagesToConstructor(componentConstructor *component.Constructor,
 		images []string,
@@ -67,6 +103,7 @@ type RegistryService interface {
 	PushComponentVersion(archive *comparch.ComponentArchive,
 		insecure bool,
 		overwrite bool,
+		copyResources bool,
 		credentials string,
 		registryURL string,
 	) error
@@ -80,6 +117,19 @@ type RegistryService interface {
 		credentials string,
 		registryURL string,
 	) (bool, error)
+	// CompareComponentVersion returns the differences of the pushed component version to the one of the archive,
+	// none if both have the same normalized digest.
+	CompareComponentVersion(archive *comparch.ComponentArchive,
+		insecure bool,
+		copyResources bool,
+		credentials string,
+		registryURL string,
+	) ([]string, error)
+	ExportComponentVersion(archive *comparch.ComponentArchive,
+		archivePath string,
+		overwrite bool,
+		copyResources bool,
+	) error
 }
 
 type ModuleTemplateService interface {
@@ -87,12 +137,15 @@ type ModuleTemplateService interface {
 		descriptorToRender *compdesc.ComponentDescriptor,
 		data []byte,
 		isCrdClusterScoped bool,
+		provenanceAnnotations map[string]string,
 		templateOutput string,
+		reproducible bool,
 	) error
 }
 
 type CRDParserService interface {
 	IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error)
+	ValidateDefaultCR(paths *types.ResourcePaths) error
 }
 
 type ModuleResourceService interface {
@@ -105,27 +158,54 @@ type ImageVersionVerifierService interface {
 }
 
 type ManifestService interface {
-	ExtractImagesFromManifest(manifestPath string) ([]string, error)
+	ExtractImagesFromManifest(manifestPath string, imageLocations []contentprovider.ImageLocation) ([]string, error)
+}
+
+type ImageDigestService interface {
+	// ResolveDigests returns the digest of every image that is referenced by tag only, keyed by the image.
+	ResolveDigests(images []string, insecure bool, credentials, registryURL string) (map[string]string, error)
+}
+
+type CredentialService interface {
+	// UserPasswordCredentials returns the user:password credentials for the target registry, if any are configured.
+	UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error)
+	// ConfigureTargetRegistry loads the token and the TLS material for the target registry.
+	ConfigureTargetRegistry(config credential.TargetRegistryConfig) error
+}
+
+type ProvenanceService interface {
+	Collect(moduleConfig *contentprovider.ModuleConfig,
+		gitRepoPath string,
+		resourcePaths *types.ResourcePaths,
+	) (*provenance.Provenance, error)
+	WriteStatement(buildProvenance *provenance.Provenance, artifacts []string, statementOutput string) error
+}
+
+type SignatureService interface {
+	Sign(componentVersion cpi.ComponentVersionAccess, config signature.SigningConfig,
+	) (*compdesc.ComponentDescriptor, error)
 }
 
 type Service struct {
-	moduleConfigService         ModuleConfigService
+	moduleCheckService          *modulecheck.Service
 	gitSourcesService           GitSourcesService
+	securityConfigService       SecurityConfigService
 	componentConstructorService ComponentConstructorService
 	componentArchiveService     ComponentArchiveService
 	registryService             RegistryService
 	moduleTemplateService       ModuleTemplateService
 	crdParserService            CRDParserService
 	moduleResourceService       ModuleResourceService
-	imageVersionVerifierService ImageVersionVerifierService
-	manifestService             ManifestService
-	manifestFileResolver        FileResolver
-	defaultCRFileResolver       FileResolver
+	imageDigestService          ImageDigestService
+	credentialService           CredentialService
+	provenanceService           ProvenanceService
+	signatureService            SignatureService
 	fileSystem                  FileSystem
 }
 
 func NewService(moduleConfigService ModuleConfigService,
 	gitSourcesService GitSourcesService,
+	securityConfigService SecurityConfigService,
 	componentConstructorService ComponentConstructorService,
 	componentArchiveService ComponentArchiveService,
 	registryService RegistryService,
@@ -134,18 +214,23 @@ func NewService(moduleConfigService ModuleConfigService,
 	moduleResourceService ModuleResourceService,
 	imageVersionVerifierService ImageVersionVerifierService,
 	manifestService ManifestService,
-	manifestFileResolver FileResolver,
+	manifestFileResolver ManifestFileResolver,
 	defaultCRFileResolver FileResolver,
+	manifestRenderer ManifestRenderer,
+	imageDigestService ImageDigestService,
+	credentialService CredentialService,
+	provenanceService ProvenanceService,
+	signatureService SignatureService,
 	fileSystem FileSystem,
 ) (*Service, error) {
-	if moduleConfigService == nil {
-		return nil, fmt.Errorf("moduleConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
-	}
-
 	if gitSourcesService == nil {
 		return nil, fmt.Errorf("gitSourcesService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	if securityConfigService == nil {
+		return nil, fmt.Errorf("securityConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
 	if componentConstructorService == nil {
 		return nil, fmt.Errorf("componentConstructorService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
@@ -170,38 +255,48 @@ func NewService(moduleConfigService ModuleConfigService,
 		return nil, fmt.Errorf("moduleResourceService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
-	if imageVersionVerifierService == nil {
-		return nil, fmt.Errorf("imageVersionVerifierService must not be nil: %w", commonerrors.ErrInvalidArg)
+	if imageDigestService == nil {
+		return nil, fmt.Errorf("imageDigestService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
-	if manifestService == nil {
-		return nil, fmt.Errorf("manifestService must not be nil: %w", commonerrors.ErrInvalidArg)
+
+	if credentialService == nil {
+		return nil, fmt.Errorf("credentialService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
-	if manifestFileResolver == nil {
-		return nil, fmt.Errorf("manifestFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	if provenanceService == nil {
+		return nil, fmt.Errorf("provenanceService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
-	if defaultCRFileResolver == nil {
-		return nil, fmt.Errorf("defaultCRFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	if signatureService == nil {
+		return nil, fmt.Errorf("signatureService must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
 	if fileSystem == nil {
 		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	// The checks are shared with the validate command, so that validate runs exactly what create runs.
+	moduleCheckService, err := modulecheck.NewService(moduleConfigService, manifestService,
+		imageVersionVerifierService, crdParserService, securityConfigService, manifestFileResolver,
+		defaultCRFileResolver, manifestRenderer)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Service{
-		moduleConfigService:         moduleConfigService,
+		moduleCheckService:          moduleCheckService,
 		gitSourcesService:           gitSourcesService,
+		securityConfigService:       securityConfigService,
 		componentConstructorService: componentConstructorService,
 		componentArchiveService:     componentArchiveService,
 		registryService:             registryService,
 		moduleTemplateService:       moduleTemplateService,
 		crdParserService:            crdParserService,
 		moduleResourceService:       moduleResourceService,
-		imageVersionVerifierService: imageVersionVerifierService,
-		manifestService:             manifestService,
-		manifestFileResolver:        manifestFileResolver,
-		defaultCRFileResolver:       defaultCRFileResolver,
+		imageDigestService:          imageDigestService,
+		credentialService:           credentialService,
+		provenanceService:           provenanceService,
+		signatureService:            signatureService,
 		fileSystem:                  fileSystem,
 	}, nil
 }
@@ -211,72 +306,93 @@ func (s *Service) Run(opts Options) (rErr error) { //nolint:nonamedreturns // na
 		return err
 	}
 
-	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile)
-	if err != nil {
-		return fmt.Errorf("failed to parse module config: %w", err)
+	// The registry targets are only resolved if the registry is accessed, i.e. the component version is pushed to
+	// or compared with them or the image digests are resolved, so that building the component constructor file or
+	// the archive does not depend on the registry credentials.
+	var targets []registryTarget
+	if (!opts.DisableOCMRegistryPush && opts.OutputArchive == "") || opts.PinDigests {
+		var err error
+		if targets, err = s.resolveRegistryTargets(opts); err != nil {
+			return err
+		}
+		// The primary target is used wherever a single registry is needed, e.g. to resolve image digests.
+		opts.RegistryURL, opts.Insecure, opts.Credentials = targets[0].registryURL, targets[0].insecure,
+			targets[0].credentials
 	}
 
 	defer func() {
 		if rErr != nil { // only clean up if an error occurs
-			s.cleanupTempFiles(opts)
+			s.moduleCheckService.CleanupTempFiles(opts.Out)
 		}
 	}()
 
-	configFilePath := path.Dir(opts.ConfigFile)
-	// If the manifest is a local file reference, it's entry in the module config file will be relative to the module
-	// config file location (usually the same directory).
-	manifestFilePath, err := s.manifestFileResolver.Resolve(moduleConfig.Manifest, configFilePath)
+	module, err := s.moduleCheckService.Check(modulecheck.Options{
+		Out:                   opts.Out,
+		ConfigFile:            opts.ConfigFile,
+		AllowUnknownFields:    opts.AllowUnknownFields,
+		ReservedKeyPrefixes:   opts.ReservedKeyPrefixes,
+		SkipVersionValidation: opts.SkipVersionValidation,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to resolve manifest file: %w", err)
+		return err
 	}
+	moduleConfig := module.ModuleConfig
+	resourcePaths := types.NewResourcePaths(module.DefaultCRFilePath, module.ManifestFilePath, opts.TemplateOutput)
 
-	var defaultCRFilePath string
-	if !moduleConfig.DefaultCR.IsEmpty() {
-		// If the defaultCR is a local file reference, it's entry in the module config file will be relative to the
-		// module config file location (usually the same directory).
-		defaultCRFilePath, err = s.defaultCRFileResolver.Resolve(moduleConfig.DefaultCR, configFilePath)
-		if err != nil {
-			return fmt.Errorf("failed to resolve default CR file: %w", err)
-		}
+	opts.Out.Write("- Collecting build provenance\n")
+	buildProvenance, err := s.provenanceService.Collect(moduleConfig, opts.ModuleSourcesGitDirectory, resourcePaths)
+	if err != nil {
+		return fmt.Errorf("failed to collect build provenance: %w", err)
 	}
 
-	resourcePaths := types.NewResourcePaths(defaultCRFilePath, manifestFilePath, opts.TemplateOutput)
-
+	artifacts := []string{opts.TemplateOutput}
 	if opts.DisableOCMRegistryPush {
-		err = s.useComponentConstructor(moduleConfig, resourcePaths, opts)
+		err = s.useComponentConstructor(moduleConfig, module.SecurityConfig, module.Images, resourcePaths,
+			buildProvenance, opts)
+		artifacts = append(artifacts, opts.OutputConstructorFile)
 	} else {
-		err = s.useComponentDescriptor(moduleConfig, resourcePaths, opts)
+		err = s.useComponentDescriptor(moduleConfig, module.SecurityConfig, module.Images, resourcePaths,
+			buildProvenance, targets, opts)
 		if err == nil {
-			s.cleanupTempFiles(opts)
+			s.moduleCheckService.CleanupTempFiles(opts.Out)
 		}
 	}
 	if err != nil {
 		return fmt.Errorf("failed to process component: %w", err)
 	}
+
+	if opts.ProvenanceOutput != "" {
+		opts.Out.Write("- Writing provenance statement\n")
+		if err = s.provenanceService.WriteStatement(buildProvenance, artifacts, opts.ProvenanceOutput); err != nil {
+			return fmt.Errorf("failed to write provenance statement: %w", err)
+		}
+	}
 	return nil
 }
 
 func (s *Service) useComponentConstructor(moduleConfig *contentprovider.ModuleConfig,
+	securityConfig *contentprovider.SecurityScanConfig,
+	images []string,
 	resourcePaths *types.ResourcePaths,
+	buildProvenance *provenance.Provenance,
 	opts Options,
 ) error {
 	constructor := component.NewConstructor(moduleConfig.Name, moduleConfig.Version)
+	componentdescriptor.AddProvenanceLabelsToConstructor(constructor, buildProvenance.Labels())
 
 	if err := s.gitSourcesService.AddGitSourcesToConstructor(constructor, opts.ModuleSourcesGitDirectory,
 		moduleConfig.Repository); err != nil {
 		return fmt.Errorf("failed to add git sources to constructor: %w", err)
 	}
 
-	images, err := s.extractImagesFromManifest(resourcePaths.RawManifest, opts)
-	if err != nil {
-		return fmt.Errorf("failed to extract images from manifest: %w", err)
+	if securityConfig != nil {
+		opts.Out.Write("- Adding security scan labels to component constructor\n")
+		s.securityConfigService.AppendSecurityLabelsToConstructorSources(constructor, securityConfig)
 	}
 
-	if !opts.SkipVersionValidation {
-		if err := s.imageVersionVerifierService.VerifyModuleResources(moduleConfig,
-			resourcePaths.RawManifest); err != nil {
-			return fmt.Errorf("failed to verify module resources: %w", err)
-		}
+	images, err := s.pinImageDigests(images, opts)
+	if err != nil {
+		return fmt.Errorf("failed to pin image digests: %w", err)
 	}
 
 	opts.Out.Write("- Adding oci artifacts to component descriptor\n")
@@ -285,7 +401,7 @@ func (s *Service) useComponentConstructor(moduleConfig *contentprovider.ModuleCo
 	}
 
 	opts.Out.Write("- Creating module template\n")
-	err = s.createModuleTemplate(moduleConfig, nil, resourcePaths)
+	err = s.createModuleTemplate(moduleConfig, nil, resourcePaths, buildProvenance, opts.Reproducible)
 	if err != nil {
 		return fmt.Errorf("failed to create module template: %w", err)
 	}
@@ -305,7 +421,11 @@ func (s *Service) useComponentConstructor(moduleConfig *contentprovider.ModuleCo
 
 // This method will be deprecated in the future along with the OCM registry push support.
 func (s *Service) useComponentDescriptor(moduleConfig *contentprovider.ModuleConfig,
+	securityConfig *contentprovider.SecurityScanConfig,
+	images []string,
 	resourcePaths *types.ResourcePaths,
+	buildProvenance *provenance.Provenance,
+	targets []registryTarget,
 	opts Options,
 ) error {
 	securityScanEnabled := getSecurityScanEnabled(moduleConfig)
@@ -316,14 +436,25 @@ func (s *Service) useComponentDescriptor(moduleConfig *contentprovider.ModuleCon
 		return fmt.Errorf("failed to populate component descriptor metadata: %w", err)
 	}
 
+	if err = componentdescriptor.AddProvenanceLabels(descriptor, buildProvenance.Labels()); err != nil {
+		return fmt.Errorf("failed to add provenance labels: %w", err)
+	}
+
 	if err = s.gitSourcesService.AddGitSources(descriptor, opts.ModuleSourcesGitDirectory, moduleConfig.Repository,
 		moduleConfig.Version); err != nil {
 		return fmt.Errorf("failed to add git sources: %w", err)
 	}
 
-	images, err := s.extractImagesFromManifest(resourcePaths.RawManifest, opts)
+	if securityConfig != nil {
+		opts.Out.Write("- Adding security scan labels to component descriptor\n")
+		if err = s.securityConfigService.AppendSecurityLabelsToSources(descriptor, securityConfig); err != nil {
+			return fmt.Errorf("failed to add security scan labels: %w", err)
+		}
+	}
+
+	images, err = s.pinImageDigests(images, opts)
 	if err != nil {
-		return fmt.Errorf("failed to extract images from manifest: %w", err)
+		return fmt.Errorf("failed to pin image digests: %w", err)
 	}
 
 	err = addImagesOciArtifactsToDescriptor(descriptor, images, securityScanEnabled, opts)
@@ -337,53 +468,82 @@ func (s *Service) useComponentDescriptor(moduleConfig *contentprovider.ModuleCon
 		return fmt.Errorf("failed to create component archive: %w", err)
 	}
 
-	if !opts.SkipVersionValidation {
-		if err := s.imageVersionVerifierService.VerifyModuleResources(moduleConfig,
-			resourcePaths.RawManifest); err != nil {
-			return fmt.Errorf("failed to verify module resources: %w", err)
-		}
-	}
-
 	moduleResources := s.moduleResourceService.GenerateModuleResources(resourcePaths, moduleConfig.Version)
 	if err = s.componentArchiveService.AddModuleResourcesToArchive(archive,
 		moduleResources); err != nil {
 		return fmt.Errorf("failed to add module resources to component archive: %w", err)
 	}
 
-	opts.Out.Write("- Pushing component version\n")
-	if !opts.DryRun {
-		descriptor, err = s.pushComponentVersion(archive, opts)
+	if opts.SigningKeyFile != "" {
+		opts.Out.Write("- Signing component version\n")
+		descriptor, err = s.signatureService.Sign(archive, signature.SigningConfig{
+			Name:            opts.SignatureName,
+			PrivateKeyFile:  opts.SigningKeyFile,
+			CertificateFile: opts.SigningCertificateFile,
+		})
 		if err != nil {
-			return fmt.Errorf("failed to push component version: %w", err)
+			return fmt.Errorf("failed to sign component version: %w", err)
 		}
-	} else {
+	}
+
+	switch {
+	case opts.OutputArchive != "":
+		opts.Out.Write(fmt.Sprintf("- Writing component version to archive %s\n", opts.OutputArchive))
+		writeCopyResourcesInfo(opts)
+		if err = s.registryService.ExportComponentVersion(archive, opts.OutputArchive,
+			opts.OverwriteComponentVersion, opts.CopyResources); err != nil {
+			return fmt.Errorf("failed to write component version to archive: %w", err)
+		}
+	case opts.DryRun:
+		opts.Out.Write("- Pushing component version\n")
 		opts.Out.Write("\tSkipping push due to dry-run mode\n")
-		if err = s.ensureComponentVersionDoesNotExist(archive, opts); err != nil {
-			return err
+		for _, target := range targets {
+			if _, err = s.checkExistingComponentVersion(archive, target, opts); err != nil {
+				return fmt.Errorf("registry %s: %w", target.registryURL, err)
+			}
+		}
+	default:
+		opts.Out.Write("- Pushing component version\n")
+		writeCopyResourcesInfo(opts)
+		descriptor, err = s.pushComponentVersion(archive, targets, opts)
+		if err != nil {
+			return fmt.Errorf("failed to push component version: %w", err)
 		}
 	}
 
 	opts.Out.Write("- Creating module template\n")
-	err = s.createModuleTemplate(moduleConfig, descriptor, resourcePaths)
+	err = s.createModuleTemplate(moduleConfig, descriptor, resourcePaths, buildProvenance, opts.Reproducible)
 	if err != nil {
 		return fmt.Errorf("failed to create module template: %w", err)
 	}
 	return nil
 }
 
-func (s *Service) ensureComponentVersionDoesNotExist(archive *comparch.ComponentArchive, opts Options) error {
+func writeCopyResourcesInfo(opts Options) {
+	if opts.CopyResources {
+		opts.Out.Write("\tCopying the OCI artifacts referenced by the resources\n")
+	}
+}
+
+// checkExistingComponentVersion checks if the component version already exists in the target registry. Re-publishing
+// a component version with the same normalized digest is a no-op, so it returns identical for an existing one with
+// the same content. An existing one with different content fails with the differences, unless it is overwritten.
+// If the component version is signed, an existing one without the requested signature has different content.
+func (s *Service) checkExistingComponentVersion(archive *comparch.ComponentArchive, target registryTarget,
+	opts Options,
+) (bool, error) {
 	exists, err := s.registryService.ExistsComponentVersion(archive,
-		opts.Insecure,
-		opts.Credentials,
-		opts.RegistryURL)
+		target.insecure,
+		target.credentials,
+		target.registryURL)
 	if err != nil {
-		return fmt.Errorf("failed to check if component version exists: %w", err)
+		return false, fmt.Errorf("failed to check if component version exists: %w", err)
 	}
 
 	if !exists {
 		opts.Out.Write(
 			fmt.Sprintf("\tComponent %s in version %s does not exist yet\n", archive.GetName(), archive.GetVersion()))
-		return nil
+		return false, nil
 	}
 
 	if opts.OverwriteComponentVersion {
@@ -394,41 +554,137 @@ func (s *Service) ensureComponentVersionDoesNotExist(archive *comparch.Component
 				archive.GetVersion(),
 			),
 		)
-		return nil
+		return false, nil
 	}
 
-	return fmt.Errorf("component %s in version %s already exists: %w", archive.GetName(), archive.GetVersion(),
-		ErrComponentVersionExists)
+	differences, err := s.registryService.CompareComponentVersion(archive,
+		target.insecure,
+		opts.CopyResources,
+		target.credentials,
+		target.registryURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to compare with the existing component version: %w", err)
+	}
+	if opts.SigningKeyFile != "" {
+		signed, err := s.hasSignature(archive, target, opts.SignatureName)
+		if err != nil {
+			return false, err
+		}
+		if !signed {
+			differences = append(differences, "+ signature "+opts.SignatureName)
+		}
+	}
+	if len(differences) > 0 {
+		return false, fmt.Errorf("component %s in version %s already exists with different content: %w\n%s",
+			archive.GetName(), archive.GetVersion(), ErrComponentVersionExists, strings.Join(differences, "\n"))
+	}
+
+	opts.Out.Write(fmt.Sprintf("\tComponent %s in version %s already exists with identical content\n",
+		archive.GetName(), archive.GetVersion()))
+	return true, nil
 }
 
-func (s *Service) pushComponentVersion(archive *comparch.ComponentArchive, opts Options) (
+// hasSignature returns whether the existing component version has the signature with the given name. The
+// signatures are left out of the compared content, so an existing unsigned component version would otherwise be
+// kept instead of the signed one.
+func (s *Service) hasSignature(archive *comparch.ComponentArchive, target registryTarget, name string,
+) (bool, error) {
+	componentVersion, err := s.registryService.GetComponentVersion(archive, target.insecure, target.credentials,
+		target.registryURL)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the existing component version: %w", err)
+	}
+	return componentVersion.GetDescriptor().GetSignatureIndex(name) >= 0, nil
+}
+
+// pushComponentVersion pushes the component version to every target registry, also if the push to a previous one
+// failed, and reports the result per target. It returns the descriptor of the component version pushed to the
+// primary target, the first one, which the ModuleTemplate is rendered from.
+func (s *Service) pushComponentVersion(archive *comparch.ComponentArchive, targets []registryTarget, opts Options) (
 	*compdesc.ComponentDescriptor,
 	error,
 ) {
-	if err := s.registryService.PushComponentVersion(archive,
-		opts.Insecure,
-		opts.OverwriteComponentVersion,
-		opts.Credentials,
-		opts.RegistryURL); err != nil {
-		return nil, fmt.Errorf("failed to push component archive: %w", err)
+	var descriptor *compdesc.ComponentDescriptor
+	var errs []error
+	for i, target := range targets {
+		pushed, err := s.pushComponentVersionToTarget(archive, target, opts)
+		if err != nil {
+			opts.Out.Write(fmt.Sprintf("\tFailed to push to %s: %v\n", target.registryURL, err))
+			errs = append(errs, fmt.Errorf("%s: %w", target.registryURL, err))
+			continue
+		}
+		if pushed {
+			opts.Out.Write(fmt.Sprintf("\tPushed to %s\n", target.registryURL))
+		} else {
+			opts.Out.Write(fmt.Sprintf("\tSkipped push to %s\n", target.registryURL))
+		}
+
+		if i > 0 {
+			continue
+		}
+		componentVersionAccess, err := s.registryService.GetComponentVersion(archive, target.insecure,
+			target.credentials, target.registryURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get component version from %s: %w", target.registryURL, err))
+			continue
+		}
+		descriptor = componentVersionAccess.GetDescriptor()
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed for %d of %d registries: %w", len(errs), len(targets), errors.Join(errs...))
 	}
+	return descriptor, nil
+}
 
-	componentVersionAccess, err := s.registryService.GetComponentVersion(archive, opts.Insecure, opts.Credentials,
-		opts.RegistryURL)
+// pushComponentVersionToTarget pushes the component version to the target registry, unless it already exists there
+// with identical content. It returns whether the component version was pushed.
+func (s *Service) pushComponentVersionToTarget(archive *comparch.ComponentArchive, target registryTarget,
+	opts Options,
+) (bool, error) {
+	identical, err := s.checkExistingComponentVersion(archive, target, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get component version: %w", err)
+		return false, err
+	}
+	if identical {
+		return false, nil
 	}
 
-	return componentVersionAccess.GetDescriptor(), nil
+	if err = s.registryService.PushComponentVersion(archive,
+		target.insecure,
+		opts.OverwriteComponentVersion,
+		opts.CopyResources,
+		target.credentials,
+		target.registryURL); err != nil {
+		return false, fmt.Errorf("failed to push component archive: %w", err)
+	}
+	return true, nil
 }
 
-func (s *Service) extractImagesFromManifest(manifestFilePath string, opts Options) ([]string, error) {
-	opts.Out.Write("- Extracting images from raw manifest\n")
-	images, err := s.manifestService.ExtractImagesFromManifest(manifestFilePath)
+// pinImageDigests appends the registry digest to every image referenced by tag only if digest pinning is enabled,
+// so that the component references exactly the images that were scanned.
+func (s *Service) pinImageDigests(images []string, opts Options) ([]string, error) {
+	if !opts.PinDigests {
+		return images, nil
+	}
+
+	opts.Out.Write("- Pinning image digests\n")
+	digests, err := s.imageDigestService.ResolveDigests(images, opts.Insecure, opts.Credentials, opts.RegistryURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract images from manifest: %w", err)
+		return nil, fmt.Errorf("failed to resolve image digests: %w", err)
+	}
+
+	pinnedImages := make([]string, 0, len(images))
+	for _, image := range images {
+		digest, ok := digests[image]
+		if !ok {
+			pinnedImages = append(pinnedImages, image)
+			continue
+		}
+		opts.Out.Write(fmt.Sprintf("\t%s -> %s\n", image, digest))
+		pinnedImages = append(pinnedImages, image+"@"+digest)
 	}
-	return images, nil
+	return pinnedImages, nil
 }
 
 func addImagesOciArtifactsToDescriptor(descriptor *compdesc.ComponentDescriptor,
@@ -449,19 +705,12 @@ func getSecurityScanEnabled(moduleConfig *contentprovider.ModuleConfig) bool {
 	return *moduleConfig.SecurityScanEnabled
 }
 
-func (s *Service) cleanupTempFiles(opts Options) {
-	if err := s.defaultCRFileResolver.CleanupTempFiles(); err != nil {
-		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary default CR files: %v\n", err))
-	}
-	if err := s.manifestFileResolver.CleanupTempFiles(); err != nil {
-		opts.Out.Write(fmt.Sprintf("failed to cleanup temporary manifest files: %v\n", err))
-	}
-}
-
 func (s *Service) createModuleTemplate(
 	moduleConfig *contentprovider.ModuleConfig,
 	descriptorToRender *compdesc.ComponentDescriptor,
 	resourcePaths *types.ResourcePaths,
+	buildProvenance *provenance.Provenance,
+	reproducible bool,
 ) error {
 	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths)
 	if err != nil {
@@ -480,7 +729,9 @@ func (s *Service) createModuleTemplate(
 		descriptorToRender,
 		crData,
 		isCRDClusterScoped,
-		resourcePaths.ModuleTemplate); err != nil {
+		buildProvenance.Annotations(),
+		resourcePaths.ModuleTemplate,
+		reproducible); err != nil {
 		return fmt.Errorf("failed to generate module template: %w", err)
 	}
 
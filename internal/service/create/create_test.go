@@ -1,32 +1,43 @@
 package create_test
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/common/types/component"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/componentarchive"
 	"github.com/kyma-project/modulectl/internal/service/componentdescriptor/resources"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	"github.com/kyma-project/modulectl/internal/service/create"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	"github.com/kyma-project/modulectl/internal/testutils"
 	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
 func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
 	_, err := create.NewService(nil, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
 		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
 		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 
 	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
 	require.Contains(t, err.Error(), "moduleConfigService")
@@ -34,11 +45,13 @@ func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
 
 func Test_CreateModule_ReturnsError_WhenModuleConfigFileIsEmpty(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withModuleConfigFile("").build()
@@ -51,11 +64,13 @@ func Test_CreateModule_ReturnsError_WhenModuleConfigFileIsEmpty(t *testing.T) {
 
 func Test_CreateModule_ReturnsError_WhenOutIsNil(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withOut(nil).build()
@@ -68,11 +83,13 @@ func Test_CreateModule_ReturnsError_WhenOutIsNil(t *testing.T) {
 
 func Test_CreateModule_ReturnsError_WhenCredentialsIsInInvalidFormat(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withCredentials("user").build()
@@ -85,11 +102,13 @@ func Test_CreateModule_ReturnsError_WhenCredentialsIsInInvalidFormat(t *testing.
 
 func Test_CreateModule_ReturnsError_WhenTemplateOutputIsEmpty(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withTemplateOutput("").build()
@@ -102,11 +121,13 @@ func Test_CreateModule_ReturnsError_WhenTemplateOutputIsEmpty(t *testing.T) {
 
 func Test_CreateModule_ReturnsError_WhenParseAndValidateModuleConfigReturnsError(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceParseErrorStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -119,11 +140,13 @@ func Test_CreateModule_ReturnsError_WhenParseAndValidateModuleConfigReturnsError
 
 func Test_CreateModule_ReturnsError_WhenResolvingManifestFilePathReturnsError(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverErrorStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -136,11 +159,13 @@ func Test_CreateModule_ReturnsError_WhenResolvingManifestFilePathReturnsError(t
 
 func Test_CreateModule_ReturnsError_WhenResolvingDefaultCRFilePathReturnsError(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverErrorStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().build()
@@ -153,11 +178,13 @@ func Test_CreateModule_ReturnsError_WhenResolvingDefaultCRFilePathReturnsError(t
 
 func Test_CreateModule_ReturnsError_WhenModuleSourcesGitDirectoryIsEmpty(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withModuleSourcesGitDirectory("").build()
@@ -170,11 +197,13 @@ func Test_CreateModule_ReturnsError_WhenModuleSourcesGitDirectoryIsEmpty(t *test
 
 func Test_CreateModule_ReturnsError_WhenModuleSourcesIsNotGitDirectory(t *testing.T) {
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{},
 		&fileResolverStub{},
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withModuleSourcesGitDirectory(".").build()
@@ -192,11 +221,13 @@ func Test_CreateModule_ReturnsError_WhenRegistryPushIsDisabled_AndVersionCheckFa
 	manifestResolverStub := &fileResolverStub{}
 	defaultCRResolverStub := &fileResolverStub{}
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierErrorStub{expectedErrMsg}, &manifestServiceStub{},
 		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().
@@ -217,11 +248,13 @@ func Test_CreateModule_CleansUpTempFiles_WhenRegistryPushIsEnabled(t *testing.T)
 	manifestResolverStub := &fileResolverStub{}
 	defaultCRResolverStub := &fileResolverStub{}
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
 		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().withDisableOCMRegistryPush(false).build() // registry push enabled
@@ -242,11 +275,13 @@ func Test_CreateModule_DoesNotCleanUpTempFiles_WhenConstructorMode_AndSuccess(t
 	manifestResolverStub := &fileResolverStub{}
 	defaultCRResolverStub := &fileResolverStub{}
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{},
 		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
 		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().
@@ -269,11 +304,13 @@ func Test_CreateModule_CleansUpTempFiles_WhenConstructorMode_AndError(t *testing
 	manifestResolverStub := &fileResolverStub{}
 	defaultCRResolverStub := &fileResolverStub{}
 	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceErrorStub{},
+		&securityConfigServiceStub{},
 		&componentConstructorServiceStub{}, &componentArchiveServiceStub{},
 		&registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
 		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
 		manifestResolverStub, defaultCRResolverStub,
-		&fileExistsStub{})
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 	require.NoError(t, err)
 
 	opts := newCreateOptionsBuilder().
@@ -291,220 +328,1562 @@ func Test_CreateModule_CleansUpTempFiles_WhenConstructorMode_AndError(t *testing
 		"expected default CR resolver to clean up temporary files on error")
 }
 
-type createOptionsBuilder struct {
-	options create.Options
+func Test_NewService_ReturnsError_WhenManifestRendererIsNil(t *testing.T) {
+	_, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
+		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		nil, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "manifestRenderer")
 }
 
-func newCreateOptionsBuilder() *createOptionsBuilder {
-	builder := &createOptionsBuilder{
-		options: create.Options{},
-	}
+func Test_CreateModule_RendersManifestFromChart_WhenChartIsSet(t *testing.T) {
+	manifestRenderer := &manifestRendererStub{}
+	svc, err := create.NewService(&moduleConfigServiceWithChartStub{}, &gitSourcesServiceErrorStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{},
+		&registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverErrorStub{}, &fileResolverStub{},
+		manifestRenderer, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-	return builder.
-		withOut(iotools.NewDefaultOut(io.Discard)).
-		withModuleConfigFile("create-module-config.yaml").
-		withRegistryURL("https://registry.kyma.cx").
-		withTemplateOutput("test").
-		withCredentials("user:password").
-		withModuleSourcesGitDirectory("../../../")
+	err = svc.Run(newCreateOptionsBuilder().
+		withModuleConfigFile("config/module-config.yaml").
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
+
+	require.ErrorContains(t, err, "failed to add git sources to constructor")
+	require.NotNil(t, manifestRenderer.chart)
+	assert.Equal(t, "charts/telemetry", manifestRenderer.chart.Path)
+	assert.Equal(t, "config", manifestRenderer.basePath)
+	assert.Equal(t, 1, manifestRenderer.cleanupTempFilesCallCount,
+		"expected manifest renderer to clean up temporary files on error")
 }
 
-func (b *createOptionsBuilder) build() create.Options {
-	return b.options
-}
+func Test_CreateModule_BuildsManifestFromKustomization_WhenKustomizationIsSet(t *testing.T) {
+	manifestRenderer := &manifestRendererStub{}
+	svc, err := create.NewService(&moduleConfigServiceWithKustomizationStub{}, &gitSourcesServiceErrorStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{},
+		&registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverErrorStub{}, &fileResolverStub{},
+		manifestRenderer, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (b *createOptionsBuilder) withOut(out iotools.Out) *createOptionsBuilder {
-	b.options.Out = out
-	return b
-}
+	err = svc.Run(newCreateOptionsBuilder().
+		withModuleConfigFile("config/module-config.yaml").
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
 
-func (b *createOptionsBuilder) withModuleConfigFile(moduleConfigFile string) *createOptionsBuilder {
-	b.options.ConfigFile = moduleConfigFile
-	return b
+	require.ErrorContains(t, err, "failed to add git sources to constructor")
+	assert.Equal(t, "overlays/prod", manifestRenderer.kustomizationDir)
+	assert.Equal(t, "config", manifestRenderer.basePath)
 }
 
-func (b *createOptionsBuilder) withRegistryURL(registryURL string) *createOptionsBuilder {
-	b.options.RegistryURL = registryURL
-	return b
-}
+func Test_CreateModule_ReturnsError_WhenDefaultCRIsInvalid(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{},
+		&registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceErrorStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (b *createOptionsBuilder) withTemplateOutput(templateOutput string) *createOptionsBuilder {
-	b.options.TemplateOutput = templateOutput
-	return b
-}
+	err = svc.Run(newCreateOptionsBuilder().build())
 
-func (b *createOptionsBuilder) withCredentials(credentials string) *createOptionsBuilder {
-	b.options.Credentials = credentials
-	return b
+	require.ErrorIs(t, err, errInvalidDefaultCR)
+	require.ErrorContains(t, err, "failed to validate default CR")
 }
 
-func (b *createOptionsBuilder) withModuleSourcesGitDirectory(moduleSourcesGitDirectory string) *createOptionsBuilder {
-	b.options.ModuleSourcesGitDirectory = moduleSourcesGitDirectory
-	return b
-}
+func Test_CreateModule_ReturnsError_WhenChartCannotBeRendered(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceWithChartStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{},
+		&registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererErrorStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (b *createOptionsBuilder) withDisableOCMRegistryPush(disableRegistryPush bool) *createOptionsBuilder {
-	b.options.DisableOCMRegistryPush = disableRegistryPush
-	return b
-}
+	err = svc.Run(newCreateOptionsBuilder().build())
 
-func (b *createOptionsBuilder) withOutputConstructorFile(outputConstructorFile string) *createOptionsBuilder {
-	b.options.OutputConstructorFile = outputConstructorFile
-	return b
+	require.ErrorContains(t, err, "failed to render manifest from chart")
 }
 
-type fileExistsStub struct{}
+func Test_CreateModule_ReturnsError_WhenSecurityConfigCannotBeParsed(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceWithSecurityStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceErrorStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (*fileExistsStub) FileExists(_ string) (bool, error) {
-	return true, nil
-}
+	err = svc.Run(newCreateOptionsBuilder().withModuleConfigFile("config/module-config.yaml").build())
 
-func (*fileExistsStub) ReadFile(_ string) ([]byte, error) {
-	return nil, nil
+	require.ErrorContains(t, err, "failed to parse security config")
+	require.ErrorContains(t, err, `"config/sec-scanners-config.yaml"`)
 }
 
-type fileResolverStub struct {
-	cleanupTempFilesCallCount int // to track how many times CleanupTempFiles is called
-}
+func Test_CreateModule_ReturnsError_WhenBDBAImageTagsDoNotMatchModuleVersion(t *testing.T) {
+	securityConfigService := &securityConfigServiceStub{
+		securityConfig: &contentprovider.SecurityScanConfig{
+			BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.42.0"},
+		},
+	}
+	svc, err := create.NewService(&moduleConfigServiceWithSecurityStub{}, &gitSourcesServiceStub{},
+		securityConfigService,
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (*fileResolverStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
-	return "/tmp/some-file.yaml", nil
-}
+	err = svc.Run(newCreateOptionsBuilder().withDisableOCMRegistryPush(true).build())
 
-func (frs *fileResolverStub) CleanupTempFiles() []error {
-	frs.cleanupTempFilesCallCount++
-	return nil
+	require.ErrorContains(t, err, "failed to validate BDBA image tags")
 }
 
-type fileResolverErrorStub struct{}
+func Test_CreateModule_ReturnsError_WhenBDBAImagesAreNotInManifest(t *testing.T) {
+	securityConfigService := &securityConfigServiceStub{
+		securityConfig: &contentprovider.SecurityScanConfig{
+			BDBA: []string{
+				"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1",
+				"europe-docker.pkg.dev/kyma-project/prod/fluent-bit:3.0.0",
+			},
+		},
+	}
+	manifestService := &manifestServiceImagesStub{
+		images: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+	}
+	svc, err := create.NewService(&moduleConfigServiceWithSecurityStub{}, &gitSourcesServiceStub{},
+		securityConfigService,
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (*fileResolverErrorStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
-	return "", errors.New("failed to resolve file")
-}
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
 
-func (*fileResolverErrorStub) CleanupTempFiles() []error {
-	return []error{errors.New("failed to cleanup temp files")}
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err,
+		"BDBA images [europe-docker.pkg.dev/kyma-project/prod/fluent-bit:3.0.0] are not used in the manifest")
+	assert.False(t, securityConfigService.appendCalled)
 }
 
-type moduleConfigServiceStub struct{}
-
-func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string) (*contentprovider.ModuleConfig, error) {
-	var fileRef contentprovider.UrlOrLocalFile
-	if err := fileRef.FromString("default-cr.yaml"); err != nil {
-		return nil, err
+func Test_CreateModule_AddsSecurityLabels_WhenSecurityConfigIsValid(t *testing.T) {
+	securityConfigService := &securityConfigServiceStub{
+		securityConfig: &contentprovider.SecurityScanConfig{
+			BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+		},
 	}
-	return &contentprovider.ModuleConfig{
-		Name:      "kyma-project.io/module/telemetry",
-		DefaultCR: fileRef,
-		Version:   "1.43.1",
-	}, nil
-}
+	manifestService := &manifestServiceImagesStub{
+		images: []string{
+			"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1",
+			"europe-docker.pkg.dev/kyma-project/prod/external/busybox:1.34.1",
+		},
+	}
+	svc, err := create.NewService(&moduleConfigServiceWithSecurityStub{}, &gitSourcesServiceStub{},
+		securityConfigService,
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
 
-type moduleConfigServiceParseErrorStub struct{}
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(out)).
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
 
-func (*moduleConfigServiceParseErrorStub) ParseAndValidateModuleConfig(
-	_ string,
-) (*contentprovider.ModuleConfig, error) {
-	return nil, errors.New("failed to read module config file")
+	require.NoError(t, err)
+	assert.True(t, securityConfigService.appendCalled)
+	assert.Contains(t, out.String(), "Warning: image europe-docker.pkg.dev/kyma-project/prod/external/busybox:1.34.1 "+
+		"is not listed in the BDBA images")
 }
 
-type gitSourcesServiceStub struct{}
+func Test_NewService_ReturnsError_WhenImageDigestServiceIsNil(t *testing.T) {
+	_, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
+		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, nil,
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
 
-func (s *gitSourcesServiceStub) AddGitSourcesToConstructor(_ *component.Constructor,
-	_, _ string,
-) error {
-	return nil
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "imageDigestService")
 }
 
-func (*gitSourcesServiceStub) AddGitSources(_ *compdesc.ComponentDescriptor,
-	_, _, _ string,
-) error {
-	return nil
-}
+func Test_CreateModule_PinsImageDigests_WhenPinDigestsIsSet(t *testing.T) {
+	componentConstructorService := &componentConstructorServiceStub{}
+	manifestService := &manifestServiceImagesStub{
+		images: []string{
+			"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1",
+			"europe-docker.pkg.dev/kyma-project/prod/external/busybox:1.34.1@sha256:" + strings.Repeat("b", 64),
+		},
+	}
+	imageDigestService := &imageDigestServiceStub{
+		digests: map[string]string{
+			"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1": "sha256:" + strings.Repeat("a", 64),
+		},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		componentConstructorService,
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, imageDigestService,
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
 
-type gitSourcesServiceErrorStub struct{}
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(out)).
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withPinDigests(true).
+		build())
 
-func (s *gitSourcesServiceErrorStub) AddGitSourcesToConstructor(_ *component.Constructor,
-	_, _ string,
-) error {
-	return errors.New("unexpected error")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1@sha256:" + strings.Repeat("a", 64),
+		"europe-docker.pkg.dev/kyma-project/prod/external/busybox:1.34.1@sha256:" + strings.Repeat("b", 64),
+	}, componentConstructorService.images)
+	assert.Equal(t, "user:password", imageDigestService.credentials)
+	assert.Contains(t, out.String(), "europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1 -> sha256:")
 }
 
-func (*gitSourcesServiceErrorStub) AddGitSources(_ *compdesc.ComponentDescriptor,
-	_, _, _ string,
-) error {
-	return errors.New("unexpected error")
+func Test_NewService_ReturnsError_WhenCredentialServiceIsNil(t *testing.T) {
+	_, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
+		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		nil, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "credentialService")
 }
 
-type componentConstructorServiceStub struct{}
+func Test_CreateModule_UsesResolvedCredentials_WhenCredentialsFlagIsNotSet(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{
+		images: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+	}
+	imageDigestService := &imageDigestServiceStub{}
+	credentialService := &credentialServiceStub{credentials: "file-user:file-pass"}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, imageDigestService,
+		credentialService, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (c *componentConstructorServiceStub) AddImagesToConstructor(_ *component.Constructor,
-	_ []string,
-) error {
-	return nil
-}
+	err = svc.Run(newCreateOptionsBuilder().
+		withCredentials("").
+		withCredentialsFile("credentials").
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withPinDigests(true).
+		build())
 
-func (c *componentConstructorServiceStub) AddResources(_ *component.Constructor,
-	_ *types.ResourcePaths,
-) error {
-	return nil
+	require.NoError(t, err)
+	assert.Equal(t, "credentials", credentialService.credentialsFile)
+	assert.Equal(t, "file-user:file-pass", imageDigestService.credentials)
 }
 
-func (c *componentConstructorServiceStub) CreateConstructorFile(_ *component.Constructor,
-	_ string,
-) error {
-	return nil
-}
+func Test_CreateModule_ReturnsError_WhenCredentialsCannotBeResolved(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceErrorStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-type componentArchiveServiceStub struct{}
+	err = svc.Run(newCreateOptionsBuilder().
+		withCredentials("").
+		withCredentialsFile("credentials").
+		build())
 
-func (*componentArchiveServiceStub) CreateComponentArchive(_ *compdesc.ComponentDescriptor) (
-	*comparch.ComponentArchive,
-	error,
-) {
-	return &comparch.ComponentArchive{}, nil
+	require.ErrorContains(t, err, "failed to resolve registry credentials")
 }
 
-func (*componentArchiveServiceStub) AddModuleResourcesToArchive(_ componentarchive.ComponentArchive,
-	_ []resources.Resource,
-) error {
-	return nil
-}
+func Test_CreateModule_DoesNotResolveCredentials_WhenConstructorMode(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceErrorStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-type registryServiceStub struct{}
+	err = svc.Run(newCreateOptionsBuilder().
+		withCredentials("").
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
 
-func (*registryServiceStub) PushComponentVersion(_ *comparch.ComponentArchive, _, _ bool,
-	_, _ string,
-) error {
-	return nil
+	require.NoError(t, err)
 }
 
-func (*registryServiceStub) GetComponentVersion(_ *comparch.ComponentArchive, _ bool,
-	_, _ string,
-) (cpi.ComponentVersionAccess, error) {
-	var componentVersion cpi.ComponentVersionAccess
-	return componentVersion, nil
-}
+func Test_CreateModule_DoesNotResolveCredentials_WhenOutputArchiveIsSet(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceErrorStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (*registryServiceStub) ExistsComponentVersion(_ *comparch.ComponentArchive, _ bool,
-	_, _ string,
-) (bool, error) {
-	return false, nil
+	err = svc.Run(newCreateOptionsBuilder().
+		withCredentials("").
+		withOutputArchive("template-operator.tgz").
+		build())
+
+	require.NoError(t, err)
 }
 
-type ModuleTemplateServiceStub struct{}
+func Test_CreateModule_ConfiguresTargetRegistry(t *testing.T) {
+	credentialService := &credentialServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		credentialService, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withCredentials("").
+		withRegistryTokenFile("token").
+		withRegistryCAFile("ca.crt").
+		withRegistryClientCert("client.crt", "client.key").
+		build())
 
-func (*ModuleTemplateServiceStub) GenerateModuleTemplate(_ *contentprovider.ModuleConfig,
-	_ *compdesc.ComponentDescriptor,
-	_ []byte, _ bool, _ string,
-) error {
-	return nil
+	require.NoError(t, err)
+	assert.Equal(t, credential.TargetRegistryConfig{
+		RegistryURL:    "https://registry.kyma.cx",
+		TokenFile:      "token",
+		CAFile:         "ca.crt",
+		ClientCertFile: "client.crt",
+		ClientKeyFile:  "client.key",
+	}, credentialService.targetRegistryConfig)
 }
 
-type CRDParserServiceStub struct{}
+func Test_CreateModule_ReturnsError_WhenTargetRegistryCannotBeConfigured(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceConfigureErrorStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
 
-func (*CRDParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
-	return false, nil
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryCAFile("ca.crt").
+		build())
+
+	require.ErrorContains(t, err, "failed to configure target registry")
+}
+
+func Test_CreateModule_DoesNotPinImageDigests_WhenPinDigestsIsNotSet(t *testing.T) {
+	componentConstructorService := &componentConstructorServiceStub{}
+	manifestService := &manifestServiceImagesStub{
+		images: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		componentConstructorService,
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceErrorStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+		componentConstructorService.images)
+}
+
+func Test_CreateModule_ReturnsError_WhenImageDigestsCannotBeResolved(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceErrorStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withPinDigests(true).
+		build())
+
+	require.ErrorContains(t, err, "failed to pin image digests")
+}
+
+func Test_CreateModule_GeneratesReproducibleModuleTemplate_WhenReproducibleIsSet(t *testing.T) {
+	moduleTemplateService := &ModuleTemplateServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, moduleTemplateService, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withReproducible(true).
+		build())
+
+	require.NoError(t, err)
+	assert.True(t, moduleTemplateService.reproducible)
+}
+
+func Test_NewService_ReturnsError_WhenProvenanceServiceIsNil(t *testing.T) {
+	_, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
+		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, nil, &signatureServiceStub{}, &fileExistsStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "provenanceService")
+}
+
+func Test_CreateModule_WritesProvenanceStatement_WhenProvenanceOutputIsSet(t *testing.T) {
+	provenanceService := &provenanceServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, provenanceService, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withTemplateOutput("template.yaml").
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withProvenanceOutput("provenance.json").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, "../../../", provenanceService.gitRepoPath)
+	assert.Equal(t, []string{"template.yaml", "constructor.yaml"}, provenanceService.artifacts)
+	assert.Equal(t, "provenance.json", provenanceService.statementOutput)
+}
+
+func Test_CreateModule_DoesNotWriteProvenanceStatement_WhenProvenanceOutputIsNotSet(t *testing.T) {
+	provenanceService := &provenanceServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, provenanceService, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		build())
+
+	require.NoError(t, err)
+	assert.Empty(t, provenanceService.statementOutput)
+}
+
+func Test_CreateModule_ReturnsError_WhenProvenanceCannotBeCollected(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceErrorStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.ErrorIs(t, err, errProvenance)
+	require.ErrorContains(t, err, "failed to collect build provenance")
+}
+
+func Test_CreateModule_ValidatesReservedKeys(t *testing.T) {
+	moduleConfigService := &moduleConfigServiceReservedKeysStub{
+		warnings: []moduleconfigreader.Violation{
+			{FieldPath: "annotations[operator.kyma-project.io/doc-url]", Err: errReservedKey},
+		},
+	}
+	svc, err := create.NewService(moduleConfigService, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(buffer)).
+		withOutputConstructorFile("constructor.yaml").
+		withDisableOCMRegistryPush(true).
+		withReservedKeyPrefixes([]string{"operator.kyma-project.io/=warning"}).
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+		{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+	}, moduleConfigService.reservedKeyPrefixes)
+	assert.Contains(t, buffer.String(),
+		"Warning: annotations[operator.kyma-project.io/doc-url]: key prefix is reserved\n")
+}
+
+func Test_CreateModule_ReturnsError_WhenReservedKeyIsUsed(t *testing.T) {
+	svc, err := create.NewService(&moduleConfigServiceReservedKeysStub{err: errReservedKey}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceStub{}, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, &manifestServiceStub{},
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().build())
+
+	require.ErrorIs(t, err, errReservedKey)
+	require.ErrorContains(t, err, "failed to validate reserved keys")
+}
+
+func Test_CreateModule_WritesArchive_WhenOutputArchiveIsSet(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceExportStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withOutputArchive("template-operator.tgz").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, "template-operator.tgz", registryService.archivePath)
+	assert.False(t, registryService.overwrite)
+	assert.False(t, registryService.copyResources)
+	assert.False(t, registryService.pushed)
+}
+
+func Test_CreateModule_CopiesResourcesIntoArchive_WhenCopyResourcesIsSet(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceExportStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputArchive("template-operator.tgz").
+		withCopyResources(true).
+		build())
+
+	require.NoError(t, err)
+	assert.True(t, registryService.copyResources)
+}
+
+func Test_CreateModule_ReturnsError_WhenArchiveCannotBeWritten(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceExportStub{err: errors.New("permission denied")}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{}, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputArchive("template-operator").
+		build())
+
+	require.ErrorContains(t, err, "failed to write component version to archive: permission denied")
+}
+
+func Test_NewService_ReturnsError_WhenSignatureServiceIsNil(t *testing.T) {
+	_, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{}, &componentArchiveServiceStub{}, &registryServiceStub{},
+		&ModuleTemplateServiceStub{}, &CRDParserServiceStub{}, &ModuleResourceServiceStub{},
+		&imageVersionVerifierStub{}, &manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, nil, &fileExistsStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "signatureService")
+}
+
+func Test_CreateModule_SignsComponentVersion_WhenSigningKeyFileIsSet(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	moduleTemplateService := &ModuleTemplateServiceStub{}
+	signatureService := &signatureServiceStub{descriptor: testutils.CreateComponentDescriptor("signed", "1.0.0")}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, &registryServiceExportStub{}, moduleTemplateService, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, signatureService, &fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputArchive("template-operator.tgz").
+		withSigning("private.pem", "chain.pem", "kyma-module").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, signature.SigningConfig{
+		Name:            "kyma-module",
+		PrivateKeyFile:  "private.pem",
+		CertificateFile: "chain.pem",
+	}, signatureService.config)
+	assert.Same(t, signatureService.descriptor, moduleTemplateService.descriptor)
+}
+
+func Test_CreateModule_ReturnsError_WhenSigningFails(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceExportStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{err: errors.New("invalid key")},
+		&fileExistsStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOutputArchive("template-operator").
+		withSigning("private.pem", "", "kyma-module").
+		build())
+
+	require.ErrorContains(t, err, "failed to sign component version: invalid key")
+	assert.Empty(t, registryService.archivePath)
+}
+
+const registryTargets = `targets:
+- registry: https://primary.kyma.cx
+  credentialsFile: primary-credentials
+- registry: http://mirror.kyma.cx
+  insecure: true
+  tokenFile: mirror-token
+`
+
+func Test_CreateModule_PushesToEveryRegistryTarget(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0")}
+	moduleTemplateService := &ModuleTemplateServiceStub{}
+	credentialService := &credentialServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, moduleTemplateService, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		credentialService, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(buffer)).
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://primary.kyma.cx", "http://mirror.kyma.cx"}, registryService.pushed)
+	assert.Equal(t, []bool{false, true}, registryService.insecure)
+	assert.Equal(t, "mirror-token", credentialService.targetRegistryConfig.TokenFile)
+	assert.Same(t, registryService.descriptor, moduleTemplateService.descriptor)
+	assert.Contains(t, buffer.String(), "Pushed to https://primary.kyma.cx")
+	assert.Contains(t, buffer.String(), "Pushed to http://mirror.kyma.cx")
+}
+
+func Test_CreateModule_UsesOnlyCredentialsFilesOfRegistryTargets(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0")}
+	credentialService := &credentialServiceStub{credentials: "user:password"}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		credentialService, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://primary.kyma.cx", "http://mirror.kyma.cx"}, registryService.pushed)
+	assert.Equal(t, []string{"user:password", ""}, registryService.credentials)
+	assert.Equal(t, "primary-credentials", credentialService.credentialsFile)
+}
+
+func Test_CreateModule_PushesToRemainingTargets_WhenPushToOneTargetFails(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{
+		descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0"),
+		failURL:    "https://primary.kyma.cx",
+	}
+	moduleTemplateService := &ModuleTemplateServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, moduleTemplateService, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(buffer)).
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		build())
+
+	require.ErrorContains(t, err, "failed for 1 of 2 registries")
+	require.ErrorContains(t, err, "https://primary.kyma.cx: failed to push component archive: push failed")
+	assert.Equal(t, []string{"https://primary.kyma.cx", "http://mirror.kyma.cx"}, registryService.pushed)
+	assert.Contains(t, buffer.String(), "Failed to push to https://primary.kyma.cx: ")
+	assert.Contains(t, buffer.String(), "Pushed to http://mirror.kyma.cx")
+	assert.Nil(t, moduleTemplateService.descriptor)
+}
+
+func Test_CreateModule_ChecksEveryRegistryTarget_InDryRunMode(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		withDryRun(true).
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://primary.kyma.cx", "http://mirror.kyma.cx"}, registryService.checked)
+	assert.Empty(t, registryService.pushed)
+}
+
+func Test_CreateModule_SkipsPush_WhenIdenticalComponentVersionExists(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{
+		descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0"),
+		existing:   map[string][]string{"https://primary.kyma.cx": nil},
+	}
+	moduleTemplateService := &ModuleTemplateServiceStub{}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, moduleTemplateService, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(buffer)).
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://mirror.kyma.cx"}, registryService.pushed)
+	assert.Same(t, registryService.descriptor, moduleTemplateService.descriptor)
+	assert.Contains(t, buffer.String(), "already exists with identical content")
+	assert.Contains(t, buffer.String(), "Skipped push to https://primary.kyma.cx")
+	assert.Contains(t, buffer.String(), "Pushed to http://mirror.kyma.cx")
+}
+
+func Test_CreateModule_ReturnsDifferences_WhenComponentVersionExistsWithDifferentContent(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{
+		descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0"),
+		existing: map[string][]string{"http://mirror.kyma.cx": {
+			"- resource raw-manifest content: sha256:pushed",
+			"+ resource raw-manifest content: sha256:built",
+		}},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		build())
+
+	require.ErrorIs(t, err, create.ErrComponentVersionExists)
+	require.ErrorContains(t, err, "already exists with different content")
+	require.ErrorContains(t, err,
+		"- resource raw-manifest content: sha256:pushed\n+ resource raw-manifest content: sha256:built")
+	assert.Equal(t, []string{"https://primary.kyma.cx"}, registryService.pushed)
+}
+
+func Test_CreateModule_ReturnsDifferences_WhenExistingComponentVersionIsNotSigned(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{
+		descriptor: testutils.CreateComponentDescriptor("primary", "1.0.0"),
+		existing:   map[string][]string{"https://primary.kyma.cx": nil},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		withSigning("private.pem", "", "kyma-module").
+		build())
+
+	require.ErrorIs(t, err, create.ErrComponentVersionExists)
+	require.ErrorContains(t, err, "+ signature kyma-module")
+	assert.Equal(t, []string{"http://mirror.kyma.cx"}, registryService.pushed)
+}
+
+func Test_CreateModule_SkipsPush_WhenExistingComponentVersionHasSignature(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	descriptor := testutils.CreateComponentDescriptor("primary", "1.0.0")
+	descriptor.Signatures = append(descriptor.Signatures, ocmv1.Signature{Name: "kyma-module"})
+	registryService := &registryServiceTargetsStub{
+		descriptor: descriptor,
+		existing:   map[string][]string{"https://primary.kyma.cx": nil},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+	buffer := &bytes.Buffer{}
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withOut(iotools.NewDefaultOut(buffer)).
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		withSigning("private.pem", "", "kyma-module").
+		build())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://mirror.kyma.cx"}, registryService.pushed)
+	assert.Contains(t, buffer.String(), "Skipped push to https://primary.kyma.cx")
+}
+
+func Test_CreateModule_Succeeds_WhenIdenticalComponentVersionExists_InDryRunMode(t *testing.T) {
+	manifestService := &manifestServiceImagesStub{images: []string{"europe-docker.pkg.dev/kyma/manager:1.43.1"}}
+	registryService := &registryServiceTargetsStub{
+		existing: map[string][]string{"https://primary.kyma.cx": nil, "http://mirror.kyma.cx": nil},
+	}
+	svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+		&securityConfigServiceStub{},
+		&componentConstructorServiceStub{},
+		&componentArchiveServiceStub{}, registryService, &ModuleTemplateServiceStub{}, &CRDParserServiceStub{},
+		&ModuleResourceServiceStub{}, &imageVersionVerifierStub{}, manifestService,
+		&fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{}, &imageDigestServiceStub{},
+		&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+		&fileSystemContentStub{content: registryTargets})
+	require.NoError(t, err)
+
+	err = svc.Run(newCreateOptionsBuilder().
+		withRegistryURL("").
+		withCredentials("").
+		withRegistryTargetsFile("targets.yaml").
+		withDryRun(true).
+		build())
+
+	require.NoError(t, err)
+	assert.Empty(t, registryService.pushed)
+}
+
+func Test_CreateModule_ReturnsError_WhenRegistryTargetsFileIsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no targets", content: "targets: []", wantErr: "does not contain a target"},
+		{
+			name:    "unknown field",
+			content: "targets:\n- registry: https://a.kyma.cx\n  user: admin",
+			wantErr: "unknown field",
+		},
+		{name: "missing registry", content: "targets:\n- insecure: true", wantErr: "registry must not be empty"},
+		{
+			name:    "duplicated registry",
+			content: "targets:\n- registry: https://a.kyma.cx\n- registry: https://a.kyma.cx",
+			wantErr: "registry target https://a.kyma.cx is duplicated",
+		},
+		{
+			name:    "client cert without key",
+			content: "targets:\n- registry: https://a.kyma.cx\n  clientCert: client.crt",
+			wantErr: "clientCert and clientKey must be set together",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, err := create.NewService(&moduleConfigServiceStub{}, &gitSourcesServiceStub{},
+				&securityConfigServiceStub{},
+				&componentConstructorServiceStub{},
+				&componentArchiveServiceStub{}, &registryServiceTargetsStub{}, &ModuleTemplateServiceStub{},
+				&CRDParserServiceStub{}, &ModuleResourceServiceStub{}, &imageVersionVerifierStub{},
+				&manifestServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+				&manifestRendererStub{}, &imageDigestServiceStub{},
+				&credentialServiceStub{}, &provenanceServiceStub{}, &signatureServiceStub{},
+				&fileSystemContentStub{content: test.content})
+			require.NoError(t, err)
+
+			err = svc.Run(newCreateOptionsBuilder().
+				withRegistryURL("").
+				withCredentials("").
+				withRegistryTargetsFile("targets.yaml").
+				build())
+
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+type createOptionsBuilder struct {
+	options create.Options
+}
+
+func newCreateOptionsBuilder() *createOptionsBuilder {
+	builder := &createOptionsBuilder{
+		options: create.Options{},
+	}
+
+	return builder.
+		withOut(iotools.NewDefaultOut(io.Discard)).
+		withModuleConfigFile("create-module-config.yaml").
+		withRegistryURL("https://registry.kyma.cx").
+		withTemplateOutput("test").
+		withCredentials("user:password").
+		withModuleSourcesGitDirectory("../../../")
+}
+
+func (b *createOptionsBuilder) build() create.Options {
+	return b.options
+}
+
+func (b *createOptionsBuilder) withOut(out iotools.Out) *createOptionsBuilder {
+	b.options.Out = out
+	return b
+}
+
+func (b *createOptionsBuilder) withModuleConfigFile(moduleConfigFile string) *createOptionsBuilder {
+	b.options.ConfigFile = moduleConfigFile
+	return b
+}
+
+func (b *createOptionsBuilder) withRegistryURL(registryURL string) *createOptionsBuilder {
+	b.options.RegistryURL = registryURL
+	return b
+}
+
+func (b *createOptionsBuilder) withTemplateOutput(templateOutput string) *createOptionsBuilder {
+	b.options.TemplateOutput = templateOutput
+	return b
+}
+
+func (b *createOptionsBuilder) withCredentials(credentials string) *createOptionsBuilder {
+	b.options.Credentials = credentials
+	return b
+}
+
+func (b *createOptionsBuilder) withModuleSourcesGitDirectory(moduleSourcesGitDirectory string) *createOptionsBuilder {
+	b.options.ModuleSourcesGitDirectory = moduleSourcesGitDirectory
+	return b
+}
+
+func (b *createOptionsBuilder) withDisableOCMRegistryPush(disableRegistryPush bool) *createOptionsBuilder {
+	b.options.DisableOCMRegistryPush = disableRegistryPush
+	return b
+}
+
+func (b *createOptionsBuilder) withPinDigests(pinDigests bool) *createOptionsBuilder {
+	b.options.PinDigests = pinDigests
+	return b
+}
+
+func (b *createOptionsBuilder) withReproducible(reproducible bool) *createOptionsBuilder {
+	b.options.Reproducible = reproducible
+	return b
+}
+
+func (b *createOptionsBuilder) withCredentialsFile(credentialsFile string) *createOptionsBuilder {
+	b.options.CredentialsFile = credentialsFile
+	return b
+}
+
+func (b *createOptionsBuilder) withRegistryTokenFile(registryTokenFile string) *createOptionsBuilder {
+	b.options.RegistryTokenFile = registryTokenFile
+	return b
+}
+
+func (b *createOptionsBuilder) withRegistryCAFile(registryCAFile string) *createOptionsBuilder {
+	b.options.RegistryCAFile = registryCAFile
+	return b
+}
+
+func (b *createOptionsBuilder) withRegistryClientCert(clientCert, clientKey string) *createOptionsBuilder {
+	b.options.RegistryClientCert = clientCert
+	b.options.RegistryClientKey = clientKey
+	return b
+}
+
+func (b *createOptionsBuilder) withReservedKeyPrefixes(reservedKeyPrefixes []string) *createOptionsBuilder {
+	b.options.ReservedKeyPrefixes = reservedKeyPrefixes
+	return b
+}
+
+func (b *createOptionsBuilder) withProvenanceOutput(provenanceOutput string) *createOptionsBuilder {
+	b.options.ProvenanceOutput = provenanceOutput
+	return b
+}
+
+func (b *createOptionsBuilder) withOutputArchive(outputArchive string) *createOptionsBuilder {
+	b.options.OutputArchive = outputArchive
+	return b
+}
+
+func (b *createOptionsBuilder) withDryRun(dryRun bool) *createOptionsBuilder {
+	b.options.DryRun = dryRun
+	return b
+}
+
+func (b *createOptionsBuilder) withRegistryTargetsFile(registryTargetsFile string) *createOptionsBuilder {
+	b.options.RegistryTargetsFile = registryTargetsFile
+	return b
+}
+
+func (b *createOptionsBuilder) withCopyResources(copyResources bool) *createOptionsBuilder {
+	b.options.CopyResources = copyResources
+	return b
+}
+
+func (b *createOptionsBuilder) withSigning(keyFile, certificateFile, name string) *createOptionsBuilder {
+	b.options.SigningKeyFile = keyFile
+	b.options.SigningCertificateFile = certificateFile
+	b.options.SignatureName = name
+	return b
+}
+
+func (b *createOptionsBuilder) withOutputConstructorFile(outputConstructorFile string) *createOptionsBuilder {
+	b.options.OutputConstructorFile = outputConstructorFile
+	return b
+}
+
+type fileExistsStub struct{}
+
+func (*fileExistsStub) FileExists(_ string) (bool, error) {
+	return true, nil
+}
+
+func (*fileExistsStub) ReadFile(_ string) ([]byte, error) {
+	return nil, nil
+}
+
+type fileSystemContentStub struct {
+	fileExistsStub
+
+	content string
+}
+
+func (s *fileSystemContentStub) ReadFile(_ string) ([]byte, error) {
+	return []byte(s.content), nil
+}
+
+type fileResolverStub struct {
+	cleanupTempFilesCallCount int // to track how many times CleanupTempFiles is called
+}
+
+func (*fileResolverStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
+	return "/tmp/some-file.yaml", nil
+}
+
+func (*fileResolverStub) ResolveAll(_ contentprovider.UrlOrLocalFiles, _ string) (string, error) {
+	return "/tmp/some-file.yaml", nil
+}
+
+func (frs *fileResolverStub) CleanupTempFiles() []error {
+	frs.cleanupTempFilesCallCount++
+	return nil
+}
+
+type fileResolverErrorStub struct{}
+
+func (*fileResolverErrorStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
+	return "", errors.New("failed to resolve file")
+}
+
+func (*fileResolverErrorStub) ResolveAll(_ contentprovider.UrlOrLocalFiles, _ string) (string, error) {
+	return "", errors.New("failed to resolve file")
+}
+
+func (*fileResolverErrorStub) CleanupTempFiles() []error {
+	return []error{errors.New("failed to cleanup temp files")}
+}
+
+var errReservedKey = errors.New("key prefix is reserved")
+
+// reservedKeysStub is embedded by the module config service stubs to report no reserved keys.
+type reservedKeysStub struct{}
+
+func (*reservedKeysStub) ValidateReservedKeys(_ *contentprovider.ModuleConfig,
+	_ []validation.ReservedKeyPrefix,
+) ([]moduleconfigreader.Violation, error) {
+	return nil, nil
+}
+
+type moduleConfigServiceStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string, _ bool) (*contentprovider.ModuleConfig, error) {
+	var fileRef contentprovider.UrlOrLocalFile
+	if err := fileRef.FromString("default-cr.yaml"); err != nil {
+		return nil, err
+	}
+	return &contentprovider.ModuleConfig{
+		Name:      "kyma-project.io/module/telemetry",
+		DefaultCR: fileRef,
+		Version:   "1.43.1",
+	}, nil
+}
+
+type moduleConfigServiceReservedKeysStub struct {
+	moduleConfigServiceStub
+
+	warnings            []moduleconfigreader.Violation
+	err                 error
+	reservedKeyPrefixes []validation.ReservedKeyPrefix
+}
+
+func (s *moduleConfigServiceReservedKeysStub) ValidateReservedKeys(_ *contentprovider.ModuleConfig,
+	reservedKeyPrefixes []validation.ReservedKeyPrefix,
+) ([]moduleconfigreader.Violation, error) {
+	s.reservedKeyPrefixes = reservedKeyPrefixes
+	return s.warnings, s.err
+}
+
+type moduleConfigServiceParseErrorStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceParseErrorStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return nil, errors.New("failed to read module config file")
+}
+
+type gitSourcesServiceStub struct{}
+
+func (s *gitSourcesServiceStub) AddGitSourcesToConstructor(_ *component.Constructor,
+	_, _ string,
+) error {
+	return nil
+}
+
+func (*gitSourcesServiceStub) AddGitSources(_ *compdesc.ComponentDescriptor,
+	_, _, _ string,
+) error {
+	return nil
+}
+
+type gitSourcesServiceErrorStub struct{}
+
+func (s *gitSourcesServiceErrorStub) AddGitSourcesToConstructor(_ *component.Constructor,
+	_, _ string,
+) error {
+	return errors.New("unexpected error")
+}
+
+func (*gitSourcesServiceErrorStub) AddGitSources(_ *compdesc.ComponentDescriptor,
+	_, _, _ string,
+) error {
+	return errors.New("unexpected error")
+}
+
+type componentConstructorServiceStub struct {
+	images []string
+}
+
+func (c *componentConstructorServiceStub) AddImagesToConstructor(_ *component.Constructor,
+	images []string,
+) error {
+	c.images = images
+	return nil
+}
+
+func (c *componentConstructorServiceStub) AddResources(_ *component.Constructor,
+	_ *types.ResourcePaths,
+) error {
+	return nil
+}
+
+func (c *componentConstructorServiceStub) CreateConstructorFile(_ *component.Constructor,
+	_ string,
+) error {
+	return nil
+}
+
+type imageDigestServiceStub struct {
+	digests     map[string]string
+	credentials string
+}
+
+func (s *imageDigestServiceStub) ResolveDigests(_ []string, _ bool, credentials, _ string,
+) (map[string]string, error) {
+	s.credentials = credentials
+	return s.digests, nil
+}
+
+type imageDigestServiceErrorStub struct{}
+
+func (*imageDigestServiceErrorStub) ResolveDigests(_ []string, _ bool, _, _ string) (map[string]string, error) {
+	return nil, errors.New("manifest unknown")
+}
+
+type credentialServiceStub struct {
+	credentials          string
+	credentialsFile      string
+	targetRegistryConfig credential.TargetRegistryConfig
+}
+
+func (s *credentialServiceStub) UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error) {
+	s.credentialsFile = credentialsFile
+	if flagCredentials != "" {
+		return flagCredentials, nil
+	}
+	return s.credentials, nil
+}
+
+func (s *credentialServiceStub) ConfigureTargetRegistry(config credential.TargetRegistryConfig) error {
+	s.targetRegistryConfig = config
+	return nil
+}
+
+type credentialServiceErrorStub struct{}
+
+func (*credentialServiceErrorStub) UserPasswordCredentials(_, _ string) (string, error) {
+	return "", errors.New("failed to read credentials file")
+}
+
+func (*credentialServiceErrorStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return nil
+}
+
+type credentialServiceConfigureErrorStub struct {
+	credentialServiceStub
+}
+
+func (*credentialServiceConfigureErrorStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return errors.New("failed to read CA file")
+}
+
+type componentArchiveServiceStub struct{}
+
+func (*componentArchiveServiceStub) CreateComponentArchive(_ *compdesc.ComponentDescriptor) (
+	*comparch.ComponentArchive,
+	error,
+) {
+	return &comparch.ComponentArchive{}, nil
+}
+
+func (*componentArchiveServiceStub) AddModuleResourcesToArchive(_ componentarchive.ComponentArchive,
+	_ []resources.Resource,
+) error {
+	return nil
+}
+
+type registryServiceStub struct{}
+
+func (*registryServiceStub) PushComponentVersion(_ *comparch.ComponentArchive, _, _, _ bool,
+	_, _ string,
+) error {
+	return nil
+}
+
+func (*registryServiceStub) GetComponentVersion(_ *comparch.ComponentArchive, _ bool,
+	_, _ string,
+) (cpi.ComponentVersionAccess, error) {
+	var componentVersion cpi.ComponentVersionAccess
+	return componentVersion, nil
+}
+
+func (*registryServiceStub) ExistsComponentVersion(_ *comparch.ComponentArchive, _ bool,
+	_, _ string,
+) (bool, error) {
+	return false, nil
+}
+
+func (*registryServiceStub) CompareComponentVersion(_ *comparch.ComponentArchive, _, _ bool,
+	_, _ string,
+) ([]string, error) {
+	return nil, nil
+}
+
+func (*registryServiceStub) ExportComponentVersion(_ *comparch.ComponentArchive, _ string, _, _ bool) error {
+	return nil
+}
+
+type registryServiceExportStub struct {
+	registryServiceStub
+
+	archivePath   string
+	overwrite     bool
+	copyResources bool
+	pushed        bool
+	err           error
+}
+
+func (s *registryServiceExportStub) PushComponentVersion(_ *comparch.ComponentArchive, _, _, _ bool,
+	_, _ string,
+) error {
+	s.pushed = true
+	return nil
+}
+
+func (s *registryServiceExportStub) ExportComponentVersion(_ *comparch.ComponentArchive, archivePath string,
+	overwrite, copyResources bool,
+) error {
+	s.archivePath = archivePath
+	s.overwrite = overwrite
+	s.copyResources = copyResources
+	return s.err
+}
+
+type registryServiceTargetsStub struct {
+	registryServiceStub
+
+	descriptor  *compdesc.ComponentDescriptor
+	failURL     string
+	pushed      []string
+	insecure    []bool
+	credentials []string
+	checked     []string
+	// existing holds the differences of the component versions that already exist, keyed by the registry.
+	existing map[string][]string
+}
+
+func (s *registryServiceTargetsStub) PushComponentVersion(_ *comparch.ComponentArchive, insecure, _, _ bool,
+	credentials, registryURL string,
+) error {
+	s.pushed = append(s.pushed, registryURL)
+	s.insecure = append(s.insecure, insecure)
+	s.credentials = append(s.credentials, credentials)
+	if registryURL == s.failURL {
+		return errors.New("push failed")
+	}
+	return nil
+}
+
+func (s *registryServiceTargetsStub) GetComponentVersion(_ *comparch.ComponentArchive, _ bool,
+	_, _ string,
+) (cpi.ComponentVersionAccess, error) {
+	return &componentVersionStub{descriptor: s.descriptor}, nil
+}
+
+func (s *registryServiceTargetsStub) ExistsComponentVersion(_ *comparch.ComponentArchive, _ bool,
+	_, registryURL string,
+) (bool, error) {
+	s.checked = append(s.checked, registryURL)
+	_, exists := s.existing[registryURL]
+	return exists, nil
+}
+
+func (s *registryServiceTargetsStub) CompareComponentVersion(_ *comparch.ComponentArchive, _, _ bool,
+	_, registryURL string,
+) ([]string, error) {
+	return s.existing[registryURL], nil
+}
+
+type componentVersionStub struct {
+	cpi.ComponentVersionAccess
+
+	descriptor *compdesc.ComponentDescriptor
+}
+
+func (s *componentVersionStub) GetDescriptor() *compdesc.ComponentDescriptor {
+	return s.descriptor
+}
+
+type ModuleTemplateServiceStub struct {
+	reproducible bool
+	descriptor   *compdesc.ComponentDescriptor
+}
+
+func (s *ModuleTemplateServiceStub) GenerateModuleTemplate(_ *contentprovider.ModuleConfig,
+	descriptor *compdesc.ComponentDescriptor,
+	_ []byte, _ bool, _ map[string]string, _ string, reproducible bool,
+) error {
+	s.reproducible = reproducible
+	s.descriptor = descriptor
+	return nil
+}
+
+type CRDParserServiceStub struct{}
+
+func (*CRDParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+	return false, nil
+}
+
+func (*CRDParserServiceStub) ValidateDefaultCR(_ *types.ResourcePaths) error {
+	return nil
+}
+
+var errInvalidDefaultCR = errors.New("invalid default CR")
+
+type CRDParserServiceErrorStub struct{}
+
+func (*CRDParserServiceErrorStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+	return false, nil
+}
+
+func (*CRDParserServiceErrorStub) ValidateDefaultCR(_ *types.ResourcePaths) error {
+	return errInvalidDefaultCR
 }
 
 type ModuleResourceServiceStub struct{}
@@ -539,6 +1918,179 @@ func (ivs *imageVersionVerifierErrorStub) VerifyModuleResources(_ *contentprovid
 
 type manifestServiceStub struct{}
 
-func (*manifestServiceStub) ExtractImagesFromManifest(_ string) ([]string, error) {
+func (*manifestServiceStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
 	return []string{"image1:latest", "image2:v1.0"}, nil
 }
+
+type manifestServiceImagesStub struct {
+	images []string
+}
+
+func (m *manifestServiceImagesStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
+	return m.images, nil
+}
+
+type manifestRendererStub struct {
+	chart                     *contentprovider.Chart
+	kustomizationDir          string
+	basePath                  string
+	cleanupTempFilesCallCount int
+}
+
+func (s *manifestRendererStub) RenderChart(chart *contentprovider.Chart, basePath string) (string, error) {
+	s.chart = chart
+	s.basePath = basePath
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (s *manifestRendererStub) RenderKustomization(kustomizationDir string, basePath string) (string, error) {
+	s.kustomizationDir = kustomizationDir
+	s.basePath = basePath
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (s *manifestRendererStub) CleanupTempFiles() []error {
+	s.cleanupTempFilesCallCount++
+	return nil
+}
+
+type manifestRendererErrorStub struct{}
+
+func (*manifestRendererErrorStub) RenderChart(_ *contentprovider.Chart, _ string) (string, error) {
+	return "", errors.New("failed to render chart")
+}
+
+func (*manifestRendererErrorStub) RenderKustomization(_ string, _ string) (string, error) {
+	return "", errors.New("failed to build kustomization")
+}
+
+func (*manifestRendererErrorStub) CleanupTempFiles() []error {
+	return nil
+}
+
+type moduleConfigServiceWithKustomizationStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithKustomizationStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:          "kyma-project.io/module/telemetry",
+		Version:       "1.43.1",
+		Kustomization: "overlays/prod",
+	}, nil
+}
+
+type moduleConfigServiceWithChartStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithChartStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		Chart: &contentprovider.Chart{
+			Path:        "charts/telemetry",
+			ReleaseName: "telemetry",
+			Namespace:   "kyma-system",
+		},
+	}, nil
+}
+
+type moduleConfigServiceWithSecurityStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithSecurityStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:     "kyma-project.io/module/telemetry",
+		Version:  "1.43.1",
+		Security: "sec-scanners-config.yaml",
+	}, nil
+}
+
+type securityConfigServiceStub struct {
+	securityConfig *contentprovider.SecurityScanConfig
+	appendCalled   bool
+}
+
+func (s *securityConfigServiceStub) ParseSecurityConfigData(_ string) (*contentprovider.SecurityScanConfig, error) {
+	return s.securityConfig, nil
+}
+
+func (s *securityConfigServiceStub) AppendSecurityLabelsToSources(_ *compdesc.ComponentDescriptor,
+	_ *contentprovider.SecurityScanConfig,
+) error {
+	s.appendCalled = true
+	return nil
+}
+
+func (s *securityConfigServiceStub) AppendSecurityLabelsToConstructorSources(_ *component.Constructor,
+	_ *contentprovider.SecurityScanConfig,
+) {
+	s.appendCalled = true
+}
+
+type securityConfigServiceErrorStub struct {
+	securityConfigServiceStub
+}
+
+func (*securityConfigServiceErrorStub) ParseSecurityConfigData(_ string) (*contentprovider.SecurityScanConfig, error) {
+	return nil, errors.New("security config file does not exist")
+}
+
+var errProvenance = errors.New("failed to get latest commit")
+
+type provenanceServiceStub struct {
+	gitRepoPath     string
+	artifacts       []string
+	statementOutput string
+}
+
+func (s *provenanceServiceStub) Collect(_ *contentprovider.ModuleConfig, gitRepoPath string,
+	_ *types.ResourcePaths,
+) (*provenance.Provenance, error) {
+	s.gitRepoPath = gitRepoPath
+	return &provenance.Provenance{GitCommit: "4c2e3f1d"}, nil
+}
+
+func (s *provenanceServiceStub) WriteStatement(_ *provenance.Provenance, artifacts []string,
+	statementOutput string,
+) error {
+	s.artifacts = artifacts
+	s.statementOutput = statementOutput
+	return nil
+}
+
+type signatureServiceStub struct {
+	config     signature.SigningConfig
+	descriptor *compdesc.ComponentDescriptor
+	err        error
+}
+
+func (s *signatureServiceStub) Sign(_ cpi.ComponentVersionAccess, config signature.SigningConfig,
+) (*compdesc.ComponentDescriptor, error) {
+	s.config = config
+	return s.descriptor, s.err
+}
+
+type provenanceServiceErrorStub struct{}
+
+func (*provenanceServiceErrorStub) Collect(_ *contentprovider.ModuleConfig, _ string,
+	_ *types.ResourcePaths,
+) (*provenance.Provenance, error) {
+	return nil, errProvenance
+}
+
+func (*provenanceServiceErrorStub) WriteStatement(_ *provenance.Provenance, _ []string, _ string) error {
+	return nil
+}
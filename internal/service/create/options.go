@@ -5,25 +5,35 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strings"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
 type Options struct {
 	Out                       iotools.Out
 	ConfigFile                string
-	Credentials               string
-	Insecure                  bool
+	RegistryTargetsFile       string
 	TemplateOutput            string
-	RegistryURL               string
 	ModuleSourcesGitDirectory string
 	OverwriteComponentVersion bool
 	DryRun                    bool
+	OutputArchive             string
+	CopyResources             bool
+	SigningKeyFile            string
+	SigningCertificateFile    string
+	SignatureName             string
 	SkipVersionValidation     bool
 	DisableOCMRegistryPush    bool
 	OutputConstructorFile     string
+	AllowUnknownFields        bool
+	PinDigests                bool
+	Reproducible              bool
+	ProvenanceOutput          string
+	ReservedKeyPrefixes       []string
+	types.RegistryAccess
 }
 
 func (opts Options) Validate() error {
@@ -35,6 +45,14 @@ func (opts Options) Validate() error {
 		return fmt.Errorf("opts.ConfigFile must not be empty: %w", commonerrors.ErrInvalidOption)
 	}
 
+	if _, err := validation.ParseReservedKeyPrefixes(opts.ReservedKeyPrefixes); err != nil {
+		return fmt.Errorf("opts.ReservedKeyPrefixes is invalid: %w", err)
+	}
+
+	if err := opts.RegistryAccess.ValidateAuth(); err != nil {
+		return err
+	}
+
 	if opts.TemplateOutput == "" {
 		return fmt.Errorf("opts.TemplateOutput must not be empty: %w", commonerrors.ErrInvalidOption)
 	}
@@ -44,8 +62,24 @@ func (opts Options) Validate() error {
 			commonerrors.ErrInvalidOption)
 	}
 
-	// Only validate registry related args if OCM registry push is not disabled
-	if !opts.DisableOCMRegistryPush {
+	if err := opts.validateOutputArchive(); err != nil {
+		return err
+	}
+
+	if err := opts.validateRegistryTargetsFile(); err != nil {
+		return err
+	}
+
+	if err := opts.validateCopyResources(); err != nil {
+		return err
+	}
+
+	if err := opts.validateSigning(); err != nil {
+		return err
+	}
+
+	// Only validate registry related args if the component version is pushed to the OCM registry
+	if !opts.DisableOCMRegistryPush && opts.OutputArchive == "" && opts.RegistryTargetsFile == "" {
 		err := opts.validateArgsForRegistryPush()
 		if err != nil {
 			return err
@@ -73,6 +107,74 @@ func (opts Options) Validate() error {
 	return nil
 }
 
+func (opts Options) validateOutputArchive() error {
+	if opts.OutputArchive == "" {
+		return nil
+	}
+
+	if opts.DisableOCMRegistryPush {
+		return fmt.Errorf("opts.OutputArchive must not be set when OCM registry push is disabled: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if opts.DryRun {
+		return fmt.Errorf("opts.OutputArchive must not be set together with opts.DryRun: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// validateRegistryTargetsFile ensures that the registry targets file is the only source of target registries.
+// The registry and its authentication and TLS settings are configured per target in the file.
+func (opts Options) validateRegistryTargetsFile() error {
+	if opts.RegistryTargetsFile == "" {
+		return nil
+	}
+
+	if opts.DisableOCMRegistryPush || opts.OutputArchive != "" {
+		return fmt.Errorf("opts.RegistryTargetsFile must not be set together with opts.OutputArchive or when "+
+			"OCM registry push is disabled: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.RegistryAccess != (types.RegistryAccess{}) {
+		return fmt.Errorf("opts.RegistryTargetsFile must not be set together with the registry options, "+
+			"configure them per target in the file instead: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func (opts Options) validateCopyResources() error {
+	if opts.CopyResources && opts.DisableOCMRegistryPush {
+		return fmt.Errorf("opts.CopyResources must not be set when OCM registry push is disabled: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+func (opts Options) validateSigning() error {
+	if opts.SigningKeyFile == "" {
+		if opts.SigningCertificateFile != "" {
+			return fmt.Errorf("opts.SigningCertificateFile must not be set without opts.SigningKeyFile: %w",
+				commonerrors.ErrInvalidOption)
+		}
+		return nil
+	}
+
+	if opts.DisableOCMRegistryPush {
+		return fmt.Errorf("opts.SigningKeyFile must not be set when OCM registry push is disabled: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if opts.SignatureName == "" {
+		return fmt.Errorf("opts.SignatureName must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
 func (opts Options) validateArgsForRegistryPush() error {
 	if opts.Credentials != "" {
 		matched, err := regexp.MatchString("(.+):(.+)", opts.Credentials)
@@ -83,14 +185,7 @@ func (opts Options) validateArgsForRegistryPush() error {
 		}
 	}
 
-	if opts.RegistryURL == "" {
-		return fmt.Errorf("opts.RegistryURL must not be empty: %w", commonerrors.ErrInvalidOption)
-	}
-
-	if !strings.HasPrefix(opts.RegistryURL, "http") {
-		return fmt.Errorf("opts.RegistryURL does not start with http(s): %w", commonerrors.ErrInvalidOption)
-	}
-	return nil
+	return opts.RegistryAccess.Validate()
 }
 
 func isGitDirectory(path string) bool {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/kyma-project/modulectl/internal/common/types"
 	"github.com/kyma-project/modulectl/internal/service/create"
 	iotools "github.com/kyma-project/modulectl/tools/io"
 )
@@ -50,19 +51,226 @@ func Test_Validate_Options(t *testing.T) {
 				Out:            iotools.NewDefaultOut(io.Discard),
 				ConfigFile:     "config.yaml",
 				TemplateOutput: "output",
-				Credentials:    "missingsemicolon",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "missingsemicolon",
+				},
 			},
 			wantErr: true,
 			errMsg:  "opts.Credentials is in invalid format",
 		},
+		{
+			name: "Credentials and CredentialsFile set together",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				RegistryAccess: types.RegistryAccess{
+					Credentials:     "username:password",
+					CredentialsFile: "credentials",
+				},
+			},
+			wantErr: true,
+			errMsg:  "opts.Credentials and opts.CredentialsFile must not be set together",
+		},
+		{
+			name: "RegistryTokenFile set together with Credentials",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				RegistryAccess: types.RegistryAccess{
+					Credentials:       "username:password",
+					RegistryTokenFile: "token",
+				},
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryTokenFile must not be set together with opts.Credentials",
+		},
+		{
+			name: "RegistryClientCert without RegistryClientKey",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				RegistryAccess: types.RegistryAccess{
+					RegistryClientCert: "client.crt",
+				},
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryClientCert and opts.RegistryClientKey must be set together",
+		},
+		{
+			name: "RegistryCAFile set together with Insecure",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				RegistryAccess: types.RegistryAccess{
+					Insecure:       true,
+					RegistryCAFile: "ca.crt",
+				},
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryCAFile must not be set together with opts.Insecure",
+		},
+		{
+			name: "ReservedKeyPrefixes invalid",
+			options: create.Options{
+				Out:                 iotools.NewDefaultOut(io.Discard),
+				ConfigFile:          "config.yaml",
+				TemplateOutput:      "output",
+				ReservedKeyPrefixes: []string{"example.com/=info"},
+			},
+			wantErr: true,
+			errMsg:  "opts.ReservedKeyPrefixes is invalid",
+		},
 		{
 			name: "All fields valid",
 			options: create.Options{
 				Out:                       iotools.NewDefaultOut(io.Discard),
 				ConfigFile:                "config.yaml",
-				Credentials:               "username:password",
 				TemplateOutput:            "output",
-				RegistryURL:               "http://registry.example.com",
+				ModuleSourcesGitDirectory: "../../../",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "username:password",
+					RegistryURL: "http://registry.example.com",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OutputArchive set together with DisableOCMRegistryPush",
+			options: create.Options{
+				Out:                    iotools.NewDefaultOut(io.Discard),
+				ConfigFile:             "config.yaml",
+				TemplateOutput:         "output",
+				DisableOCMRegistryPush: true,
+				OutputConstructorFile:  "component-constructor.yaml",
+				OutputArchive:          "archive.tgz",
+			},
+			wantErr: true,
+			errMsg:  "opts.OutputArchive must not be set when OCM registry push is disabled",
+		},
+		{
+			name: "OutputArchive set together with DryRun",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				DryRun:         true,
+				OutputArchive:  "archive.tgz",
+			},
+			wantErr: true,
+			errMsg:  "opts.OutputArchive must not be set together with opts.DryRun",
+		},
+		{
+			name: "OutputArchive set without RegistryURL",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputArchive:             "archive.tgz",
+				ModuleSourcesGitDirectory: "../../../",
+			},
+			wantErr: false,
+		},
+		{
+			name: "RegistryTargetsFile set together with RegistryURL",
+			options: create.Options{
+				Out:                 iotools.NewDefaultOut(io.Discard),
+				ConfigFile:          "config.yaml",
+				TemplateOutput:      "output",
+				RegistryTargetsFile: "targets.yaml",
+				RegistryAccess: types.RegistryAccess{
+					RegistryURL: "https://registry.kyma.cx",
+				},
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryTargetsFile must not be set together with the registry options",
+		},
+		{
+			name: "RegistryTargetsFile set together with OutputArchive",
+			options: create.Options{
+				Out:                 iotools.NewDefaultOut(io.Discard),
+				ConfigFile:          "config.yaml",
+				TemplateOutput:      "output",
+				OutputArchive:       "archive.tgz",
+				RegistryTargetsFile: "targets.yaml",
+			},
+			wantErr: true,
+			errMsg:  "opts.RegistryTargetsFile must not be set together with opts.OutputArchive",
+		},
+		{
+			name: "RegistryTargetsFile set without RegistryURL",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				RegistryTargetsFile:       "targets.yaml",
+				ModuleSourcesGitDirectory: "../../../",
+			},
+			wantErr: false,
+		},
+		{
+			name: "CopyResources set together with DisableOCMRegistryPush",
+			options: create.Options{
+				Out:                    iotools.NewDefaultOut(io.Discard),
+				ConfigFile:             "config.yaml",
+				TemplateOutput:         "output",
+				DisableOCMRegistryPush: true,
+				OutputConstructorFile:  "component-constructor.yaml",
+				CopyResources:          true,
+			},
+			wantErr: true,
+			errMsg:  "opts.CopyResources must not be set when OCM registry push is disabled",
+		},
+		{
+			name: "SigningCertificateFile set without SigningKeyFile",
+			options: create.Options{
+				Out:                    iotools.NewDefaultOut(io.Discard),
+				ConfigFile:             "config.yaml",
+				TemplateOutput:         "output",
+				OutputArchive:          "archive.tgz",
+				SigningCertificateFile: "chain.pem",
+			},
+			wantErr: true,
+			errMsg:  "opts.SigningCertificateFile must not be set without opts.SigningKeyFile",
+		},
+		{
+			name: "SigningKeyFile set together with DisableOCMRegistryPush",
+			options: create.Options{
+				Out:                    iotools.NewDefaultOut(io.Discard),
+				ConfigFile:             "config.yaml",
+				TemplateOutput:         "output",
+				DisableOCMRegistryPush: true,
+				OutputConstructorFile:  "component-constructor.yaml",
+				SigningKeyFile:         "private.pem",
+				SignatureName:          "kyma-module",
+			},
+			wantErr: true,
+			errMsg:  "opts.SigningKeyFile must not be set when OCM registry push is disabled",
+		},
+		{
+			name: "SigningKeyFile set without SignatureName",
+			options: create.Options{
+				Out:            iotools.NewDefaultOut(io.Discard),
+				ConfigFile:     "config.yaml",
+				TemplateOutput: "output",
+				OutputArchive:  "archive.tgz",
+				SigningKeyFile: "private.pem",
+			},
+			wantErr: true,
+			errMsg:  "opts.SignatureName must not be empty",
+		},
+		{
+			name: "SigningKeyFile set with SignatureName",
+			options: create.Options{
+				Out:                       iotools.NewDefaultOut(io.Discard),
+				ConfigFile:                "config.yaml",
+				TemplateOutput:            "output",
+				OutputArchive:             "archive.tgz",
+				SigningKeyFile:            "private.pem",
+				SignatureName:             "kyma-module",
 				ModuleSourcesGitDirectory: "../../../",
 			},
 			wantErr: false,
@@ -72,9 +280,11 @@ func Test_Validate_Options(t *testing.T) {
 			options: create.Options{
 				Out:            iotools.NewDefaultOut(io.Discard),
 				ConfigFile:     "config.yaml",
-				Credentials:    "username:password",
 				TemplateOutput: "output",
-				RegistryURL:    "",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "username:password",
+					RegistryURL: "",
+				},
 			},
 			wantErr: true,
 			errMsg:  "opts.RegistryURL must not be empty",
@@ -84,9 +294,11 @@ func Test_Validate_Options(t *testing.T) {
 			options: create.Options{
 				Out:            iotools.NewDefaultOut(io.Discard),
 				ConfigFile:     "config.yaml",
-				Credentials:    "username:password",
 				TemplateOutput: "output",
-				RegistryURL:    "ftp://registry.example.com",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "username:password",
+					RegistryURL: "ftp://registry.example.com",
+				},
 			},
 			wantErr: true,
 			errMsg:  "opts.RegistryURL does not start with http(s)",
@@ -96,10 +308,12 @@ func Test_Validate_Options(t *testing.T) {
 			options: create.Options{
 				Out:                       iotools.NewDefaultOut(io.Discard),
 				ConfigFile:                "config.yaml",
-				Credentials:               "username:password",
 				TemplateOutput:            "output",
-				RegistryURL:               "http://registry.example.com",
 				ModuleSourcesGitDirectory: "",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "username:password",
+					RegistryURL: "http://registry.example.com",
+				},
 			},
 			wantErr: true,
 			errMsg:  "opts.ModuleSourcesGitDirectory must not be empty",
@@ -109,10 +323,12 @@ func Test_Validate_Options(t *testing.T) {
 			options: create.Options{
 				Out:                       iotools.NewDefaultOut(io.Discard),
 				ConfigFile:                "config.yaml",
-				Credentials:               "username:password",
 				TemplateOutput:            "output",
-				RegistryURL:               "http://registry.example.com",
 				ModuleSourcesGitDirectory: ".",
+				RegistryAccess: types.RegistryAccess{
+					Credentials: "username:password",
+					RegistryURL: "http://registry.example.com",
+				},
 			},
 			wantErr: true,
 			errMsg:  "currently configured module-sources-git-directory \".\" must point to a valid git repository:",
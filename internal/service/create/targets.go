@@ -0,0 +1,167 @@
+package create
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+)
+
+var ErrNoRegistryTargets = errors.New("registry targets file does not contain a target")
+
+// RegistryTarget is an entry of the registry targets file. The component version is pushed to every target,
+// the first one is the primary target the ModuleTemplate is rendered from.
+type RegistryTarget struct {
+	Registry        string `json:"registry"`
+	Insecure        bool   `json:"insecure,omitempty"`
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+	TokenFile       string `json:"tokenFile,omitempty"`
+	CAFile          string `json:"caFile,omitempty"`
+	ClientCert      string `json:"clientCert,omitempty"`
+	ClientKey       string `json:"clientKey,omitempty"`
+}
+
+type registryTargetsFile struct {
+	Targets []RegistryTarget `json:"targets"`
+}
+
+func (t RegistryTarget) validate() error {
+	if t.Registry == "" {
+		return fmt.Errorf("registry must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !strings.HasPrefix(t.Registry, "http") {
+		return fmt.Errorf("registry does not start with http(s): %w", commonerrors.ErrInvalidOption)
+	}
+
+	if t.TokenFile != "" && t.CredentialsFile != "" {
+		return fmt.Errorf("tokenFile must not be set together with credentialsFile: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if (t.ClientCert == "") != (t.ClientKey == "") {
+		return fmt.Errorf("clientCert and clientKey must be set together: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if t.CAFile != "" && t.Insecure {
+		return fmt.Errorf("caFile must not be set together with insecure: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
+
+// registryTarget is a registry the component version is pushed to, with its resolved user:password credentials.
+type registryTarget struct {
+	registryURL string
+	insecure    bool
+	credentials string
+}
+
+// resolveRegistryTargets returns the registries the component version is pushed to. Without a registry targets
+// file, the registry of the options is the only target. The credentials, the token and the TLS material of every
+// target are resolved.
+func (s *Service) resolveRegistryTargets(opts Options) ([]registryTarget, error) {
+	if opts.RegistryTargetsFile == "" {
+		credentials, err := s.credentialService.UserPasswordCredentials(opts.Credentials, opts.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve registry credentials: %w", err)
+		}
+		target, err := s.configureRegistryTarget(RegistryTarget{
+			Registry:   opts.RegistryURL,
+			Insecure:   opts.Insecure,
+			TokenFile:  opts.RegistryTokenFile,
+			CAFile:     opts.RegistryCAFile,
+			ClientCert: opts.RegistryClientCert,
+			ClientKey:  opts.RegistryClientKey,
+		}, credentials)
+		if err != nil {
+			return nil, err
+		}
+		return []registryTarget{target}, nil
+	}
+
+	configs, err := s.readRegistryTargets(opts.RegistryTargetsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]registryTarget, 0, len(configs))
+	for _, config := range configs {
+		credentials, err := s.fileTargetCredentials(config)
+		if err != nil {
+			return nil, fmt.Errorf("registry target %s: %w", config.Registry, err)
+		}
+		target, err := s.configureRegistryTarget(config, credentials)
+		if err != nil {
+			return nil, fmt.Errorf("registry target %s: %w", config.Registry, err)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func (s *Service) readRegistryTargets(registryTargetsFile string) ([]RegistryTarget, error) {
+	content, err := s.fileSystem.ReadFile(registryTargetsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry targets file: %w", err)
+	}
+
+	var targetsFile registryTargetsFile
+	if err = yaml.UnmarshalStrict(content, &targetsFile); err != nil {
+		return nil, fmt.Errorf("failed to parse registry targets file: %w", err)
+	}
+
+	if len(targetsFile.Targets) == 0 {
+		return nil, fmt.Errorf("%s: %w", registryTargetsFile, ErrNoRegistryTargets)
+	}
+
+	registries := make(map[string]bool, len(targetsFile.Targets))
+	for i, target := range targetsFile.Targets {
+		if err = target.validate(); err != nil {
+			return nil, fmt.Errorf("registry target %d is invalid: %w", i+1, err)
+		}
+		if registries[target.Registry] {
+			return nil, fmt.Errorf("registry target %s is duplicated: %w", target.Registry,
+				commonerrors.ErrInvalidOption)
+		}
+		registries[target.Registry] = true
+	}
+	return targetsFile.Targets, nil
+}
+
+// fileTargetCredentials resolves the credentials of a target of the registry targets file from its credentials
+// file only. The credentials of the environment variables are not used, as they would be sent to every registry
+// of the file, so a target without a credentials file is looked up in the Docker config of its host instead.
+func (s *Service) fileTargetCredentials(config RegistryTarget) (string, error) {
+	if config.CredentialsFile == "" {
+		return "", nil
+	}
+
+	credentials, err := s.credentialService.UserPasswordCredentials("", config.CredentialsFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+func (s *Service) configureRegistryTarget(config RegistryTarget, credentials string) (registryTarget, error) {
+	if err := s.credentialService.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    config.Registry,
+		TokenFile:      config.TokenFile,
+		CAFile:         config.CAFile,
+		ClientCertFile: config.ClientCert,
+		ClientKeyFile:  config.ClientKey,
+	}); err != nil {
+		return registryTarget{}, fmt.Errorf("failed to configure target registry: %w", err)
+	}
+
+	return registryTarget{
+		registryURL: config.Registry,
+		insecure:    config.Insecure,
+		credentials: credentials,
+	}, nil
+}
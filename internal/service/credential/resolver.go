@@ -3,31 +3,144 @@ package credential
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"ocm.software/ocm/api/credentials"
-	"ocm.software/ocm/api/credentials/extensions/repositories/dockerconfig"
 	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/ocmutils"
+	"ocm.software/ocm/api/tech/oci/identity"
+
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const (
+	// UsernameEnvVar and PasswordEnvVar hold the credentials for the target registry
+	// if neither the credentials flag nor the credentials file is set.
+	UsernameEnvVar = "MODULECTL_REGISTRY_USERNAME"
+	PasswordEnvVar = "MODULECTL_REGISTRY_PASSWORD"
+
+	ocmConfigFileName = ".ocmconfig"
 )
 
 var (
 	ErrInvalidCredentialsFormat = errors.New("invalid credentials format, expected 'username:password'")
-	errDockerConfigNotFound     = errors.New("docker config file not found in home directory")
+	errIncompleteEnvCredentials = fmt.Errorf("both %s and %s must be set", UsernameEnvVar, PasswordEnvVar)
+
+	schemeRegex     = regexp.MustCompile(`^https?://`)
+	credFormatRegex = regexp.MustCompile(`^\S+:\S+$`)
 )
 
+// Resolver resolves registry credentials from a chain of sources. It reports the source it used to the debug
+// output, the credentials themselves are never written.
+type Resolver struct {
+	debug   iotools.Out
+	targets map[string]*targetRegistry
+
+	ocmConfigOnce   sync.Once
+	ocmConfigLoaded bool
+	ocmConfigErr    error
+}
+
+// NewResolver creates a credential resolver. If debug is nil, the debug output is discarded.
+func NewResolver(debug iotools.Out) *Resolver {
+	if debug == nil {
+		debug = iotools.NewDefaultOut(io.Discard)
+	}
+
+	return &Resolver{debug: debug, targets: make(map[string]*targetRegistry)}
+}
+
+// ResolveCredentials resolves the credentials with a resolver that discards the debug output.
 func ResolveCredentials(ctx cpi.Context, userPasswordCreds, registryURL string) (credentials.Credentials, error) {
+	return NewResolver(nil).ResolveCredentials(ctx, userPasswordCreds, registryURL)
+}
+
+// UserPasswordCredentials returns the user:password credentials for the target registry. They are taken from the
+// credentials flag, the credentials file or the UsernameEnvVar and PasswordEnvVar environment variables, in this
+// order. An empty string is returned if none of them is set.
+func (r *Resolver) UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error) {
+	if flagCredentials != "" {
+		r.debug.Write("Debug: using the registry credentials of the --registry-credentials flag\n")
+		return flagCredentials, nil
+	}
+
+	if credentialsFile != "" {
+		content, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		userPasswordCreds := strings.TrimSpace(string(content))
+		if validateCredFormat(userPasswordCreds) {
+			return "", fmt.Errorf("credentials file %s: %w", credentialsFile, ErrInvalidCredentialsFormat)
+		}
+		r.debug.Write(fmt.Sprintf("Debug: using the registry credentials of the credentials file %s\n", credentialsFile))
+		return userPasswordCreds, nil
+	}
+
+	user, pass := os.Getenv(UsernameEnvVar), os.Getenv(PasswordEnvVar)
+	if user == "" && pass == "" {
+		return "", nil
+	}
+	if user == "" || pass == "" {
+		return "", errIncompleteEnvCredentials
+	}
+	r.debug.Write(fmt.Sprintf("Debug: using the registry credentials of the %s and %s environment variables\n",
+		UsernameEnvVar, PasswordEnvVar))
+	return user + ":" + pass, nil
+}
+
+// ResolveCredentials returns the token configured for the target registry or the given user:password credentials
+// if set. Otherwise, the credentials for the registry are looked up in the Docker config, including the credential
+// helpers configured in it, and then in the OCM config. If no source has credentials for the registry, empty
+// credentials are returned for anonymous access. The TLS material configured for the target registry is added to
+// its credentials.
+func (r *Resolver) ResolveCredentials(ctx cpi.Context, userPasswordCreds, registryURL string,
+) (credentials.Credentials, error) {
+	host := registryHost(registryURL)
+	creds, err := r.resolveCredentials(ctx, userPasswordCreds, host)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.withTLSMaterial(host, creds), nil
+}
+
+func (r *Resolver) resolveCredentials(ctx cpi.Context, userPasswordCreds, host string,
+) (credentials.Credentials, error) {
+	if creds := r.tokenCredentials(host); creds != nil {
+		return creds, nil
+	}
+
 	if userPasswordCreds != "" {
 		return resolveUserPasswordCredentials(userPasswordCreds)
 	}
 
-	creds, err := tryResolveFromDockerConfig(ctx, registryURL)
-	if err == nil {
+	creds, err := lookupDockerConfig(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup credentials in Docker config: %w", err)
+	}
+	if creds != nil {
+		r.debug.Write(fmt.Sprintf("Debug: using the credentials of the Docker config for registry %s\n", host))
+		return creds, nil
+	}
+
+	creds, err = r.lookupOCMConfig(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup credentials in OCM config: %w", err)
+	}
+	if creds != nil {
+		r.debug.Write(fmt.Sprintf("Debug: using the credentials of the OCM config for registry %s\n", host))
 		return creds, nil
 	}
 
+	r.debug.Write(fmt.Sprintf("Debug: no credentials found for registry %s, using anonymous access\n", host))
 	return credentials.NewCredentials(nil), nil
 }
 
@@ -47,7 +160,7 @@ func resolveUserPasswordCredentials(userPasswordCreds string) (credentials.Crede
 // validateCredFormat checks if the credentials string is in the format "username:password",
 // where both username and password contain at least one non-whitespace character.
 func validateCredFormat(creds string) bool {
-	return !regexp.MustCompile(`^\S+:\S+$`).MatchString(creds)
+	return !credFormatRegex.MatchString(creds)
 }
 
 func parseUserPass(credentials string) (string, string) {
@@ -58,18 +171,82 @@ func parseUserPass(credentials string) (string, string) {
 	return u, p
 }
 
-func tryResolveFromDockerConfig(ctx cpi.Context, registryURL string) (credentials.Credentials, error) {
-	if home, err := os.UserHomeDir(); err == nil {
-		path := filepath.Join(home, ".docker", "config.json")
-		if repo, err := dockerconfig.NewRepository(ctx.CredentialsContext(), path, nil, true); err == nil {
-			hostNameInDockerConfig := strings.Split(registryURL, "/")[0]
-			creds, err := repo.LookupCredentials(hostNameInDockerConfig)
-			if err != nil {
-				return nil, fmt.Errorf("failed to lookup credentials: %w", err)
-			}
-			return creds, nil
+// lookupDockerConfig uses the Docker config of the DOCKER_CONFIG directory or the home directory.
+// Credential helpers configured with credsStore or credHelpers are invoked to retrieve the credentials.
+func lookupDockerConfig(host string) (credentials.Credentials, error) {
+	registry, err := name.NewRegistry(host)
+	if err != nil {
+		return nil, nil //nolint:nilerr // hosts that are no valid registry names have no Docker config entry
+	}
+
+	authenticator, err := authn.DefaultKeychain.Resolve(registry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials for %s: %w", host, err)
+	}
+	if authenticator == authn.Anonymous {
+		return nil, nil
+	}
+
+	config, err := authenticator.Authorization()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials for %s: %w", host, err)
+	}
+
+	creds := credentials.DirectCredentials{}
+	for property, value := range map[string]string{
+		"username":            config.Username,
+		"password":            config.Password,
+		identityTokenProperty: config.IdentityToken,
+	} {
+		if value != "" {
+			creds[property] = value
 		}
 	}
+	if len(creds) == 0 {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// lookupOCMConfig uses the .ocmconfig file of the home directory if it exists. The file is loaded into the context
+// of the first lookup only once per resolver, the following lookups use the credentials already configured.
+func (r *Resolver) lookupOCMConfig(ctx cpi.Context, host string) (credentials.Credentials, error) {
+	r.ocmConfigOnce.Do(func() {
+		r.ocmConfigLoaded, r.ocmConfigErr = loadOCMConfig(ctx)
+	})
+	if r.ocmConfigErr != nil {
+		return nil, r.ocmConfigErr
+	}
+	if !r.ocmConfigLoaded {
+		return nil, nil
+	}
+
+	creds, err := identity.GetCredentials(ctx, host, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials for %s: %w", host, err)
+	}
+	return creds, nil
+}
+
+// loadOCMConfig loads the .ocmconfig file of the home directory into the context and returns whether it exists.
+func loadOCMConfig(ctx cpi.Context) (bool, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false, nil //nolint:nilerr // without a home directory, there is no OCM config
+	}
+
+	path := filepath.Join(home, ocmConfigFileName)
+	if _, err = os.Stat(path); err != nil {
+		return false, nil //nolint:nilerr // the OCM config is optional
+	}
+
+	if _, err = ocmutils.Configure(ctx, path); err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return true, nil
+}
 
-	return nil, errDockerConfigNotFound
+func registryHost(registryURL string) string {
+	host, _, _ := strings.Cut(schemeRegex.ReplaceAllString(registryURL, ""), "/")
+	return host
 }
@@ -1,12 +1,18 @@
 package credential_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/cpi"
 
 	"github.com/kyma-project/modulectl/internal/service/credential"
+	iotools "github.com/kyma-project/modulectl/tools/io"
 )
 
 func TestResolveCredentials_WhenCalledWithInvalidUsernamePasswordFormats_ReturnsError(t *testing.T) {
@@ -42,9 +48,148 @@ func TestResolveCredentials_ReturnUserPasswordWhenGiven(t *testing.T) {
 }
 
 func TestResolveCredentials_WhenNoCredentialsPassedAndNoDockerConfig_ReturnsEmptyCredentials(t *testing.T) {
-	creds, err := credential.ResolveCredentials(cpi.DefaultContext(), "", "ghcr.io/template-operator")
+	isolateConfigs(t)
+	debug := &bytes.Buffer{}
+
+	creds, err := credential.NewResolver(iotools.NewDefaultOut(debug)).
+		ResolveCredentials(cpi.DefaultContext(), "", "ghcr.io/template-operator")
 
 	require.NoError(t, err)
 	require.Empty(t, creds.GetProperty("username"))
 	require.Empty(t, creds.GetProperty("password"))
+	require.Equal(t, "Debug: no credentials found for registry ghcr.io, using anonymous access\n", debug.String())
+}
+
+func TestResolveCredentials_WhenNoCredentialsPassed_ReturnsDockerConfigCredentials(t *testing.T) {
+	dockerConfigDir := isolateConfigs(t)
+	auth := base64.StdEncoding.EncodeToString([]byte("user1:secret1"))
+	require.NoError(t, os.WriteFile(filepath.Join(dockerConfigDir, "config.json"),
+		[]byte(`{"auths":{"registry.example.com":{"auth":"`+auth+`"}}}`), 0o600))
+	debug := &bytes.Buffer{}
+
+	creds, err := credential.NewResolver(iotools.NewDefaultOut(debug)).
+		ResolveCredentials(cpi.DefaultContext(), "", "https://registry.example.com/modules")
+
+	require.NoError(t, err)
+	require.Equal(t, "user1", creds.GetProperty("username"))
+	require.Equal(t, "secret1", creds.GetProperty("password"))
+	require.Equal(t, "Debug: using the credentials of the Docker config for registry registry.example.com\n",
+		debug.String())
+}
+
+func TestResolveCredentials_LoadsOCMConfigOnce(t *testing.T) {
+	isolateConfigs(t)
+	ocmConfigFile := filepath.Join(os.Getenv("HOME"), ".ocmconfig")
+	require.NoError(t, os.WriteFile(ocmConfigFile, []byte(`type: generic.config.ocm.software/v1
+configurations:
+  - type: credentials.config.ocm.software
+    consumers:
+      - identity:
+          type: OCIRegistry
+          hostname: registry.example.com
+        credentials:
+          - type: Credentials
+            properties:
+              username: user1
+              password: secret1
+`), 0o600))
+	resolver := credential.NewResolver(nil)
+	ctx := ocm.New()
+
+	creds, err := resolver.ResolveCredentials(ctx, "", "https://registry.example.com/modules")
+	require.NoError(t, err)
+	require.Equal(t, "user1", creds.GetProperty("username"))
+
+	// loading the invalid OCM config again would fail the lookup
+	require.NoError(t, os.WriteFile(ocmConfigFile, []byte("invalid"), 0o600))
+	creds, err = resolver.ResolveCredentials(ctx, "", "https://registry.example.com/modules")
+	require.NoError(t, err)
+	require.Equal(t, "secret1", creds.GetProperty("password"))
+}
+
+func TestUserPasswordCredentials_PrefersFlagCredentials(t *testing.T) {
+	t.Setenv(credential.UsernameEnvVar, "env-user")
+	t.Setenv(credential.PasswordEnvVar, "env-pass")
+	debug := &bytes.Buffer{}
+
+	creds, err := credential.NewResolver(iotools.NewDefaultOut(debug)).
+		UserPasswordCredentials("user1:pass1", writeCredentialsFile(t, "file-user:file-pass"))
+
+	require.NoError(t, err)
+	require.Equal(t, "user1:pass1", creds)
+	require.Equal(t, "Debug: using the registry credentials of the --registry-credentials flag\n", debug.String())
+}
+
+func TestUserPasswordCredentials_ReturnsFileCredentials_WhenFlagIsNotSet(t *testing.T) {
+	t.Setenv(credential.UsernameEnvVar, "env-user")
+	t.Setenv(credential.PasswordEnvVar, "env-pass")
+	credentialsFile := writeCredentialsFile(t, "file-user:file-pass\n")
+	debug := &bytes.Buffer{}
+
+	creds, err := credential.NewResolver(iotools.NewDefaultOut(debug)).UserPasswordCredentials("", credentialsFile)
+
+	require.NoError(t, err)
+	require.Equal(t, "file-user:file-pass", creds)
+	require.NotContains(t, debug.String(), "file-pass")
+}
+
+func TestUserPasswordCredentials_ReturnsError_WhenFileIsInvalid(t *testing.T) {
+	resolver := credential.NewResolver(nil)
+
+	_, err := resolver.UserPasswordCredentials("", writeCredentialsFile(t, "file-user"))
+	require.ErrorIs(t, err, credential.ErrInvalidCredentialsFormat)
+
+	_, err = resolver.UserPasswordCredentials("", filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "failed to read credentials file")
+}
+
+func TestUserPasswordCredentials_ReturnsEnvCredentials_WhenFlagAndFileAreNotSet(t *testing.T) {
+	t.Setenv(credential.UsernameEnvVar, "env-user")
+	t.Setenv(credential.PasswordEnvVar, "env-pass")
+	debug := &bytes.Buffer{}
+
+	creds, err := credential.NewResolver(iotools.NewDefaultOut(debug)).UserPasswordCredentials("", "")
+
+	require.NoError(t, err)
+	require.Equal(t, "env-user:env-pass", creds)
+	require.Contains(t, debug.String(), credential.UsernameEnvVar)
+	require.NotContains(t, debug.String(), "env-pass")
+}
+
+func TestUserPasswordCredentials_ReturnsError_WhenEnvCredentialsAreIncomplete(t *testing.T) {
+	t.Setenv(credential.UsernameEnvVar, "env-user")
+	t.Setenv(credential.PasswordEnvVar, "")
+
+	_, err := credential.NewResolver(nil).UserPasswordCredentials("", "")
+
+	require.ErrorContains(t, err, "must be set")
+}
+
+func TestUserPasswordCredentials_ReturnsEmptyCredentials_WhenNoSourceIsSet(t *testing.T) {
+	t.Setenv(credential.UsernameEnvVar, "")
+	t.Setenv(credential.PasswordEnvVar, "")
+
+	creds, err := credential.NewResolver(nil).UserPasswordCredentials("", "")
+
+	require.NoError(t, err)
+	require.Empty(t, creds)
+}
+
+// isolateConfigs points the home and Docker config directories to empty temporary directories
+// and returns the Docker config directory.
+func isolateConfigs(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("REGISTRY_AUTH_FILE", "")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	dockerConfigDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dockerConfigDir)
+	return dockerConfigDir
+}
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(content), 0o600))
+	return credentialsFile
 }
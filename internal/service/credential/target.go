@@ -0,0 +1,164 @@
+package credential
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"ocm.software/ocm/api/credentials"
+)
+
+const (
+	identityTokenProperty        = "identityToken"
+	certificateAuthorityProperty = "certificateAuthority"
+	clientCertProperty           = "clientCert"
+	clientKeyProperty            = "clientKey"
+)
+
+var (
+	errNoCertificatesInCAFile = errors.New("no PEM encoded certificates found")
+	errEmptyTokenFile         = errors.New("token file must not be empty")
+)
+
+// TargetRegistryConfig configures the TLS connection and the token authentication of the target registry.
+// All files are optional, the client certificate and key must be set together.
+type TargetRegistryConfig struct {
+	RegistryURL    string
+	TokenFile      string
+	CAFile         string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+type targetRegistry struct {
+	host          string
+	identityToken string
+	caPEM         string
+	clientCertPEM string
+	clientKeyPEM  string
+	transport     *http.Transport
+}
+
+// ConfigureTargetRegistry loads the files of the config. The credentials resolved for the target registry
+// afterwards carry the token and the TLS material, and the transport of the resolver uses the TLS material
+// for requests to the target registry. It can be called once per target registry, a later config for the same
+// host replaces the earlier one.
+func (r *Resolver) ConfigureTargetRegistry(config TargetRegistryConfig) error {
+	target := &targetRegistry{host: registryHost(config.RegistryURL)}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.TokenFile != "" {
+		content, err := os.ReadFile(config.TokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read token file: %w", err)
+		}
+		target.identityToken = strings.TrimSpace(string(content))
+		if target.identityToken == "" {
+			return fmt.Errorf("%s: %w", config.TokenFile, errEmptyTokenFile)
+		}
+	}
+
+	if config.CAFile != "" {
+		content, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(content) {
+			return fmt.Errorf("CA file %s: %w", config.CAFile, errNoCertificatesInCAFile)
+		}
+		target.caPEM = string(content)
+		tlsConfig.RootCAs = rootCAs
+		r.debug.Write(fmt.Sprintf("Debug: using the CA file %s for registry %s\n", config.CAFile, target.host))
+	}
+
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		certPEM, err := os.ReadFile(config.ClientCertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client certificate file: %w", err)
+		}
+		keyPEM, err := os.ReadFile(config.ClientKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client key file: %w", err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		target.clientCertPEM, target.clientKeyPEM = string(certPEM), string(keyPEM)
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+		r.debug.Write(fmt.Sprintf("Debug: using the client certificate %s for registry %s\n",
+			config.ClientCertFile, target.host))
+	}
+
+	if target.caPEM != "" || target.clientCertPEM != "" {
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			transport = &http.Transport{}
+		}
+		target.transport = transport.Clone()
+		target.transport.TLSClientConfig = tlsConfig
+	}
+
+	r.targets[target.host] = target
+	return nil
+}
+
+// Transport returns an HTTP transport that connects to the target registries with their TLS material and
+// to other hosts with the base transport.
+func (r *Resolver) Transport(base http.RoundTripper) http.RoundTripper {
+	return &targetTransport{resolver: r, base: base}
+}
+
+type targetTransport struct {
+	resolver *Resolver
+	base     http.RoundTripper
+}
+
+func (t *targetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if target, ok := t.resolver.targets[req.URL.Host]; ok && target.transport != nil {
+		return target.transport.RoundTrip(req)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// tokenCredentials returns the token credentials if a token is configured for the host.
+func (r *Resolver) tokenCredentials(host string) credentials.Credentials {
+	target, ok := r.targets[host]
+	if !ok || target.identityToken == "" {
+		return nil
+	}
+
+	r.debug.Write(fmt.Sprintf("Debug: using the registry token of the token file for registry %s\n", host))
+	return credentials.DirectCredentials{identityTokenProperty: target.identityToken}
+}
+
+// withTLSMaterial adds the TLS material configured for the host to the credentials.
+func (r *Resolver) withTLSMaterial(host string, creds credentials.Credentials) credentials.Credentials {
+	target, ok := r.targets[host]
+	if !ok || (target.caPEM == "" && target.clientCertPEM == "") {
+		return creds
+	}
+
+	withTLS := credentials.DirectCredentials{}
+	for property, value := range creds.Properties() {
+		withTLS[property] = value
+	}
+	for property, value := range map[string]string{
+		certificateAuthorityProperty: target.caPEM,
+		clientCertProperty:           target.clientCertPEM,
+		clientKeyProperty:            target.clientKeyPEM,
+	} {
+		if value != "" {
+			withTLS[property] = value
+		}
+	}
+	return withTLS
+}
@@ -0,0 +1,196 @@
+package credential_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/cpi"
+
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func TestConfigureTargetRegistry_AddsTLSMaterialToTargetRegistryCredentials(t *testing.T) {
+	isolateConfigs(t)
+	certFile, keyFile := writeClientCertificate(t)
+	resolver := credential.NewResolver(nil)
+
+	err := resolver.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    "https://registry.example.com/modules",
+		CAFile:         certFile,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	require.NoError(t, err)
+
+	creds, err := resolver.ResolveCredentials(cpi.DefaultContext(), "user1:pass1", "registry.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "user1", creds.GetProperty("username"))
+	require.Equal(t, readFile(t, certFile), creds.GetProperty("certificateAuthority"))
+	require.Equal(t, readFile(t, certFile), creds.GetProperty("clientCert"))
+	require.Equal(t, readFile(t, keyFile), creds.GetProperty("clientKey"))
+
+	creds, err = resolver.ResolveCredentials(cpi.DefaultContext(), "", "other.example.com")
+	require.NoError(t, err)
+	require.Empty(t, creds.GetProperty("certificateAuthority"))
+	require.Empty(t, creds.GetProperty("clientCert"))
+}
+
+func TestConfigureTargetRegistry_UsesTokenForTargetRegistry(t *testing.T) {
+	isolateConfigs(t)
+	debug := &bytes.Buffer{}
+	resolver := credential.NewResolver(iotools.NewDefaultOut(debug))
+
+	err := resolver.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL: "https://registry.example.com",
+		TokenFile:   writeCredentialsFile(t, "secret-token\n"),
+	})
+	require.NoError(t, err)
+
+	creds, err := resolver.ResolveCredentials(cpi.DefaultContext(), "", "https://registry.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "secret-token", creds.GetProperty("identityToken"))
+	require.Empty(t, creds.GetProperty("username"))
+	require.NotContains(t, debug.String(), "secret-token")
+
+	creds, err = resolver.ResolveCredentials(cpi.DefaultContext(), "", "https://other.example.com")
+	require.NoError(t, err)
+	require.Empty(t, creds.GetProperty("identityToken"))
+}
+
+func TestConfigureTargetRegistry_KeepsConfigPerTargetRegistry(t *testing.T) {
+	isolateConfigs(t)
+	resolver := credential.NewResolver(nil)
+
+	require.NoError(t, resolver.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL: "https://primary.example.com",
+		TokenFile:   writeCredentialsFile(t, "primary-token"),
+	}))
+	require.NoError(t, resolver.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL: "https://mirror.example.com",
+		TokenFile:   writeCredentialsFile(t, "mirror-token"),
+	}))
+
+	creds, err := resolver.ResolveCredentials(cpi.DefaultContext(), "", "https://primary.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "primary-token", creds.GetProperty("identityToken"))
+
+	creds, err = resolver.ResolveCredentials(cpi.DefaultContext(), "", "https://mirror.example.com")
+	require.NoError(t, err)
+	require.Equal(t, "mirror-token", creds.GetProperty("identityToken"))
+}
+
+func TestConfigureTargetRegistry_ReturnsError_WhenFilesAreInvalid(t *testing.T) {
+	certFile, _ := writeClientCertificate(t)
+	_, otherKeyFile := writeClientCertificate(t)
+	tests := []struct {
+		name    string
+		config  credential.TargetRegistryConfig
+		wantErr string
+	}{
+		{
+			name:    "CA file without certificates",
+			config:  credential.TargetRegistryConfig{CAFile: writeCredentialsFile(t, "no certificate")},
+			wantErr: "no PEM encoded certificates found",
+		},
+		{
+			name:    "missing CA file",
+			config:  credential.TargetRegistryConfig{CAFile: filepath.Join(t.TempDir(), "missing")},
+			wantErr: "failed to read CA file",
+		},
+		{
+			name:    "client key not matching the certificate",
+			config:  credential.TargetRegistryConfig{ClientCertFile: certFile, ClientKeyFile: otherKeyFile},
+			wantErr: "failed to load client certificate",
+		},
+		{
+			name:    "client certificate without key",
+			config:  credential.TargetRegistryConfig{ClientCertFile: certFile},
+			wantErr: "failed to read client key file",
+		},
+		{
+			name:    "empty token file",
+			config:  credential.TargetRegistryConfig{TokenFile: writeCredentialsFile(t, "\n")},
+			wantErr: "token file must not be empty",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := credential.NewResolver(nil).ConfigureTargetRegistry(test.config)
+
+			require.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestTransport_TrustsCAFile_ForTargetRegistry(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+	resolver := credential.NewResolver(nil)
+	client := &http.Client{Transport: resolver.Transport(http.DefaultTransport)}
+
+	_, err := client.Get(server.URL) //nolint:noctx // test request
+	require.Error(t, err)
+
+	require.NoError(t, resolver.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL: server.URL,
+		CAFile:      caFile,
+	}))
+	response, err := client.Get(server.URL) //nolint:noctx // test request
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func writeClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "modulectl"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		0o600))
+	return certFile, keyFile
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
@@ -1,9 +1,12 @@
 package fileresolver
 
 import (
+	"bytes"
 	"fmt"
 	"net/url"
 	"path"
+	"slices"
+	"strings"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
@@ -11,7 +14,14 @@ import (
 
 type TempFileSystem interface {
 	DownloadTempFile(dir, pattern string, url *url.URL) (string, error)
+	WriteTempFile(dir, pattern string, content []byte) (string, error)
 	FileExists(filePath string) (bool, error)
+	DirExists(dirPath string) (bool, error)
+	// ListFiles returns the regular files in the directory and its subdirectories in lexical order.
+	ListFiles(dirPath string) ([]string, error)
+	// Glob returns the regular files matching the pattern in lexical order.
+	Glob(pattern string) ([]string, error)
+	ReadFile(filePath string) ([]byte, error)
 	RemoveTempFiles() []error
 }
 
@@ -61,3 +71,147 @@ func (r *FileResolver) Resolve(fileRef contentprovider.UrlOrLocalFile, basePath
 func (r *FileResolver) CleanupTempFiles() []error {
 	return r.tempFileSystem.RemoveTempFiles()
 }
+
+// ResolveAll resolves a list of file references into a single file.
+// A single reference to a file or a URL is resolved like Resolve. Otherwise, local directories are expanded to the
+// YAML files they contain and glob patterns to the files they match, both in lexical order. The files are then
+// concatenated in the order of the references into a temp file, a file referenced more than once is included once.
+func (r *FileResolver) ResolveAll(fileRefs contentprovider.UrlOrLocalFiles, basePath string) (string, error) {
+	entries := fileRefs.Entries()
+	if len(entries) == 0 {
+		return "", fmt.Errorf("file references are empty: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if len(entries) == 1 && !isLocalFileSet(entries[0], basePath, r.tempFileSystem) {
+		return r.Resolve(entries[0], basePath)
+	}
+
+	var sources []fileSource
+	for _, fileRef := range entries {
+		resolved, err := r.resolveFileSet(fileRef, basePath)
+		if err != nil {
+			return "", err
+		}
+		for _, source := range resolved {
+			if !slices.ContainsFunc(sources, func(existing fileSource) bool { return existing.name == source.name }) {
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	return r.concatenate(sources)
+}
+
+// fileSource is a resolved file with the name it is referenced by in the concatenated file.
+type fileSource struct {
+	name string
+	path string
+}
+
+func (r *FileResolver) resolveFileSet(fileRef contentprovider.UrlOrLocalFile, basePath string) ([]fileSource, error) {
+	if fileRef.IsEmpty() {
+		return nil, fmt.Errorf("file reference is empty: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if fileRef.IsURL() {
+		filePath, err := r.Resolve(fileRef, basePath)
+		if err != nil {
+			return nil, err
+		}
+		return []fileSource{{name: fileRef.String(), path: filePath}}, nil
+	}
+
+	localPath := path.Join(basePath, fileRef.String())
+	var filePaths []string
+	switch {
+	case isGlobPattern(fileRef.String()):
+		matches, err := r.tempFileSystem.Glob(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate glob pattern %s: %w", localPath, err)
+		}
+		filePaths = matches
+	default:
+		isDir, err := r.tempFileSystem.DirExists(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if directory exists %s: %w", localPath, err)
+		}
+		if !isDir {
+			filePath, err := r.Resolve(fileRef, basePath)
+			if err != nil {
+				return nil, err
+			}
+			return []fileSource{{name: fileRef.String(), path: filePath}}, nil
+		}
+
+		files, err := r.tempFileSystem.ListFiles(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in directory %s: %w", localPath, err)
+		}
+		filePaths = slices.DeleteFunc(files, func(file string) bool { return !isYAMLFile(file) })
+	}
+
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("%s does not match any files: %w", localPath, commonerrors.ErrInvalidArg)
+	}
+
+	sources := make([]fileSource, 0, len(filePaths))
+	for _, filePath := range filePaths {
+		sources = append(sources, fileSource{name: relativeName(filePath, basePath), path: filePath})
+	}
+	return sources, nil
+}
+
+func (r *FileResolver) concatenate(sources []fileSource) (string, error) {
+	var content bytes.Buffer
+	for _, source := range sources {
+		data, err := r.tempFileSystem.ReadFile(source.path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file %s: %w", source.path, err)
+		}
+
+		// every file starts a new document, a leading separator would add an empty one
+		document := strings.TrimPrefix(strings.TrimLeft(string(data), "\n"), "---\n")
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		content.WriteString("---\n# Source: " + source.name + "\n")
+		content.WriteString(document)
+		if !strings.HasSuffix(document, "\n") {
+			content.WriteString("\n")
+		}
+	}
+
+	tempFilePath, err := r.tempFileSystem.WriteTempFile("", r.filePattern, content.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to write concatenated file: %w", err)
+	}
+	return tempFilePath, nil
+}
+
+// isLocalFileSet returns true if the reference is a local directory or glob pattern that may match several files.
+func isLocalFileSet(fileRef contentprovider.UrlOrLocalFile, basePath string, tempFileSystem TempFileSystem) bool {
+	if fileRef.IsURL() || fileRef.IsEmpty() {
+		return false
+	}
+	if isGlobPattern(fileRef.String()) {
+		return true
+	}
+	isDir, err := tempFileSystem.DirExists(path.Join(basePath, fileRef.String()))
+	return err == nil && isDir
+}
+
+func isGlobPattern(value string) bool {
+	return strings.ContainsAny(value, "*?[")
+}
+
+func isYAMLFile(filePath string) bool {
+	extension := path.Ext(filePath)
+	return extension == ".yaml" || extension == ".yml"
+}
+
+func relativeName(filePath, basePath string) string {
+	if basePath == "" || basePath == "." {
+		return filePath
+	}
+	return strings.TrimPrefix(filePath, strings.TrimSuffix(basePath, "/")+"/")
+}
@@ -3,15 +3,19 @@ package fileresolver_test
 import (
 	"errors"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	"github.com/kyma-project/modulectl/internal/service/fileresolver"
+	"github.com/kyma-project/modulectl/tools/filesystem"
 )
 
 const filePattern = "kyma-module-manifest-*.yaml"
@@ -174,6 +178,214 @@ func Test_Resolve_WhenLocalFile_Returns_Error_WhenFileDoesNotExist(t *testing.T)
 	assert.Empty(t, result)
 }
 
+func Test_ResolveAll_WhenSingleFile_ReturnsFilePath(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
+
+	result, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles("path/to/manifest.yaml"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "path/to/manifest.yaml", result)
+}
+
+func Test_ResolveAll_WhenSingleURL_ReturnsDownloadedFilePath(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
+
+	result, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles("https://example.com/manifest.yaml"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "file.yaml", result)
+}
+
+func Test_ResolveAll_WhenEmpty_ReturnsError(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tmpfileSystemStub{})
+
+	_, err := resolver.ResolveAll(contentprovider.UrlOrLocalFiles{}, "")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func Test_ResolveAll_ConcatenatesDirectoryGlobAndFilesInOrder(t *testing.T) {
+	basePath := t.TempDir()
+	writeFile(t, basePath, "manifests/b-deployment.yaml", "kind: Deployment\n")
+	writeFile(t, basePath, "manifests/a-namespace.yaml", "---\nkind: Namespace\n")
+	writeFile(t, basePath, "manifests/nested/service.yml", "kind: Service")
+	writeFile(t, basePath, "manifests/README.md", "not a manifest")
+	writeFile(t, basePath, "crds/b.yaml", "kind: CustomResourceDefinition\nmetadata:\n  name: b\n")
+	writeFile(t, basePath, "crds/a.yaml", "kind: CustomResourceDefinition\nmetadata:\n  name: a\n")
+	writeFile(t, basePath, "rbac.yaml", "kind: ClusterRole\n")
+	tempFileSystem := filesystem.NewTempFileSystem()
+	resolver, _ := fileresolver.NewFileResolver(filePattern, tempFileSystem)
+	defer resolver.CleanupTempFiles()
+
+	result, err := resolver.ResolveAll(
+		contentprovider.MustUrlOrLocalFiles("crds/*.yaml", "manifests", "rbac.yaml", "crds/a.yaml"), basePath)
+
+	require.NoError(t, err)
+	content, err := os.ReadFile(result)
+	require.NoError(t, err)
+	assert.Equal(t, `---
+# Source: crds/a.yaml
+kind: CustomResourceDefinition
+metadata:
+  name: a
+---
+# Source: crds/b.yaml
+kind: CustomResourceDefinition
+metadata:
+  name: b
+---
+# Source: manifests/a-namespace.yaml
+kind: Namespace
+---
+# Source: manifests/b-deployment.yaml
+kind: Deployment
+---
+# Source: manifests/nested/service.yml
+kind: Service
+---
+# Source: rbac.yaml
+kind: ClusterRole
+`, string(content))
+}
+
+func Test_ResolveAll_WhenGlobMatchesNothing_ReturnsError(t *testing.T) {
+	basePath := t.TempDir()
+	resolver, _ := fileresolver.NewFileResolver(filePattern, filesystem.NewTempFileSystem())
+
+	_, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles("manifests/*.yaml"), basePath)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "does not match any files")
+}
+
+func Test_ResolveAll_WhenListedFileDoesNotExist_ReturnsError(t *testing.T) {
+	basePath := t.TempDir()
+	writeFile(t, basePath, "rbac.yaml", "kind: ClusterRole\n")
+	resolver, _ := fileresolver.NewFileResolver(filePattern, filesystem.NewTempFileSystem())
+
+	_, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles("rbac.yaml", "missing.yaml"), basePath)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "file does not exist")
+}
+
+func Test_ResolveAll_WhenDownloadFails_ReturnsError(t *testing.T) {
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tempfileSystemErrorStub{})
+
+	_, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles(
+		"https://example.com/a.yaml", "https://example.com/b.yaml"), "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to download file")
+}
+
+func Test_ResolveAll_ConcatenatesDownloadedFiles(t *testing.T) {
+	tempFileSystem := &tempFileSystemFilesStub{
+		contents: map[string]string{"file.yaml": "kind: Deployment"},
+	}
+	resolver, _ := fileresolver.NewFileResolver(filePattern, tempFileSystem)
+
+	result, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles(
+		"https://example.com/a.yaml", "https://example.com/b.yaml"), "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "concatenated.yaml", result)
+	assert.Equal(t, "---\n# Source: https://example.com/a.yaml\nkind: Deployment\n"+
+		"---\n# Source: https://example.com/b.yaml\nkind: Deployment\n", tempFileSystem.written)
+}
+
+func Test_ResolveAll_SkipsEmptyFiles_AndNamesFilesByPath_WhenBasePathIsEmpty(t *testing.T) {
+	tempFileSystem := &tempFileSystemFilesStub{
+		dirs:     map[string]bool{"manifests": true},
+		files:    []string{"manifests/empty.yaml", "manifests/rbac.yaml"},
+		contents: map[string]string{"manifests/empty.yaml": "---\n", "manifests/rbac.yaml": "kind: Role\n"},
+	}
+	resolver, _ := fileresolver.NewFileResolver(filePattern, tempFileSystem)
+
+	_, err := resolver.ResolveAll(contentprovider.MustUrlOrLocalFiles("manifests"), ".")
+
+	require.NoError(t, err)
+	assert.Equal(t, "---\n# Source: manifests/rbac.yaml\nkind: Role\n", tempFileSystem.written)
+}
+
+func Test_ResolveAll_ReturnsError_WhenReferenceIsEmpty(t *testing.T) {
+	var fileRefs contentprovider.UrlOrLocalFiles
+	require.NoError(t, yaml.Unmarshal([]byte("- \"\"\n- manifest.yaml\n"), &fileRefs))
+	resolver, _ := fileresolver.NewFileResolver(filePattern, &tempFileSystemFilesStub{})
+
+	_, err := resolver.ResolveAll(fileRefs, "")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "file reference is empty")
+}
+
+func Test_ResolveAll_ReturnsError_WhenFileSystemFails(t *testing.T) {
+	tests := []struct {
+		name           string
+		fileRefs       contentprovider.UrlOrLocalFiles
+		tempFileSystem *tempFileSystemFilesStub
+		expectedErr    string
+	}{
+		{
+			name:           "glob fails",
+			fileRefs:       contentprovider.MustUrlOrLocalFiles("manifests/*.yaml"),
+			tempFileSystem: &tempFileSystemFilesStub{err: errFileSystem},
+			expectedErr:    "failed to evaluate glob pattern manifests/*.yaml",
+		},
+		{
+			name:           "directory check fails",
+			fileRefs:       contentprovider.MustUrlOrLocalFiles("crds", "manifests"),
+			tempFileSystem: &tempFileSystemFilesStub{err: errFileSystem},
+			expectedErr:    "failed to check if directory exists crds",
+		},
+		{
+			name:     "listing files fails",
+			fileRefs: contentprovider.MustUrlOrLocalFiles("manifests"),
+			tempFileSystem: &tempFileSystemFilesStub{
+				dirs:    map[string]bool{"manifests": true},
+				listErr: errFileSystem,
+			},
+			expectedErr: "failed to list files in directory manifests",
+		},
+		{
+			name:     "reading file fails",
+			fileRefs: contentprovider.MustUrlOrLocalFiles("manifests"),
+			tempFileSystem: &tempFileSystemFilesStub{
+				dirs:  map[string]bool{"manifests": true},
+				files: []string{"manifests/missing.yaml"},
+			},
+			expectedErr: "failed to read file manifests/missing.yaml",
+		},
+		{
+			name:     "writing file fails",
+			fileRefs: contentprovider.MustUrlOrLocalFiles("manifests"),
+			tempFileSystem: &tempFileSystemFilesStub{
+				dirs:     map[string]bool{"manifests": true},
+				files:    []string{"manifests/rbac.yaml"},
+				contents: map[string]string{"manifests/rbac.yaml": "kind: Role\n"},
+				writeErr: errFileSystem,
+			},
+			expectedErr: "failed to write concatenated file",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver, _ := fileresolver.NewFileResolver(filePattern, test.tempFileSystem)
+
+			_, err := resolver.ResolveAll(test.fileRefs, "")
+
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func writeFile(t *testing.T, basePath, name, content string) {
+	t.Helper()
+	filePath := filepath.Join(basePath, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+}
+
 type tmpfileSystemStub struct{}
 
 func (*tmpfileSystemStub) DownloadTempFile(_ string, _ string, _ *url.URL) (string, error) {
@@ -187,6 +399,26 @@ func (s *tmpfileSystemStub) FileExists(filePath string) (bool, error) {
 	return false, nil
 }
 
+func (*tmpfileSystemStub) WriteTempFile(_, _ string, _ []byte) (string, error) {
+	return "concatenated.yaml", nil
+}
+
+func (*tmpfileSystemStub) DirExists(_ string) (bool, error) {
+	return false, nil
+}
+
+func (*tmpfileSystemStub) ListFiles(_ string) ([]string, error) {
+	return nil, nil
+}
+
+func (*tmpfileSystemStub) Glob(_ string) ([]string, error) {
+	return nil, nil
+}
+
+func (*tmpfileSystemStub) ReadFile(_ string) ([]byte, error) {
+	return nil, nil
+}
+
 func (s *tmpfileSystemStub) RemoveTempFiles() []error {
 	return nil
 }
@@ -204,6 +436,80 @@ func (s *tempfileSystemErrorStub) FileExists(filePath string) (bool, error) {
 	return false, errors.New("error checking if file exists")
 }
 
+func (*tempfileSystemErrorStub) WriteTempFile(_, _ string, _ []byte) (string, error) {
+	return "", errors.New("error writing file")
+}
+
+func (*tempfileSystemErrorStub) DirExists(_ string) (bool, error) {
+	return false, nil
+}
+
+func (*tempfileSystemErrorStub) ListFiles(_ string) ([]string, error) {
+	return nil, errors.New("error listing files")
+}
+
+func (*tempfileSystemErrorStub) Glob(_ string) ([]string, error) {
+	return nil, errors.New("error matching files")
+}
+
+func (*tempfileSystemErrorStub) ReadFile(_ string) ([]byte, error) {
+	return nil, errors.New("error reading file")
+}
+
 func (s *tempfileSystemErrorStub) RemoveTempFiles() []error {
 	return nil
 }
+
+var errFileSystem = errors.New("file system error")
+
+// tempFileSystemFilesStub serves directories and files from memory, err fails every directory and glob lookup.
+type tempFileSystemFilesStub struct {
+	dirs     map[string]bool
+	files    []string
+	contents map[string]string
+	err      error
+	listErr  error
+	writeErr error
+	written  string
+}
+
+func (*tempFileSystemFilesStub) DownloadTempFile(_ string, _ string, _ *url.URL) (string, error) {
+	return "file.yaml", nil
+}
+
+func (s *tempFileSystemFilesStub) WriteTempFile(_, _ string, content []byte) (string, error) {
+	if s.writeErr != nil {
+		return "", s.writeErr
+	}
+	s.written = string(content)
+	return "concatenated.yaml", nil
+}
+
+func (s *tempFileSystemFilesStub) FileExists(filePath string) (bool, error) {
+	_, ok := s.contents[filePath]
+	return ok, nil
+}
+
+func (s *tempFileSystemFilesStub) DirExists(dirPath string) (bool, error) {
+	return s.dirs[dirPath], s.err
+}
+
+func (s *tempFileSystemFilesStub) ListFiles(_ string) ([]string, error) {
+	return s.files, s.listErr
+}
+
+func (s *tempFileSystemFilesStub) Glob(_ string) ([]string, error) {
+	return s.files, s.err
+}
+
+func (s *tempFileSystemFilesStub) ReadFile(filePath string) ([]byte, error) {
+	content, ok := s.contents[filePath]
+	if !ok {
+		return nil, errFileSystem
+	}
+	return []byte(content), nil
+}
+
+func (*tempFileSystemFilesStub) RemoveTempFiles() []error {
+	return nil
+}
@@ -1,9 +1,13 @@
 package git
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 type Service struct {
@@ -33,3 +37,64 @@ func (s *Service) GetLatestCommit(gitRepoPath string) (string, error) {
 
 	return s.latestCommit, nil
 }
+
+// GetLatestCommitTime returns the committer time of the head commit.
+func (s *Service) GetLatestCommitTime(gitRepoPath string) (time.Time, error) {
+	repo, err := git.PlainOpen(gitRepoPath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	ref, err := repo.Head()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get head: %w", err)
+	}
+
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get head commit: %w", err)
+	}
+
+	return commit.Committer.When, nil
+}
+
+// GetTagsOfLatestCommit returns the sorted names of the lightweight and annotated tags pointing to the head commit.
+func (s *Service) GetTagsOfLatestCommit(gitRepoPath string) ([]string, error) {
+	repo, err := git.PlainOpen(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repo: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head: %w", err)
+	}
+
+	tagRefs, err := repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	var tags []string
+	err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+		tag, err := repo.TagObject(target)
+		switch {
+		case err == nil:
+			target = tag.Target
+		case !errors.Is(err, plumbing.ErrObjectNotFound):
+			return fmt.Errorf("failed to get tag %s: %w", ref.Name().Short(), err)
+		}
+
+		if target == head.Hash() {
+			tags = append(tags, ref.Name().Short())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(tags)
+	return tags, nil
+}
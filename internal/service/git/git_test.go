@@ -0,0 +1,72 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/git"
+)
+
+func TestService_ReturnsLatestCommitTimeAndTags(t *testing.T) {
+	repoPath := t.TempDir()
+	repo, err := gogit.PlainInit(repoPath, false)
+	require.NoError(t, err)
+	signature := &object.Signature{
+		Name:  "dev",
+		Email: "dev@example.com",
+		When:  time.Date(2024, time.May, 7, 10, 30, 0, 0, time.UTC),
+	}
+	first := commitFile(t, repo, repoPath, "first", signature)
+	_, err = repo.CreateTag("0.9.0", first, nil)
+	require.NoError(t, err)
+	head := commitFile(t, repo, repoPath, "second", signature)
+	_, err = repo.CreateTag("1.0.0", head, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTag("latest", head, &gogit.CreateTagOptions{Tagger: signature, Message: "latest"})
+	require.NoError(t, err)
+
+	svc := git.NewService()
+	commit, err := svc.GetLatestCommit(repoPath)
+	require.NoError(t, err)
+	commitTime, err := svc.GetLatestCommitTime(repoPath)
+	require.NoError(t, err)
+	tags, err := svc.GetTagsOfLatestCommit(repoPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, head.String(), commit)
+	assert.True(t, signature.When.Equal(commitTime))
+	assert.Equal(t, []string{"1.0.0", "latest"}, tags)
+}
+
+func TestService_ReturnsError_WhenDirectoryIsNoGitRepository(t *testing.T) {
+	svc := git.NewService()
+
+	_, err := svc.GetLatestCommitTime(t.TempDir())
+	require.ErrorContains(t, err, "failed to open repo")
+
+	_, err = svc.GetTagsOfLatestCommit(t.TempDir())
+	require.ErrorContains(t, err, "failed to open repo")
+}
+
+func commitFile(t *testing.T, repo *gogit.Repository, repoPath, content string,
+	signature *object.Signature,
+) plumbing.Hash {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte(content), 0o600))
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("file.txt")
+	require.NoError(t, err)
+	hash, err := worktree.Commit(content, &gogit.CommitOptions{Author: signature, Committer: signature})
+	require.NoError(t, err)
+	return hash
+}
@@ -0,0 +1,139 @@
+package imagedigest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/cpi"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+var schemeRegex = regexp.MustCompile(`^https?://`)
+
+type CredResolverFunc func(ctx cpi.Context, userPasswordCreds, registryURL string) (credentials.Credentials, error)
+
+// Service resolves the digests of tagged images against their registries, so that the images can be pinned.
+type Service struct {
+	credResolver CredResolverFunc
+	options      []remote.Option
+}
+
+// NewService creates the service, the remote options are applied to every registry request, e.g. a transport.
+func NewService(credResolverFunc CredResolverFunc, options ...remote.Option) (*Service, error) {
+	if credResolverFunc == nil {
+		return nil, fmt.Errorf("credResolverFunc must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		credResolver: credResolverFunc,
+		options:      options,
+	}, nil
+}
+
+// ResolveDigests returns the manifest digest of every image that is referenced by tag only, keyed by the image.
+// Images that already reference a digest are not looked up. The credentials are resolved like for the module
+// registry: the given user:password credentials are used for the registry at registryURL, other registries use
+// the credentials of the Docker config.
+func (s *Service) ResolveDigests(images []string, insecure bool, userPasswordCreds, registryURL string,
+) (map[string]string, error) {
+	keychain := &credentialKeychain{
+		ctx:               cpi.DefaultContext(),
+		credResolver:      s.credResolver,
+		userPasswordCreds: userPasswordCreds,
+		registryHost:      registryHost(registryURL),
+	}
+	options := append([]remote.Option{remote.WithAuthFromKeychain(keychain)}, s.options...)
+
+	digests := make(map[string]string, len(images))
+	for _, image := range images {
+		if _, resolved := digests[image]; resolved {
+			continue
+		}
+
+		ref, err := parseReference(image, insecure, keychain.registryHost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse image reference %s: %w", image, err)
+		}
+		if _, isDigest := ref.(name.Digest); isDigest {
+			continue
+		}
+
+		digest, err := resolveDigest(ref, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve digest of image %s: %w", image, err)
+		}
+		digests[image] = digest
+	}
+
+	return digests, nil
+}
+
+// parseReference parses the image and allows plain HTTP only if the image is hosted by the insecure module
+// registry, images of other registries are always resolved via HTTPS.
+func parseReference(image string, insecure bool, insecureRegistryHost string) (name.Reference, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if !insecure || ref.Context().RegistryStr() != insecureRegistryHost {
+		return ref, nil
+	}
+	return name.ParseReference(image, name.Insecure)
+}
+
+// resolveDigest uses a HEAD request and falls back to GET for registries that do not return the digest on HEAD.
+func resolveDigest(ref name.Reference, options []remote.Option) (string, error) {
+	descriptor, err := remote.Head(ref, options...)
+	if err == nil {
+		return descriptor.Digest.String(), nil
+	}
+
+	manifest, err := remote.Get(ref, options...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get manifest: %w", err)
+	}
+	return manifest.Digest.String(), nil
+}
+
+type credentialKeychain struct {
+	ctx               cpi.Context
+	credResolver      CredResolverFunc
+	userPasswordCreds string
+	registryHost      string
+}
+
+func (k *credentialKeychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	userPasswordCreds := ""
+	if resource.RegistryStr() == k.registryHost {
+		userPasswordCreds = k.userPasswordCreds
+	}
+
+	creds, err := k.credResolver(k.ctx, userPasswordCreds, resource.RegistryStr())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials for %s: %w", resource.RegistryStr(), err)
+	}
+	if creds == nil {
+		return authn.Anonymous, nil
+	}
+
+	config := authn.AuthConfig{
+		Username:      creds.GetProperty("username"),
+		Password:      creds.GetProperty("password"),
+		IdentityToken: creds.GetProperty("identityToken"),
+	}
+	if config == (authn.AuthConfig{}) {
+		return authn.Anonymous, nil
+	}
+	return authn.FromConfig(config), nil
+}
+
+func registryHost(registryURL string) string {
+	host, _, _ := strings.Cut(schemeRegex.ReplaceAllString(registryURL, ""), "/")
+	return host
+}
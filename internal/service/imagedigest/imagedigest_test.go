@@ -0,0 +1,111 @@
+package imagedigest_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/imagedigest"
+)
+
+func Test_NewService_ReturnsError_WhenCredResolverIsNil(t *testing.T) {
+	_, err := imagedigest.NewService(nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "credResolverFunc must not be nil")
+}
+
+func Test_ResolveDigests_ReturnsDigestsOfTaggedImages(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	digest := pushRandomImage(t, host+"/kyma-project/template-operator:1.0.0", authn.Anonymous)
+	pinnedImage := host + "/kyma-project/other:2.0.0@sha256:" + strings.Repeat("a", 64)
+	svc, err := imagedigest.NewService(credential.ResolveCredentials)
+	require.NoError(t, err)
+
+	digests, err := svc.ResolveDigests([]string{host + "/kyma-project/template-operator:1.0.0", pinnedImage},
+		true, "", server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{host + "/kyma-project/template-operator:1.0.0": digest}, digests)
+}
+
+func Test_ResolveDigests_UsesCredentials_WhenImageIsInModuleRegistry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(requireBasicAuth(registry.New(), "user", "pass"))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	image := host + "/kyma-project/template-operator:1.0.0"
+	digest := pushRandomImage(t, image, &authn.Basic{Username: "user", Password: "pass"})
+	svc, err := imagedigest.NewService(credential.ResolveCredentials)
+	require.NoError(t, err)
+
+	digests, err := svc.ResolveDigests([]string{image}, true, "user:pass", server.URL)
+
+	require.NoError(t, err)
+	assert.Equal(t, digest, digests[image])
+}
+
+func Test_ResolveDigests_ReturnsError_WhenCredentialsAreMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(requireBasicAuth(registry.New(), "user", "pass"))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	image := host + "/kyma-project/template-operator:1.0.0"
+	pushRandomImage(t, image, &authn.Basic{Username: "user", Password: "pass"})
+	svc, err := imagedigest.NewService(credential.ResolveCredentials)
+	require.NoError(t, err)
+
+	_, err = svc.ResolveDigests([]string{image}, true, "user:pass", "https://other-registry.example.com")
+
+	require.ErrorContains(t, err, "failed to resolve digest of image "+image)
+}
+
+func Test_ResolveDigests_ReturnsError_WhenImageDoesNotExist(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	svc, err := imagedigest.NewService(credential.ResolveCredentials)
+	require.NoError(t, err)
+
+	_, err = svc.ResolveDigests([]string{host + "/kyma-project/missing:1.0.0"}, true, "", server.URL)
+
+	require.ErrorContains(t, err, "failed to resolve digest of image")
+}
+
+func pushRandomImage(t *testing.T, image string, authenticator authn.Authenticator) string {
+	t.Helper()
+	ref, err := name.ParseReference(image, name.Insecure)
+	require.NoError(t, err)
+	img, err := random.Image(256, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img, remote.WithAuth(authenticator)))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return digest.String()
+}
+
+func requireBasicAuth(handler http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != username || pass != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
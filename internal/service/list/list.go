@@ -0,0 +1,188 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"ocm.software/ocm/api/ocm/compdesc"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/registry"
+)
+
+const noTime = "-"
+
+type RegistryService interface {
+	ListComponentVersions(name string, insecure bool, userPasswordCreds, registryURL string,
+	) ([]registry.ComponentVersion, error)
+}
+
+type CredentialService interface {
+	UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error)
+	ConfigureTargetRegistry(config credential.TargetRegistryConfig) error
+}
+
+// Service lists the versions of a module published to a registry.
+type Service struct {
+	registryService   RegistryService
+	credentialService CredentialService
+}
+
+func NewService(registryService RegistryService, credentialService CredentialService) (*Service, error) {
+	if registryService == nil {
+		return nil, fmt.Errorf("registryService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if credentialService == nil {
+		return nil, fmt.Errorf("credentialService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		registryService:   registryService,
+		credentialService: credentialService,
+	}, nil
+}
+
+// ModuleVersion is a published version of a module. The creation time and the commit time are only set if the
+// component descriptor of the version records them, the commit time as part of its provenance. The error is
+// only set if the version cannot be read.
+type ModuleVersion struct {
+	Version      string     `json:"version"`
+	CreationTime *time.Time `json:"creationTime,omitempty"`
+	CommitTime   *time.Time `json:"commitTime,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	credentials, err := s.credentialService.UserPasswordCredentials(opts.Credentials, opts.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+
+	if err = s.credentialService.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    opts.RegistryURL,
+		TokenFile:      opts.RegistryTokenFile,
+		CAFile:         opts.RegistryCAFile,
+		ClientCertFile: opts.RegistryClientCert,
+		ClientKeyFile:  opts.RegistryClientKey,
+	}); err != nil {
+		return fmt.Errorf("failed to configure target registry: %w", err)
+	}
+
+	componentVersions, err := s.registryService.ListComponentVersions(opts.ModuleName, opts.Insecure, credentials,
+		opts.RegistryURL)
+	if err != nil {
+		return fmt.Errorf("failed to list module versions: %w", err)
+	}
+
+	versions := make([]ModuleVersion, 0, len(componentVersions))
+	for _, componentVersion := range componentVersions {
+		versions = append(versions, moduleVersion(componentVersion))
+	}
+	sortVersions(versions)
+
+	if opts.Output == OutputJSON {
+		return writeJSON(opts, versions)
+	}
+	return writeText(opts, versions)
+}
+
+func moduleVersion(componentVersion registry.ComponentVersion) ModuleVersion {
+	version := ModuleVersion{Version: componentVersion.Version}
+	if componentVersion.Err != nil {
+		version.Error = componentVersion.Err.Error()
+		return version
+	}
+	if componentVersion.Descriptor.CreationTime != nil {
+		created := componentVersion.Descriptor.CreationTime.UTC()
+		version.CreationTime = &created
+	}
+	version.CommitTime = commitTime(componentVersion.Descriptor)
+	return version
+}
+
+// commitTime returns the commit time of the module sources recorded in the provenance labels of the descriptor.
+func commitTime(descriptor *compdesc.ComponentDescriptor) *time.Time {
+	var value string
+	if found, err := descriptor.Labels.GetValue(provenance.CommitTimeKey, &value); err != nil || !found {
+		return nil
+	}
+	committed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	committed = committed.UTC()
+	return &committed
+}
+
+// sortVersions sorts the versions by semantic version. Versions that are no semantic versions
+// are sorted lexically after all others.
+func sortVersions(versions []ModuleVersion) {
+	slices.SortStableFunc(versions, func(a, b ModuleVersion) int {
+		semverA, errA := semver.NewVersion(a.Version)
+		semverB, errB := semver.NewVersion(b.Version)
+		switch {
+		case errA == nil && errB == nil:
+			if result := semverA.Compare(semverB); result != 0 {
+				return result
+			}
+			return strings.Compare(a.Version, b.Version)
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			return strings.Compare(a.Version, b.Version)
+		}
+	})
+}
+
+func writeJSON(opts Options, versions []ModuleVersion) error {
+	data, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal module versions: %w", err)
+	}
+
+	opts.Out.Write(string(data) + "\n")
+	return nil
+}
+
+func writeText(opts Options, versions []ModuleVersion) error {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tCREATED\tCOMMITTED")
+	for _, version := range versions {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", version.Version, formatTime(version.CreationTime),
+			formatTime(version.CommitTime))
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to format module versions: %w", err)
+	}
+
+	for _, version := range versions {
+		if version.Error != "" {
+			fmt.Fprintf(builder, "Warning: version %s could not be read: %s\n", version.Version, version.Error)
+		}
+	}
+
+	opts.Out.Write(builder.String())
+	return nil
+}
+
+func formatTime(timestamp *time.Time) string {
+	if timestamp == nil {
+		return noTime
+	}
+	return timestamp.Format(time.RFC3339)
+}
@@ -0,0 +1,204 @@
+package list_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/list"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/registry"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const moduleName = "kyma-project.io/module/template-operator"
+
+func Test_NewService_ReturnsError_WhenRegistryServiceIsNil(t *testing.T) {
+	_, err := list.NewService(nil, &credentialServiceStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "registryService")
+}
+
+func Test_NewService_ReturnsError_WhenCredentialServiceIsNil(t *testing.T) {
+	_, err := list.NewService(&registryServiceStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "credentialService")
+}
+
+func Test_Run_ReturnsError_WhenOptionsAreInvalid(t *testing.T) {
+	svc, _ := list.NewService(&registryServiceStub{}, &credentialServiceStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *list.Options) { opts.Output = "yaml" }))
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "opts.Output")
+}
+
+func Test_Run_PrintsVersionsSortedBySemver(t *testing.T) {
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{
+		descriptor("1.10.0", nil),
+		descriptor("latest", nil),
+		descriptor("1.2.0", ocmv1.NewTimestampPFor(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))),
+		descriptor("1.2.0-rc.1", nil),
+	}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Equal(t, moduleName, registryService.name)
+	assert.Equal(t, "user:password", registryService.credentials)
+	assert.Equal(t, "VERSION      CREATED                COMMITTED\n"+
+		"1.2.0-rc.1   -                      -\n"+
+		"1.2.0        2024-05-01T12:00:00Z   -\n"+
+		"1.10.0       -                      -\n"+
+		"latest       -                      -\n", out.String())
+}
+
+func Test_Run_PrintsVersionsAsJSON(t *testing.T) {
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{
+		descriptor("1.1.0", nil),
+		descriptor("1.0.0", ocmv1.NewTimestampPFor(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))),
+	}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out, func(opts *list.Options) { opts.Output = list.OutputJSON }))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"version": "1.0.0", "creationTime": "2024-05-01T12:00:00Z"},
+		{"version": "1.1.0"}
+	]`, out.String())
+}
+
+func Test_Run_PrintsCommitTime_WhenProvenanceRecordsIt(t *testing.T) {
+	componentVersion := descriptor("1.0.0", nil)
+	commitTimeLabel, err := ocmv1.NewLabel(provenance.CommitTimeKey, "2024-05-01T12:00:00Z", ocmv1.WithVersion("v1"))
+	require.NoError(t, err)
+	componentVersion.Descriptor.Labels = append(componentVersion.Descriptor.Labels, *commitTimeLabel)
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{componentVersion}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err = svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Equal(t, "VERSION   CREATED   COMMITTED\n"+
+		"1.0.0     -         2024-05-01T12:00:00Z\n", out.String())
+}
+
+func Test_Run_PrintsCommitTimeAsJSON(t *testing.T) {
+	componentVersion := descriptor("1.0.0", nil)
+	commitTimeLabel, err := ocmv1.NewLabel(provenance.CommitTimeKey, "2024-05-01T12:00:00Z", ocmv1.WithVersion("v1"))
+	require.NoError(t, err)
+	componentVersion.Descriptor.Labels = append(componentVersion.Descriptor.Labels, *commitTimeLabel)
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{componentVersion}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err = svc.Run(newOptions(out, func(opts *list.Options) { opts.Output = list.OutputJSON }))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"version": "1.0.0", "commitTime": "2024-05-01T12:00:00Z"}]`, out.String())
+}
+
+func Test_Run_ReportsUnreadableVersion(t *testing.T) {
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{
+		descriptor("1.1.0", nil),
+		{Version: "1.0.0", Err: errors.New("manifest unknown")},
+	}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Equal(t, "VERSION   CREATED   COMMITTED\n"+
+		"1.0.0     -         -\n"+
+		"1.1.0     -         -\n"+
+		"Warning: version 1.0.0 could not be read: manifest unknown\n", out.String())
+}
+
+func Test_Run_ReportsUnreadableVersionAsJSON(t *testing.T) {
+	registryService := &registryServiceStub{componentVersions: []registry.ComponentVersion{
+		{Version: "1.0.0", Err: errors.New("manifest unknown")},
+	}}
+	svc, _ := list.NewService(registryService, &credentialServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out, func(opts *list.Options) { opts.Output = list.OutputJSON }))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"version": "1.0.0", "error": "manifest unknown"}]`, out.String())
+}
+
+func Test_Run_ReturnsError_WhenListingFails(t *testing.T) {
+	svc, _ := list.NewService(&registryServiceStub{err: errors.New("not found")}, &credentialServiceStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}))
+
+	require.ErrorContains(t, err, "failed to list module versions: not found")
+}
+
+func newOptions(out *bytes.Buffer, modifiers ...func(opts *list.Options)) list.Options {
+	opts := list.Options{
+		Out:        iotools.NewDefaultOut(out),
+		ModuleName: moduleName,
+		Output:     list.OutputText,
+		RegistryAccess: types.RegistryAccess{
+			RegistryURL: "http://localhost:5001",
+			Insecure:    true,
+			Credentials: "user:password",
+		},
+	}
+	for _, modify := range modifiers {
+		modify(&opts)
+	}
+	return opts
+}
+
+func descriptor(version string, creationTime *ocmv1.Timestamp) registry.ComponentVersion {
+	componentDescriptor := &compdesc.ComponentDescriptor{}
+	componentDescriptor.SetName(moduleName)
+	componentDescriptor.SetVersion(version)
+	componentDescriptor.CreationTime = creationTime
+	return registry.ComponentVersion{Version: version, Descriptor: componentDescriptor}
+}
+
+// Test Stubs
+
+type registryServiceStub struct {
+	componentVersions []registry.ComponentVersion
+	err               error
+	name              string
+	credentials       string
+}
+
+func (s *registryServiceStub) ListComponentVersions(name string, _ bool, credentials, _ string,
+) ([]registry.ComponentVersion, error) {
+	s.name, s.credentials = name, credentials
+	return s.componentVersions, s.err
+}
+
+type credentialServiceStub struct{}
+
+func (*credentialServiceStub) UserPasswordCredentials(flagCredentials, _ string) (string, error) {
+	return flagCredentials, nil
+}
+
+func (*credentialServiceStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return nil
+}
@@ -0,0 +1,42 @@
+package list
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+type Options struct {
+	Out        iotools.Out
+	ModuleName string
+	Output     string
+	types.RegistryAccess
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.ModuleName == "" {
+		return fmt.Errorf("opts.ModuleName must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if err := opts.RegistryAccess.Validate(); err != nil {
+		return err
+	}
+
+	if opts.Output != OutputText && opts.Output != OutputJSON {
+		return fmt.Errorf("opts.Output must be %s or %s: %w", OutputText, OutputJSON,
+			commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
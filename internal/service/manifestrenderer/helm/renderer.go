@@ -0,0 +1,140 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/getter"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+const notesFileSuffix = "NOTES.txt"
+
+// Renderer renders local Helm charts in-process, equivalent to `helm template` without a cluster connection.
+type Renderer struct{}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Render renders the chart directory or packaged .tgz chart at chartPath into a multi-document manifest.
+// The values files are merged in the given order, later files take precedence.
+// The output is deterministic: the CRDs of the chart come first, followed by the rendered templates,
+// each sorted by their source file.
+func (r *Renderer) Render(chartPath string, valuesFiles []string, releaseName, namespace string) ([]byte, error) {
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	if chrt.Metadata.Type == "library" {
+		return nil, fmt.Errorf("library chart %s cannot be rendered: %w", chrt.Name(), commonerrors.ErrInvalidOption)
+	}
+
+	if err := checkDependencies(chrt); err != nil {
+		return nil, err
+	}
+
+	valueOpts := &values.Options{ValueFiles: valuesFiles}
+	vals, err := valueOpts.MergeValues(getter.Providers{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values files: %w", err)
+	}
+
+	if err := chartutil.ProcessDependencies(chrt, vals); err != nil {
+		return nil, fmt.Errorf("failed to process chart dependencies: %w", err)
+	}
+
+	releaseOptions := chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: namespace,
+		Revision:  1,
+		IsInstall: true,
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, vals, releaseOptions, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute values: %w", err)
+	}
+
+	templates, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render templates: %w", err)
+	}
+
+	return joinManifests(chrt.CRDObjects(), templates), nil
+}
+
+// checkDependencies fails if a dependency declared in Chart.yaml is not vendored into the charts directory,
+// as rendering must not download charts.
+func checkDependencies(chrt *chart.Chart) error {
+	var missing []string
+	for _, dependency := range chrt.Metadata.Dependencies {
+		if !slices.ContainsFunc(chrt.Dependencies(), func(subchart *chart.Chart) bool {
+			return subchart.Name() == dependency.Name
+		}) {
+			missing = append(missing, dependency.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("chart dependencies [%s] are missing in the charts directory, run 'helm dependency build': %w",
+			strings.Join(missing, ", "), commonerrors.ErrInvalidOption)
+	}
+	return nil
+}
+
+func joinManifests(crds []chart.CRD, templates map[string]string) []byte {
+	var manifest bytes.Buffer
+
+	slices.SortFunc(crds, func(a, b chart.CRD) int {
+		return strings.Compare(a.Filename, b.Filename)
+	})
+	for _, crd := range crds {
+		writeDocument(&manifest, crd.Filename, string(crd.File.Data))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(templates)) {
+		if !isManifestFile(name) {
+			continue
+		}
+		writeDocument(&manifest, name, templates[name])
+	}
+
+	return manifest.Bytes()
+}
+
+func writeDocument(manifest *bytes.Buffer, source, content string) {
+	// a leading document separator would add an empty document
+	content = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(content), "---"))
+	if content == "" {
+		return
+	}
+
+	manifest.WriteString("---\n# Source: " + source + "\n")
+	manifest.WriteString(content)
+	manifest.WriteString("\n")
+}
+
+// isManifestFile excludes the notes and partials, which do not contain resources.
+func isManifestFile(name string) bool {
+	if strings.HasSuffix(name, notesFileSuffix) {
+		return false
+	}
+
+	switch path.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return !strings.HasPrefix(path.Base(name), "_")
+	default:
+		return false
+	}
+}
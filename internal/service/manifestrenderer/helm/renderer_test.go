@@ -0,0 +1,106 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer/helm"
+)
+
+const (
+	sampleChart    = "testdata/sample"
+	prodValuesFile = "testdata/prod-values.yaml"
+)
+
+func Test_Render_RendersChartWithDefaultValues(t *testing.T) {
+	manifest, err := helm.NewRenderer().Render(sampleChart, nil, "sample", "kyma-system")
+
+	require.NoError(t, err)
+	assert.Equal(t, `---
+# Source: sample/crds/samples.yaml
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: samples.operator.kyma-project.io
+spec:
+  group: operator.kyma-project.io
+  names:
+    kind: Sample
+    plural: samples
+  scope: Namespaced
+  versions:
+    - name: v1alpha1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+---
+# Source: sample/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: sample-manager
+  namespace: kyma-system
+  labels:
+    app.kubernetes.io/name: sample
+    app.kubernetes.io/instance: sample
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: sample
+      app.kubernetes.io/instance: sample
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: sample
+        app.kubernetes.io/instance: sample
+    spec:
+      containers:
+        - name: manager
+          image: europe-docker.pkg.dev/kyma-project/prod/sample-manager:1.0.0
+`, string(manifest))
+}
+
+func Test_Render_MergesValuesFiles(t *testing.T) {
+	manifest, err := helm.NewRenderer().Render(sampleChart, []string{prodValuesFile}, "prod", "kyma-system")
+
+	require.NoError(t, err)
+	assert.Contains(t, string(manifest), "  name: prod-manager\n")
+	assert.Contains(t, string(manifest), "  replicas: 3\n")
+	assert.Contains(t, string(manifest), "image: europe-docker.pkg.dev/kyma-project/prod/sample-manager:1.1.0\n")
+}
+
+func Test_Render_IsDeterministic(t *testing.T) {
+	renderer := helm.NewRenderer()
+
+	first, err := renderer.Render(sampleChart, []string{prodValuesFile}, "sample", "kyma-system")
+	require.NoError(t, err)
+	second, err := renderer.Render(sampleChart, []string{prodValuesFile}, "sample", "kyma-system")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func Test_Render_ReturnsError_WhenChartDoesNotExist(t *testing.T) {
+	_, err := helm.NewRenderer().Render("testdata/not-existing", nil, "sample", "kyma-system")
+
+	require.ErrorContains(t, err, "failed to load chart")
+}
+
+func Test_Render_ReturnsError_WhenValuesFileDoesNotExist(t *testing.T) {
+	_, err := helm.NewRenderer().Render(sampleChart, []string{"testdata/not-existing.yaml"}, "sample", "kyma-system")
+
+	require.ErrorContains(t, err, "failed to read values files")
+}
+
+func Test_Render_ReturnsError_WhenChartIsLibrary(t *testing.T) {
+	_, err := helm.NewRenderer().Render("testdata/library", nil, "library", "kyma-system")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.ErrorContains(t, err, "library chart library cannot be rendered")
+}
@@ -0,0 +1 @@
+{{- define "library.name" -}}library{{- end }}
@@ -0,0 +1,34 @@
+package kustomize
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Builder builds kustomizations in-process, equivalent to `kustomize build` with its default options:
+// resources keep the order in which they are declared, plugins are disabled, and files are only loaded
+// from within the kustomization root.
+type Builder struct {
+	fileSystem filesys.FileSystem
+}
+
+func NewBuilder() *Builder {
+	return &Builder{fileSystem: filesys.MakeFsOnDisk()}
+}
+
+// Build builds the kustomization in the given directory into a multi-document manifest.
+func (b *Builder) Build(kustomizationDir string) ([]byte, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resources, err := kustomizer.Run(b.fileSystem, kustomizationDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+
+	manifest, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize kustomization resources: %w", err)
+	}
+	return manifest, nil
+}
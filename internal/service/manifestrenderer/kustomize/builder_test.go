@@ -0,0 +1,58 @@
+package kustomize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer/kustomize"
+)
+
+func Test_Build_BuildsOverlay(t *testing.T) {
+	manifest, err := kustomize.NewBuilder().Build("testdata/overlays/prod")
+
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: sample-manager
+  namespace: kyma-system
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: europe-docker.pkg.dev/kyma-project/prod/sample-manager:1.1.0
+        name: manager
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: sample-manager
+  namespace: kyma-system
+`, string(manifest))
+}
+
+func Test_Build_IsDeterministic(t *testing.T) {
+	builder := kustomize.NewBuilder()
+
+	first, err := builder.Build("testdata/overlays/prod")
+	require.NoError(t, err)
+	second, err := builder.Build("testdata/overlays/prod")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+}
+
+func Test_Build_ReturnsError_WhenResourceDoesNotExist(t *testing.T) {
+	_, err := kustomize.NewBuilder().Build("testdata/invalid")
+
+	require.ErrorContains(t, err, "failed to build kustomization")
+}
+
+func Test_Build_ReturnsError_WhenDirectoryHasNoKustomization(t *testing.T) {
+	_, err := kustomize.NewBuilder().Build("testdata")
+
+	require.ErrorContains(t, err, "failed to build kustomization")
+}
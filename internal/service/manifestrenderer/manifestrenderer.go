@@ -0,0 +1,109 @@
+package manifestrenderer
+
+import (
+	"fmt"
+	"path"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+type ChartRenderer interface {
+	Render(chartPath string, valuesFiles []string, releaseName, namespace string) ([]byte, error)
+}
+
+type KustomizationBuilder interface {
+	Build(kustomizationDir string) ([]byte, error)
+}
+
+type TempFileSystem interface {
+	WriteTempFile(dir, pattern string, content []byte) (string, error)
+	RemoveTempFiles() []error
+}
+
+// Service renders manifest sources, e.g. a Helm chart or a kustomization, into a single raw manifest file.
+type Service struct {
+	filePattern          string
+	chartRenderer        ChartRenderer
+	kustomizationBuilder KustomizationBuilder
+	tempFileSystem       TempFileSystem
+}
+
+func NewService(filePattern string,
+	chartRenderer ChartRenderer,
+	kustomizationBuilder KustomizationBuilder,
+	tempFileSystem TempFileSystem,
+) (*Service, error) {
+	if filePattern == "" {
+		return nil, fmt.Errorf("filePattern must not be empty: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if chartRenderer == nil {
+		return nil, fmt.Errorf("chartRenderer must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if kustomizationBuilder == nil {
+		return nil, fmt.Errorf("kustomizationBuilder must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if tempFileSystem == nil {
+		return nil, fmt.Errorf("tempFileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		filePattern:          filePattern,
+		chartRenderer:        chartRenderer,
+		kustomizationBuilder: kustomizationBuilder,
+		tempFileSystem:       tempFileSystem,
+	}, nil
+}
+
+// RenderChart renders the chart into a temp file and returns its path.
+// The chart and values file paths are resolved relative to the provided basePath.
+func (s *Service) RenderChart(chart *contentprovider.Chart, basePath string) (string, error) {
+	if chart == nil {
+		return "", fmt.Errorf("chart must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	valuesFiles := make([]string, 0, len(chart.ValuesFiles))
+	for _, valuesFile := range chart.ValuesFiles {
+		valuesFiles = append(valuesFiles, path.Join(basePath, valuesFile))
+	}
+
+	chartPath := path.Join(basePath, chart.Path)
+	manifest, err := s.chartRenderer.Render(chartPath, valuesFiles, chart.ReleaseName, chart.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to render chart %s: %w", chartPath, err)
+	}
+
+	return s.writeManifest(manifest)
+}
+
+// RenderKustomization builds the kustomization directory into a temp file and returns its path.
+// The directory is resolved relative to the provided basePath.
+func (s *Service) RenderKustomization(kustomizationDir string, basePath string) (string, error) {
+	if kustomizationDir == "" {
+		return "", fmt.Errorf("kustomizationDir must not be empty: %w", commonerrors.ErrInvalidArg)
+	}
+
+	kustomizationPath := path.Join(basePath, kustomizationDir)
+	manifest, err := s.kustomizationBuilder.Build(kustomizationPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to build kustomization %s: %w", kustomizationPath, err)
+	}
+
+	return s.writeManifest(manifest)
+}
+
+func (s *Service) writeManifest(manifest []byte) (string, error) {
+	manifestPath, err := s.tempFileSystem.WriteTempFile("", s.filePattern, manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to write rendered manifest: %w", err)
+	}
+
+	return manifestPath, nil
+}
+
+func (s *Service) CleanupTempFiles() []error {
+	return s.tempFileSystem.RemoveTempFiles()
+}
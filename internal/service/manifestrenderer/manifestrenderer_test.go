@@ -0,0 +1,219 @@
+package manifestrenderer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/manifestrenderer"
+)
+
+const filePattern = "kyma-module-manifest-*.yaml"
+
+func Test_NewService_ReturnsError_WhenFilePatternIsEmpty(t *testing.T) {
+	_, err := manifestrenderer.NewService("", &chartRendererStub{}, &kustomizationBuilderStub{}, &tempFileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "filePattern must not be empty")
+}
+
+func Test_NewService_ReturnsError_WhenChartRendererIsNil(t *testing.T) {
+	_, err := manifestrenderer.NewService(filePattern, nil, &kustomizationBuilderStub{}, &tempFileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "chartRenderer must not be nil")
+}
+
+func Test_NewService_ReturnsError_WhenKustomizationBuilderIsNil(t *testing.T) {
+	_, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, nil, &tempFileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "kustomizationBuilder must not be nil")
+}
+
+func Test_NewService_ReturnsError_WhenTempFileSystemIsNil(t *testing.T) {
+	_, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "tempFileSystem must not be nil")
+}
+
+func Test_RenderChart_WritesRenderedManifestToTempFile(t *testing.T) {
+	chartRenderer := &chartRendererStub{manifest: []byte("kind: Deployment\n")}
+	tempFileSystem := &tempFileSystemStub{}
+	svc, err := manifestrenderer.NewService(filePattern, chartRenderer, &kustomizationBuilderStub{}, tempFileSystem)
+	require.NoError(t, err)
+
+	manifestPath, err := svc.RenderChart(&contentprovider.Chart{
+		Path:        "charts/sample",
+		ValuesFiles: []string{"values.yaml", "overrides/prod.yaml"},
+		ReleaseName: "sample",
+		Namespace:   "kyma-system",
+	}, "path/to/module")
+
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/kyma-module-manifest-1.yaml", manifestPath)
+	assert.Equal(t, "path/to/module/charts/sample", chartRenderer.chartPath)
+	assert.Equal(t, []string{"path/to/module/values.yaml", "path/to/module/overrides/prod.yaml"},
+		chartRenderer.valuesFiles)
+	assert.Equal(t, "sample", chartRenderer.releaseName)
+	assert.Equal(t, "kyma-system", chartRenderer.namespace)
+	assert.Equal(t, filePattern, tempFileSystem.pattern)
+	assert.Equal(t, "kind: Deployment\n", string(tempFileSystem.content))
+}
+
+func Test_RenderChart_ReturnsError_WhenChartIsNil(t *testing.T) {
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderStub{},
+		&tempFileSystemStub{})
+	require.NoError(t, err)
+
+	_, err = svc.RenderChart(nil, "")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func Test_RenderChart_ReturnsError_WhenRenderingFails(t *testing.T) {
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererErrorStub{}, &kustomizationBuilderStub{},
+		&tempFileSystemStub{})
+	require.NoError(t, err)
+
+	_, err = svc.RenderChart(&contentprovider.Chart{Path: "charts/sample"}, "")
+
+	require.ErrorIs(t, err, errRender)
+	assert.Contains(t, err.Error(), "failed to render chart charts/sample")
+}
+
+func Test_RenderChart_ReturnsError_WhenWritingFails(t *testing.T) {
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderStub{},
+		&tempFileSystemErrorStub{})
+	require.NoError(t, err)
+
+	_, err = svc.RenderChart(&contentprovider.Chart{Path: "charts/sample"}, "")
+
+	require.ErrorIs(t, err, errWrite)
+	assert.Contains(t, err.Error(), "failed to write rendered manifest")
+}
+
+func Test_RenderKustomization_WritesBuiltManifestToTempFile(t *testing.T) {
+	kustomizationBuilder := &kustomizationBuilderStub{manifest: []byte("kind: ServiceAccount\n")}
+	tempFileSystem := &tempFileSystemStub{}
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, kustomizationBuilder, tempFileSystem)
+	require.NoError(t, err)
+
+	manifestPath, err := svc.RenderKustomization("overlays/prod", "path/to/module")
+
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/kyma-module-manifest-1.yaml", manifestPath)
+	assert.Equal(t, "path/to/module/overlays/prod", kustomizationBuilder.kustomizationDir)
+	assert.Equal(t, "kind: ServiceAccount\n", string(tempFileSystem.content))
+}
+
+func Test_RenderKustomization_ReturnsError_WhenDirectoryIsEmpty(t *testing.T) {
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderStub{},
+		&tempFileSystemStub{})
+	require.NoError(t, err)
+
+	_, err = svc.RenderKustomization("", "path/to/module")
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func Test_RenderKustomization_ReturnsError_WhenBuildFails(t *testing.T) {
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderErrorStub{},
+		&tempFileSystemStub{})
+	require.NoError(t, err)
+
+	_, err = svc.RenderKustomization("overlays/prod", "")
+
+	require.ErrorIs(t, err, errBuild)
+	assert.Contains(t, err.Error(), "failed to build kustomization overlays/prod")
+}
+
+func Test_CleanupTempFiles_RemovesTempFiles(t *testing.T) {
+	tempFileSystem := &tempFileSystemStub{}
+	svc, err := manifestrenderer.NewService(filePattern, &chartRendererStub{}, &kustomizationBuilderStub{}, tempFileSystem)
+	require.NoError(t, err)
+
+	errs := svc.CleanupTempFiles()
+
+	assert.Empty(t, errs)
+	assert.True(t, tempFileSystem.removed)
+}
+
+// Test Stubs
+
+var (
+	errRender = errors.New("render error")
+	errBuild  = errors.New("build error")
+	errWrite  = errors.New("write error")
+)
+
+type chartRendererStub struct {
+	manifest    []byte
+	chartPath   string
+	valuesFiles []string
+	releaseName string
+	namespace   string
+}
+
+func (s *chartRendererStub) Render(chartPath string, valuesFiles []string, releaseName, namespace string,
+) ([]byte, error) {
+	s.chartPath = chartPath
+	s.valuesFiles = valuesFiles
+	s.releaseName = releaseName
+	s.namespace = namespace
+	return s.manifest, nil
+}
+
+type chartRendererErrorStub struct{}
+
+func (*chartRendererErrorStub) Render(_ string, _ []string, _, _ string) ([]byte, error) {
+	return nil, errRender
+}
+
+type kustomizationBuilderStub struct {
+	manifest         []byte
+	kustomizationDir string
+}
+
+func (s *kustomizationBuilderStub) Build(kustomizationDir string) ([]byte, error) {
+	s.kustomizationDir = kustomizationDir
+	return s.manifest, nil
+}
+
+type kustomizationBuilderErrorStub struct{}
+
+func (*kustomizationBuilderErrorStub) Build(_ string) ([]byte, error) {
+	return nil, errBuild
+}
+
+type tempFileSystemStub struct {
+	pattern string
+	content []byte
+	removed bool
+}
+
+func (s *tempFileSystemStub) WriteTempFile(_, pattern string, content []byte) (string, error) {
+	s.pattern = pattern
+	s.content = content
+	return "/tmp/kyma-module-manifest-1.yaml", nil
+}
+
+func (s *tempFileSystemStub) RemoveTempFiles() []error {
+	s.removed = true
+	return nil
+}
+
+type tempFileSystemErrorStub struct{}
+
+func (*tempFileSystemErrorStub) WriteTempFile(_, _ string, _ []byte) (string, error) {
+	return "", errWrite
+}
+
+func (*tempFileSystemErrorStub) RemoveTempFiles() []error {
+	return nil
+}
@@ -0,0 +1,311 @@
+package modulecheck
+
+import (
+	"fmt"
+	"path"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type ModuleConfigService interface {
+	ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool) (*contentprovider.ModuleConfig, error)
+	ValidateReservedKeys(moduleConfig *contentprovider.ModuleConfig,
+		reservedKeyPrefixes []validation.ReservedKeyPrefix,
+	) ([]moduleconfigreader.Violation, error)
+}
+
+type FileResolver interface {
+	// Resolve resolves a file reference, which can be either a URL or a local file path (may be just a file name).
+	// For local file paths, it will resolve the path relative to the provided basePath (absolute or relative).
+	Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestFileResolver interface {
+	// ResolveAll resolves a list of file references, which can be URLs, local file paths, directories or glob
+	// patterns, into a single file. Several files are concatenated in a deterministic order.
+	ResolveAll(fileRefs contentprovider.UrlOrLocalFiles, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestRenderer interface {
+	// RenderChart renders a local Helm chart into a temp file and returns its path.
+	// The chart and values file paths are resolved relative to the provided basePath.
+	RenderChart(chart *contentprovider.Chart, basePath string) (string, error)
+	// RenderKustomization builds a local kustomization directory into a temp file and returns its path.
+	// The directory is resolved relative to the provided basePath.
+	RenderKustomization(kustomizationDir string, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestService interface {
+	ExtractImagesFromManifest(manifestPath string, imageLocations []contentprovider.ImageLocation) ([]string, error)
+}
+
+type ImageVersionVerifierService interface {
+	VerifyModuleResources(moduleConfig *contentprovider.ModuleConfig, filePath string) error
+}
+
+type CRDParserService interface {
+	ValidateDefaultCR(paths *types.ResourcePaths) error
+}
+
+type SecurityConfigService interface {
+	ParseSecurityConfigData(securityConfigFile string) (*contentprovider.SecurityScanConfig, error)
+}
+
+// Options configures the checks of a module.
+type Options struct {
+	Out                   iotools.Out
+	ConfigFile            string
+	AllowUnknownFields    bool
+	ReservedKeyPrefixes   []string
+	SkipVersionValidation bool
+}
+
+// Result is a checked module with its resolved inputs. The manifest and default CR files may be temp files,
+// which are removed with CleanupTempFiles.
+type Result struct {
+	ModuleConfig      *contentprovider.ModuleConfig
+	ManifestFilePath  string
+	DefaultCRFilePath string
+	Images            []string
+	SecurityConfig    *contentprovider.SecurityScanConfig
+}
+
+// Service runs the checks of a module config and its referenced files, which the create and the validate
+// commands share, so that validate runs exactly the checks of create.
+type Service struct {
+	moduleConfigService         ModuleConfigService
+	manifestService             ManifestService
+	imageVersionVerifierService ImageVersionVerifierService
+	crdParserService            CRDParserService
+	securityConfigService       SecurityConfigService
+	manifestFileResolver        ManifestFileResolver
+	defaultCRFileResolver       FileResolver
+	manifestRenderer            ManifestRenderer
+}
+
+func NewService(moduleConfigService ModuleConfigService,
+	manifestService ManifestService,
+	imageVersionVerifierService ImageVersionVerifierService,
+	crdParserService CRDParserService,
+	securityConfigService SecurityConfigService,
+	manifestFileResolver ManifestFileResolver,
+	defaultCRFileResolver FileResolver,
+	manifestRenderer ManifestRenderer,
+) (*Service, error) {
+	if moduleConfigService == nil {
+		return nil, fmt.Errorf("moduleConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if manifestService == nil {
+		return nil, fmt.Errorf("manifestService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if imageVersionVerifierService == nil {
+		return nil, fmt.Errorf("imageVersionVerifierService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if crdParserService == nil {
+		return nil, fmt.Errorf("crdParserService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if securityConfigService == nil {
+		return nil, fmt.Errorf("securityConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if manifestFileResolver == nil {
+		return nil, fmt.Errorf("manifestFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if defaultCRFileResolver == nil {
+		return nil, fmt.Errorf("defaultCRFileResolver must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if manifestRenderer == nil {
+		return nil, fmt.Errorf("manifestRenderer must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		moduleConfigService:         moduleConfigService,
+		manifestService:             manifestService,
+		imageVersionVerifierService: imageVersionVerifierService,
+		crdParserService:            crdParserService,
+		securityConfigService:       securityConfigService,
+		manifestFileResolver:        manifestFileResolver,
+		defaultCRFileResolver:       defaultCRFileResolver,
+		manifestRenderer:            manifestRenderer,
+	}, nil
+}
+
+// Check parses and validates the module config, resolves its manifest and default CR and checks them together
+// with the images of the manifest and the security config. The caller must call CleanupTempFiles once the
+// resolved files are no longer needed, also if the check fails.
+func (s *Service) Check(opts Options) (*Result, error) {
+	opts.Out.Write("- Validating module config\n")
+	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile, opts.AllowUnknownFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse module config: %w", err)
+	}
+
+	if err = s.validateReservedKeys(moduleConfig, opts); err != nil {
+		return nil, err
+	}
+
+	configFilePath := path.Dir(opts.ConfigFile)
+	manifestFilePath, err := s.resolveManifest(moduleConfig, configFilePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultCRFilePath string
+	if !moduleConfig.DefaultCR.IsEmpty() {
+		// If the defaultCR is a local file reference, it's entry in the module config file will be relative to the
+		// module config file location (usually the same directory).
+		opts.Out.Write("- Resolving default CR\n")
+		defaultCRFilePath, err = s.defaultCRFileResolver.Resolve(moduleConfig.DefaultCR, configFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve default CR file: %w", err)
+		}
+	}
+
+	opts.Out.Write("- Extracting images from raw manifest\n")
+	images, err := s.manifestService.ExtractImagesFromManifest(manifestFilePath, moduleConfig.ImageLocations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract images from manifest: %w", err)
+	}
+	opts.Out.Write(fmt.Sprintf("\tFound %d image(s)\n", len(images)))
+
+	var securityConfig *contentprovider.SecurityScanConfig
+	if moduleConfig.Security != "" {
+		securityConfig, err = s.validateSecurityConfig(moduleConfig, configFilePath, images, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !opts.SkipVersionValidation {
+		opts.Out.Write("- Verifying module resources\n")
+		if err = s.imageVersionVerifierService.VerifyModuleResources(moduleConfig, manifestFilePath); err != nil {
+			return nil, fmt.Errorf("failed to verify module resources: %w", err)
+		}
+	}
+
+	if defaultCRFilePath != "" {
+		opts.Out.Write("- Validating default CR\n")
+		if err = s.crdParserService.ValidateDefaultCR(types.NewResourcePaths(defaultCRFilePath, manifestFilePath,
+			"")); err != nil {
+			return nil, fmt.Errorf("failed to validate default CR: %w", err)
+		}
+	}
+
+	return &Result{
+		ModuleConfig:      moduleConfig,
+		ManifestFilePath:  manifestFilePath,
+		DefaultCRFilePath: defaultCRFilePath,
+		Images:            images,
+		SecurityConfig:    securityConfig,
+	}, nil
+}
+
+// CleanupTempFiles removes the files that were downloaded or rendered by Check.
+func (s *Service) CleanupTempFiles(out iotools.Out) {
+	if err := s.defaultCRFileResolver.CleanupTempFiles(); err != nil {
+		out.Write(fmt.Sprintf("failed to cleanup temporary default CR files: %v\n", err))
+	}
+	if err := s.manifestFileResolver.CleanupTempFiles(); err != nil {
+		out.Write(fmt.Sprintf("failed to cleanup temporary manifest files: %v\n", err))
+	}
+	if err := s.manifestRenderer.CleanupTempFiles(); err != nil {
+		out.Write(fmt.Sprintf("failed to cleanup rendered manifest files: %v\n", err))
+	}
+}
+
+// validateReservedKeys fails on labels and annotations with a reserved key prefix of error severity
+// and warns about the ones with a reserved key prefix of warning severity.
+func (s *Service) validateReservedKeys(moduleConfig *contentprovider.ModuleConfig, opts Options) error {
+	reservedKeyPrefixes, err := validation.ParseReservedKeyPrefixes(opts.ReservedKeyPrefixes)
+	if err != nil {
+		return fmt.Errorf("failed to parse reserved key prefixes: %w", err)
+	}
+
+	warnings, err := s.moduleConfigService.ValidateReservedKeys(moduleConfig, reservedKeyPrefixes)
+	for _, warning := range warnings {
+		opts.Out.Write(fmt.Sprintf("\tWarning: %s\n", warning))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to validate reserved keys: %w", err)
+	}
+	return nil
+}
+
+// resolveManifest returns the path of the raw manifest, which is either referenced by the module config or
+// rendered from its chart or kustomization. Local references are relative to the module config file location.
+func (s *Service) resolveManifest(moduleConfig *contentprovider.ModuleConfig,
+	configFilePath string,
+	opts Options,
+) (string, error) {
+	if moduleConfig.Chart != nil {
+		opts.Out.Write("- Rendering chart\n")
+		manifestFilePath, err := s.manifestRenderer.RenderChart(moduleConfig.Chart, configFilePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to render manifest from chart: %w", err)
+		}
+		return manifestFilePath, nil
+	}
+
+	if moduleConfig.Kustomization != "" {
+		opts.Out.Write("- Building kustomization\n")
+		manifestFilePath, err := s.manifestRenderer.RenderKustomization(moduleConfig.Kustomization, configFilePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to build manifest from kustomization: %w", err)
+		}
+		return manifestFilePath, nil
+	}
+
+	opts.Out.Write("- Resolving manifest\n")
+	manifestFilePath, err := s.manifestFileResolver.ResolveAll(moduleConfig.Manifest, configFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve manifest file: %w", err)
+	}
+	return manifestFilePath, nil
+}
+
+// validateSecurityConfig loads the security scanners config referenced by the module config. Like the manifest,
+// the reference is resolved relative to the module config file location. It fails if the BDBA images do not match
+// the module version or are not used by the manifest, and warns about manifest images that are not scanned.
+func (s *Service) validateSecurityConfig(moduleConfig *contentprovider.ModuleConfig,
+	configFilePath string,
+	images []string,
+	opts Options,
+) (*contentprovider.SecurityScanConfig, error) {
+	securityConfigFile := moduleConfig.Security
+	if !path.IsAbs(securityConfigFile) {
+		securityConfigFile = path.Join(configFilePath, securityConfigFile)
+	}
+
+	opts.Out.Write("- Validating security config\n")
+	securityConfig, err := s.securityConfigService.ParseSecurityConfigData(securityConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse security config: failed to read %q: %w", securityConfigFile, err)
+	}
+
+	if err = securityConfig.ValidateBDBAImageTags(moduleConfig.Version); err != nil {
+		return nil, fmt.Errorf("failed to validate BDBA image tags: %w", err)
+	}
+
+	if err = securityConfig.ValidateBDBAImagesInManifest(images); err != nil {
+		return nil, fmt.Errorf("failed to verify security config images: %w", err)
+	}
+	for _, img := range securityConfig.UnscannedImages(images) {
+		opts.Out.Write(fmt.Sprintf("\tWarning: image %s is not listed in the BDBA images of the security config\n", img))
+	}
+
+	return securityConfig, nil
+}
@@ -0,0 +1,196 @@
+package modulecheck_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/modulecheck"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
+	_, err := modulecheck.NewService(nil, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "moduleConfigService")
+}
+
+func Test_NewService_ReturnsError_WhenManifestRendererIsNil(t *testing.T) {
+	_, err := modulecheck.NewService(&moduleConfigServiceStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{},
+		&fileResolverStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "manifestRenderer")
+}
+
+func Test_Check_ReturnsResolvedModule(t *testing.T) {
+	svc := newModuleCheckService(t, &moduleConfigServiceStub{}, &crdParserServiceStub{})
+
+	result, err := svc.Check(newOptions(&bytes.Buffer{}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "kyma-project.io/module/telemetry", result.ModuleConfig.Name)
+	assert.Equal(t, "/tmp/manifest.yaml", result.ManifestFilePath)
+	assert.Equal(t, "/tmp/default-cr.yaml", result.DefaultCRFilePath)
+	assert.Equal(t, []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"}, result.Images)
+	assert.Nil(t, result.SecurityConfig)
+}
+
+func Test_Check_PrintsReservedKeyWarnings(t *testing.T) {
+	moduleConfigService := &moduleConfigServiceStub{
+		warnings: []moduleconfigreader.Violation{{
+			FieldPath: "labels",
+			Err:       errors.New("operator.kyma-project.io/managed-by is reserved"),
+		}},
+	}
+	svc := newModuleCheckService(t, moduleConfigService, &crdParserServiceStub{})
+	buffer := &bytes.Buffer{}
+
+	_, err := svc.Check(newOptions(buffer))
+
+	require.NoError(t, err)
+	assert.Contains(t, buffer.String(), "\tWarning: ")
+	assert.Contains(t, buffer.String(), "operator.kyma-project.io/managed-by")
+}
+
+func Test_Check_ReturnsError_WhenDefaultCRIsInvalid(t *testing.T) {
+	svc := newModuleCheckService(t, &moduleConfigServiceStub{}, &crdParserServiceStub{err: errDefaultCR})
+
+	_, err := svc.Check(newOptions(&bytes.Buffer{}))
+
+	require.ErrorIs(t, err, errDefaultCR)
+	require.ErrorContains(t, err, "failed to validate default CR")
+}
+
+func Test_CleanupTempFiles_CleansUpAllResolvers(t *testing.T) {
+	manifestFileResolver := &fileResolverStub{}
+	defaultCRFileResolver := &fileResolverStub{}
+	manifestRenderer := &manifestRendererStub{}
+	svc, err := modulecheck.NewService(&moduleConfigServiceStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, &securityConfigServiceStub{}, manifestFileResolver,
+		defaultCRFileResolver, manifestRenderer)
+	require.NoError(t, err)
+
+	svc.CleanupTempFiles(iotools.NewDefaultOut(&bytes.Buffer{}))
+
+	assert.Equal(t, 1, manifestFileResolver.cleanupTempFilesCallCount)
+	assert.Equal(t, 1, defaultCRFileResolver.cleanupTempFilesCallCount)
+	assert.Equal(t, 1, manifestRenderer.cleanupTempFilesCallCount)
+}
+
+func newModuleCheckService(t *testing.T,
+	moduleConfigService modulecheck.ModuleConfigService,
+	crdParserService modulecheck.CRDParserService,
+) *modulecheck.Service {
+	t.Helper()
+	svc, err := modulecheck.NewService(moduleConfigService, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		crdParserService, &securityConfigServiceStub{}, &fileResolverStub{path: "/tmp/manifest.yaml"},
+		&fileResolverStub{path: "/tmp/default-cr.yaml"}, &manifestRendererStub{})
+	require.NoError(t, err)
+	return svc
+}
+
+func newOptions(buffer *bytes.Buffer) modulecheck.Options {
+	return modulecheck.Options{
+		Out:        iotools.NewDefaultOut(buffer),
+		ConfigFile: "module-config.yaml",
+	}
+}
+
+// Test Stubs
+
+var errDefaultCR = errors.New("default CR does not match the CRD")
+
+type moduleConfigServiceStub struct {
+	warnings []moduleconfigreader.Violation
+}
+
+func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string, _ bool) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:      "kyma-project.io/module/telemetry",
+		Version:   "1.43.1",
+		Manifest:  contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		DefaultCR: contentprovider.MustUrlOrLocalFile("default-cr.yaml"),
+	}, nil
+}
+
+func (s *moduleConfigServiceStub) ValidateReservedKeys(_ *contentprovider.ModuleConfig,
+	_ []validation.ReservedKeyPrefix,
+) ([]moduleconfigreader.Violation, error) {
+	return s.warnings, nil
+}
+
+type fileResolverStub struct {
+	path                      string
+	cleanupTempFilesCallCount int
+}
+
+func (frs *fileResolverStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
+	return frs.path, nil
+}
+
+func (frs *fileResolverStub) ResolveAll(_ contentprovider.UrlOrLocalFiles, _ string) (string, error) {
+	return frs.path, nil
+}
+
+func (frs *fileResolverStub) CleanupTempFiles() []error {
+	frs.cleanupTempFilesCallCount++
+	return nil
+}
+
+type manifestServiceStub struct{}
+
+func (*manifestServiceStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
+	return []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"}, nil
+}
+
+type imageVersionVerifierStub struct{}
+
+func (*imageVersionVerifierStub) VerifyModuleResources(_ *contentprovider.ModuleConfig, _ string) error {
+	return nil
+}
+
+type crdParserServiceStub struct {
+	err error
+}
+
+func (s *crdParserServiceStub) ValidateDefaultCR(_ *types.ResourcePaths) error {
+	return s.err
+}
+
+type securityConfigServiceStub struct{}
+
+func (*securityConfigServiceStub) ParseSecurityConfigData(_ string) (*contentprovider.SecurityScanConfig, error) {
+	return &contentprovider.SecurityScanConfig{}, nil
+}
+
+type manifestRendererStub struct {
+	cleanupTempFilesCallCount int
+}
+
+func (*manifestRendererStub) RenderChart(_ *contentprovider.Chart, _ string) (string, error) {
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (*manifestRendererStub) RenderKustomization(_ string, _ string) (string, error) {
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (s *manifestRendererStub) CleanupTempFiles() []error {
+	s.cleanupTempFilesCallCount++
+	return nil
+}
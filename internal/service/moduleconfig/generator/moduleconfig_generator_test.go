@@ -117,7 +117,7 @@ func (*fileExistsStub) FileExists(_ string) (bool, error) {
 }
 
 func (*fileExistsStub) ReadFile(_ string) ([]byte, error) {
-	manifest := contentprovider.MustUrlOrLocalFile("path/to/manifests")
+	manifest := contentprovider.MustUrlOrLocalFiles("path/to/manifests")
 	defaultCR := contentprovider.MustUrlOrLocalFile("path/to/defaultCR")
 
 	moduleConfig := contentprovider.ModuleConfig{
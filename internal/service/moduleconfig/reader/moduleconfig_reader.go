@@ -1,17 +1,31 @@
 package moduleconfigreader
 
 import (
+	"bytes"
 	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"gopkg.in/yaml.v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 )
 
+// ownedLabelKeys and ownedAnnotationKeys are set by modulectl and evaluated by lifecycle-manager,
+// so they must not be overridden by the labels and annotations of the module config.
+var (
+	ownedLabelKeys      = []string{shared.ModuleName, shared.BetaLabel, shared.InternalLabel}
+	ownedAnnotationKeys = []string{shared.IsClusterScopedAnnotation, common.ContentDigestAnnotationKey}
+)
+
 type FileSystem interface {
 	ReadFile(path string) ([]byte, error)
 }
@@ -30,140 +44,379 @@ func NewService(fileSystem FileSystem) (*Service, error) {
 	}, nil
 }
 
-func (s *Service) ParseAndValidateModuleConfig(moduleConfigFile string,
+// ParseAndValidateModuleConfig parses the module config file and validates it. Unless allowUnknownFields is set,
+// keys that are not part of the module config are rejected.
+func (s *Service) ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool,
 ) (*contentprovider.ModuleConfig, error) {
-	moduleConfig, err := ParseModuleConfig(moduleConfigFile, s.fileSystem)
+	moduleConfig, document, err := parseModuleConfig(moduleConfigFile, s.fileSystem, !allowUnknownFields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse module config file: %w", err)
 	}
 
-	if err = ValidateModuleConfig(moduleConfig); err != nil {
+	result := NewValidationResult(document)
+	validateModuleConfig(moduleConfig, result)
+	if err = result.Err(); err != nil {
 		return nil, fmt.Errorf("failed to validate module config: %w", err)
 	}
 
 	return moduleConfig, nil
 }
 
+// ValidateReservedKeys reports the labels and annotations of the module config whose key starts with one of the
+// reserved prefixes. Keys with a prefix of error severity are returned as *ValidationError, keys with a prefix of
+// warning severity are returned as warnings.
+func (s *Service) ValidateReservedKeys(moduleConfig *contentprovider.ModuleConfig,
+	reservedKeyPrefixes []validation.ReservedKeyPrefix,
+) ([]Violation, error) {
+	errorResult := NewValidationResult(nil)
+	warningResult := NewValidationResult(nil)
+	for _, field := range []struct {
+		name    string
+		entries map[string]string
+	}{
+		{"labels", moduleConfig.Labels},
+		{"annotations", moduleConfig.Annotations},
+	} {
+		for _, key := range slices.Sorted(maps.Keys(field.entries)) {
+			prefix, found := validation.MatchReservedKeyPrefix(key, reservedKeyPrefixes)
+			if !found {
+				continue
+			}
+
+			result := errorResult
+			if prefix.Severity == validation.ReservedKeySeverityWarning {
+				result = warningResult
+			}
+			result.Add(fmt.Sprintf("%s[%s]", field.name, key),
+				fmt.Errorf("key prefix %q is reserved: %w", prefix.Prefix, commonerrors.ErrInvalidOption))
+		}
+	}
+
+	return warningResult.Violations(), errorResult.Err()
+}
+
+// ValidateModuleConfig validates the module config and returns a *ValidationError listing all violations.
 func ValidateModuleConfig(moduleConfig *contentprovider.ModuleConfig) error {
-	if err := validation.ValidateModuleName(moduleConfig.Name); err != nil {
-		return fmt.Errorf("failed to validate module name: %w", err)
+	result := NewValidationResult(nil)
+	validateModuleConfig(moduleConfig, result)
+	return result.Err()
+}
+
+func ValidateAssociatedResources(resources []*metav1.GroupVersionKind) error {
+	result := NewValidationResult(nil)
+	validateAssociatedResources(resources, result)
+	return result.Err()
+}
+
+func ValidateManager(manager *contentprovider.Manager) error {
+	result := NewValidationResult(nil)
+	validateManager(manager, result)
+	return result.Err()
+}
+
+// ParseModuleConfig strictly parses the module config file, unknown keys are rejected.
+func ParseModuleConfig(configFilePath string, fileSystem FileSystem) (*contentprovider.ModuleConfig, error) {
+	moduleConfig, _, err := parseModuleConfig(configFilePath, fileSystem, true)
+	return moduleConfig, err
+}
+
+func parseModuleConfig(configFilePath string, fileSystem FileSystem, strict bool) (*contentprovider.ModuleConfig,
+	*yaml.Node, error,
+) {
+	moduleConfigData, err := fileSystem.ReadFile(configFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read module config file: %w", err)
 	}
 
-	if err := validation.ValidateModuleVersion(moduleConfig.Version); err != nil {
-		return fmt.Errorf("failed to validate module version: %w", err)
+	// the node is kept to resolve the source position of violations
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(moduleConfigData, document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse module config file: %w", err)
 	}
 
-	if moduleConfig.Manifest.IsURL() {
-		if moduleConfig.Manifest.URL().Scheme != "https" {
-			return fmt.Errorf(
-				"failed to validate manifest: %w", fmt.Errorf(
-					"'%s' is not using https scheme: %w",
-					moduleConfig.Manifest.String(),
-					commonerrors.ErrInvalidOption,
-				),
-			)
-		}
-	} else {
-		if moduleConfig.Manifest.IsEmpty() {
-			return fmt.Errorf("failed to validate manifest: must not be empty: %w", commonerrors.ErrInvalidOption)
-		}
-		if strings.HasPrefix(moduleConfig.Manifest.String(), "/") {
-			return fmt.Errorf("failed to validate manifest: must not be an absolute path: %w",
-				commonerrors.ErrInvalidOption)
+	moduleConfig := &contentprovider.ModuleConfig{}
+	if len(document.Content) == 0 {
+		return moduleConfig, document, nil
+	}
+
+	if strict {
+		// unknown keys are reported upfront to list all of them with their position and a suggestion
+		result := NewValidationResult(document)
+		checkUnknownFields(document, reflect.TypeFor[contentprovider.ModuleConfig](), "", result)
+		if err := result.Err(); err != nil {
+			return nil, nil, err
 		}
 	}
 
+	decoder := yaml.NewDecoder(bytes.NewReader(moduleConfigData))
+	decoder.KnownFields(strict)
+	if err := decoder.Decode(moduleConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse module config file: %w", err)
+	}
+
+	return moduleConfig, document, nil
+}
+
+func validateModuleConfig(moduleConfig *contentprovider.ModuleConfig, result *ValidationResult) {
+	if err := validation.ValidateModuleName(moduleConfig.Name); err != nil {
+		result.Add("name", err)
+	}
+
+	if err := validation.ValidateModuleVersion(moduleConfig.Version); err != nil {
+		result.Add("version", err)
+	}
+
+	validateManifestSource(moduleConfig, result)
+
 	if err := validation.ValidateIsValidHTTPSURL(moduleConfig.Repository); err != nil {
-		return fmt.Errorf("failed to validate repository: %w", err)
+		result.Add("repository", err)
 	}
 
 	if err := validation.ValidateIsValidHTTPSURL(moduleConfig.Documentation); err != nil {
-		return fmt.Errorf("failed to validate documentation: %w", err)
+		result.Add("documentation", err)
 	}
 
 	if len(moduleConfig.Icons) == 0 {
-		return fmt.Errorf("failed to validate module icons: must contain at least one icon: %w",
-			commonerrors.ErrInvalidOption)
+		result.Add("icons", fmt.Errorf("must contain at least one icon: %w", commonerrors.ErrInvalidOption))
 	}
+	validateMapEntries("icons", moduleConfig.Icons, result)
+	validateMapEntries("resources", moduleConfig.Resources, result)
 
-	if err := validation.ValidateMapEntries(moduleConfig.Icons); err != nil {
-		return fmt.Errorf("failed to validate module icons: %w", err)
+	if !moduleConfig.DefaultCR.IsEmpty() {
+		if err := validateFileReference(moduleConfig.DefaultCR); err != nil {
+			result.Add("defaultCR", err)
+		}
 	}
 
-	if err := validation.ValidateMapEntries(moduleConfig.Resources); err != nil {
-		return fmt.Errorf("failed to validate resources: %w", err)
+	validateAssociatedResources(moduleConfig.AssociatedResources, result)
+	validateManager(moduleConfig.Manager, result)
+	validateImageLocations(moduleConfig.ImageLocations, result)
+	validateChannels(moduleConfig.Channels, result)
+	validateLabels(moduleConfig.Labels, result)
+	validateAnnotations(moduleConfig.Annotations, result)
+}
+
+func validateLabels(labels map[string]string, result *ValidationResult) {
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		fieldPath := fmt.Sprintf("labels[%s]", key)
+		if slices.Contains(ownedLabelKeys, key) {
+			result.Add(fieldPath, fmt.Errorf("label %s is set by modulectl and must not be overridden: %w", key,
+				commonerrors.ErrInvalidOption))
+		} else if err := validation.ValidateLabel(key, labels[key]); err != nil {
+			result.Add(fieldPath, err)
+		}
 	}
+}
 
-	if moduleConfig.DefaultCR.IsURL() {
-		if moduleConfig.DefaultCR.URL().Scheme != "https" {
-			return fmt.Errorf(
-				"failed to validate default CR: %w",
-				fmt.Errorf(
-					"'%s' is not using https scheme: %w",
-					moduleConfig.DefaultCR.String(),
-					commonerrors.ErrInvalidOption,
-				),
-			)
+func validateAnnotations(annotations map[string]string, result *ValidationResult) {
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		fieldPath := fmt.Sprintf("annotations[%s]", key)
+		if slices.Contains(ownedAnnotationKeys, key) {
+			result.Add(fieldPath, fmt.Errorf("annotation %s is set by modulectl and must not be overridden: %w", key,
+				commonerrors.ErrInvalidOption))
+		} else if err := validation.ValidateAnnotationKey(key); err != nil {
+			result.Add(fieldPath, err)
 		}
-	} else {
-		if !moduleConfig.DefaultCR.IsEmpty() && strings.HasPrefix(moduleConfig.DefaultCR.String(), "/") {
-			return fmt.Errorf("failed to validate default CR: must not be an absolute path: %w",
-				commonerrors.ErrInvalidOption)
+	}
+
+	if err := validation.ValidateAnnotationsSize(annotations); err != nil {
+		result.Add("annotations", err)
+	}
+}
+
+func validateChannels(channels []string, result *ValidationResult) {
+	for index, channel := range channels {
+		fieldPath := fmt.Sprintf("channels[%d]", index)
+		if err := validation.ValidateChannel(channel); err != nil {
+			result.Add(fieldPath, err)
+		} else if slices.Contains(channels[:index], channel) {
+			result.Add(fieldPath, fmt.Errorf("channel %s is listed more than once: %w", channel,
+				commonerrors.ErrInvalidOption))
 		}
 	}
+}
 
-	if err := ValidateAssociatedResources(moduleConfig.AssociatedResources); err != nil {
-		return fmt.Errorf("failed to validate associated resources: %w", err)
+func validateFileReference(fileRef contentprovider.UrlOrLocalFile) error {
+	if fileRef.IsURL() {
+		if fileRef.URL().Scheme != "https" {
+			return fmt.Errorf("'%s' is not using https scheme: %w", fileRef.String(), commonerrors.ErrInvalidOption)
+		}
+		return nil
 	}
 
-	if err := ValidateManager(moduleConfig.Manager); err != nil {
-		return fmt.Errorf("failed to validate manager: %w", err)
+	if strings.HasPrefix(fileRef.String(), "/") {
+		return fmt.Errorf("must not be an absolute path: %w", commonerrors.ErrInvalidOption)
 	}
 
 	return nil
 }
 
-func ValidateAssociatedResources(resources []*metav1.GroupVersionKind) error {
-	for _, resource := range resources {
-		if err := validation.ValidateGvk(resource.Group, resource.Version, resource.Kind); err != nil {
-			return fmt.Errorf("GVK is invalid: %w", err)
+// validateMapEntries validates name/link entries in a stable order so that repeated runs report the same output.
+func validateMapEntries(fieldPath string, nameLinkMap map[string]string, result *ValidationResult) {
+	for _, name := range slices.Sorted(maps.Keys(nameLinkMap)) {
+		if err := validation.ValidateMapEntry(name, nameLinkMap[name]); err != nil {
+			result.Add(fmt.Sprintf("%s[%s]", fieldPath, name), err)
 		}
 	}
-	return nil
 }
 
-func ValidateManager(manager *contentprovider.Manager) error {
+func validateAssociatedResources(resources []*metav1.GroupVersionKind, result *ValidationResult) {
+	for index, resource := range resources {
+		fieldPath := fmt.Sprintf("associatedResources[%d]", index)
+		if resource == nil {
+			result.Add(fieldPath, fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+			continue
+		}
+		validateGvk(fieldPath, resource.Group, resource.Version, resource.Kind, result)
+	}
+}
+
+func validateManager(manager *contentprovider.Manager, result *ValidationResult) {
 	if manager == nil {
-		return nil
+		return
 	}
 
 	if manager.Name == "" {
-		return fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption)
+		result.Add("manager.name", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
 	}
 
-	if err := validation.ValidateGvk(manager.Group, manager.Version, manager.Kind); err != nil {
-		return fmt.Errorf("GVK is invalid: %w", err)
-	}
+	validateGvk("manager", manager.Group, manager.Version, manager.Kind, result)
 
 	if manager.Namespace != "" {
 		if err := validation.ValidateNamespace(manager.Namespace); err != nil {
-			return fmt.Errorf("namespace is invalid: %w", err)
+			result.Add("manager.namespace", err)
+		}
+	}
+}
+
+// validateManifestSource ensures that exactly one of manifest, chart and kustomization is set.
+func validateManifestSource(moduleConfig *contentprovider.ModuleConfig, result *ValidationResult) {
+	var sources []string
+	if !moduleConfig.Manifest.IsEmpty() {
+		sources = append(sources, "manifest")
+		validateManifestReferences(moduleConfig.Manifest, result)
+	}
+
+	if moduleConfig.Chart != nil {
+		sources = append(sources, "chart")
+		validateChart(moduleConfig.Chart, result)
+	}
+
+	if moduleConfig.Kustomization != "" {
+		sources = append(sources, "kustomization")
+		if err := validateLocalPath(moduleConfig.Kustomization); err != nil {
+			result.Add("kustomization", err)
+		}
+	}
+
+	if len(sources) == 0 {
+		result.Add("manifest", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+		return
+	}
+	for _, source := range sources[1:] {
+		result.Add(source, fmt.Errorf("must not be set together with %s: %w", sources[0], commonerrors.ErrInvalidOption))
+	}
+}
+
+// validateManifestReferences reports errors of a single reference on "manifest" and of a list on "manifest[i]".
+func validateManifestReferences(manifest contentprovider.UrlOrLocalFiles, result *ValidationResult) {
+	entries := manifest.Entries()
+	for index, fileRef := range entries {
+		fieldPath := "manifest"
+		if len(entries) > 1 {
+			fieldPath = fmt.Sprintf("manifest[%d]", index)
+		}
+
+		if fileRef.IsEmpty() {
+			result.Add(fieldPath, fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+			continue
+		}
+		if err := validateFileReference(fileRef); err != nil {
+			result.Add(fieldPath, err)
+			continue
+		}
+		if !fileRef.IsURL() {
+			if _, err := path.Match(fileRef.String(), ""); err != nil {
+				result.Add(fieldPath, fmt.Errorf("'%s' is not a valid glob pattern: %w", fileRef.String(),
+					commonerrors.ErrInvalidOption))
+			}
+		}
+	}
+}
+
+func validateChart(chart *contentprovider.Chart, result *ValidationResult) {
+	if chart.Path == "" {
+		result.Add("chart.path", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+	} else if err := validateLocalPath(chart.Path); err != nil {
+		result.Add("chart.path", err)
+	}
+
+	for index, valuesFile := range chart.ValuesFiles {
+		if err := validateLocalPath(valuesFile); err != nil {
+			result.Add(fmt.Sprintf("chart.valuesFiles[%d]", index), err)
 		}
 	}
 
+	if chart.ReleaseName == "" {
+		result.Add("chart.releaseName", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+	}
+
+	if chart.Namespace == "" {
+		result.Add("chart.namespace", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+	} else if err := validation.ValidateNamespace(chart.Namespace); err != nil {
+		result.Add("chart.namespace", err)
+	}
+}
+
+func validateLocalPath(filePath string) error {
+	if filePath == "" {
+		return fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if strings.HasPrefix(filePath, "/") {
+		return fmt.Errorf("must not be an absolute path: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if strings.Contains(filePath, "://") {
+		return fmt.Errorf("'%s' must be a local path: %w", filePath, commonerrors.ErrInvalidOption)
+	}
+
 	return nil
 }
 
-func ParseModuleConfig(configFilePath string, fileSystem FileSystem) (*contentprovider.ModuleConfig, error) {
-	moduleConfigData, err := fileSystem.ReadFile(configFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read module config file: %w", err)
+// validateImageLocations allows an empty group as image locations may refer to core resources.
+func validateImageLocations(imageLocations []contentprovider.ImageLocation, result *ValidationResult) {
+	for index, location := range imageLocations {
+		fieldPath := fmt.Sprintf("imageLocations[%d]", index)
+		if location.Kind == "" {
+			result.Add(fieldPath+".kind", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+		}
+
+		if location.Version == "" {
+			result.Add(fieldPath+".version", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+		}
+
+		if len(location.Paths) == 0 {
+			result.Add(fieldPath+".paths", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+		}
+		for pathIndex, path := range location.Paths {
+			if _, err := contentprovider.ParseImageJSONPath(path); err != nil {
+				result.Add(fmt.Sprintf("%s.paths[%d]", fieldPath, pathIndex), err)
+			}
+		}
 	}
+}
 
-	moduleConfig := &contentprovider.ModuleConfig{}
-	if err := yaml.Unmarshal(moduleConfigData, moduleConfig); err != nil {
-		return nil, fmt.Errorf("failed to parse module config file: %w", err)
+func validateGvk(fieldPath, group, version, kind string, result *ValidationResult) {
+	if kind == "" {
+		result.Add(fieldPath+".kind", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
 	}
 
-	return moduleConfig, nil
+	if group == "" {
+		result.Add(fieldPath+".group", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+	}
+
+	if version == "" {
+		result.Add(fieldPath+".version", fmt.Errorf("must not be empty: %w", commonerrors.ErrInvalidOption))
+	}
 }
@@ -3,6 +3,7 @@ package moduleconfigreader_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/validation"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
 	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
 )
@@ -69,8 +71,8 @@ func TestNew_CalledWithNilDependencies_ReturnsErr(t *testing.T) {
 }
 
 func Test_ValidateModuleConfig(t *testing.T) {
-	exampleManifest := contentprovider.MustUrlOrLocalFile("https://example.com/path/to/manifest")
-	emptyManifest := contentprovider.MustUrlOrLocalFile("")
+	exampleManifest := contentprovider.MustUrlOrLocalFiles("https://example.com/path/to/manifest")
+	emptyManifest := contentprovider.MustUrlOrLocalFiles("")
 
 	tests := []struct {
 		name          string
@@ -87,7 +89,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 			moduleConfig: &contentprovider.ModuleConfig{
 				Name:          "github.com/module-name",
 				Version:       "0.0.1",
-				Manifest:      contentprovider.MustUrlOrLocalFile("./test"), // valid local file path
+				Manifest:      contentprovider.MustUrlOrLocalFiles("./test"), // valid local file path
 				Repository:    exampleRepository,
 				Documentation: exampleDocumentation,
 				Icons: contentprovider.Icons{
@@ -101,7 +103,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 			moduleConfig: &contentprovider.ModuleConfig{
 				Name:          "github.com/module-name",
 				Version:       "0.0.1",
-				Manifest:      contentprovider.MustUrlOrLocalFile("/some/path/test.yaml"), // invalid absolute path
+				Manifest:      contentprovider.MustUrlOrLocalFiles("/some/path/test.yaml"), // invalid absolute path
 				Repository:    exampleRepository,
 				Documentation: exampleDocumentation,
 				Icons: contentprovider.Icons{
@@ -109,7 +111,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate manifest: must not be an absolute path: %w",
+				"manifest: must not be an absolute path: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -142,7 +144,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				DefaultCR: contentprovider.MustUrlOrLocalFile("/some/path/test.yaml"), // invalid absolute path
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate default CR: must not be an absolute path: %w",
+				"defaultCR: must not be an absolute path: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -159,7 +161,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"opts.ModuleName must match the required pattern, e.g: 'github.com/path-to/your-repo': %w",
+				"name: must match the required pattern, e.g: 'github.com/path-to/your-repo': %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -175,7 +177,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("opts.ModuleVersion failed to be parsed as semantic version: %w",
+			expectedError: fmt.Errorf("version: failed to be parsed as semantic version: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -190,7 +192,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate manifest: must not be empty: %w",
+			expectedError: fmt.Errorf("manifest: must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -205,7 +207,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate repository: must not be empty: %w",
+			expectedError: fmt.Errorf("repository: must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -220,7 +222,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate repository: 'some repository' is not using https scheme: %w",
+			expectedError: fmt.Errorf("repository: 'some repository' is not using https scheme: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -235,7 +237,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate documentation: must not be empty: %w",
+			expectedError: fmt.Errorf("documentation: must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -251,7 +253,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate documentation: 'some documentation' is not using https scheme: %w",
+				"documentation: 'some documentation' is not using https scheme: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -265,7 +267,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				Documentation: exampleDocumentation,
 				Icons:         contentprovider.Icons{},
 			},
-			expectedError: fmt.Errorf("failed to validate module icons: must contain at least one icon: %w",
+			expectedError: fmt.Errorf("icons: must contain at least one icon: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -280,7 +282,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"": exampleIcon,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate module icons: name must not be empty: %w",
+			expectedError: fmt.Errorf("icons[]: name must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -295,7 +297,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"module-icon": "",
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate module icons: link must not be empty: %w",
+			expectedError: fmt.Errorf("icons[module-icon]: link must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -311,7 +313,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate module icons: failed to validate link:"+
+				"icons[module-icon]: failed to validate link:"+
 					" 'this is not a URL' is not using https scheme: %w",
 				commonerrors.ErrInvalidOption,
 			),
@@ -332,7 +334,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate resources: failed to validate link: '%%%% not a URL' is not a valid URL: %w",
+				"resources[key]: failed to validate link: '%%%% not a URL' is not a valid URL: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -351,7 +353,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"": exampleRawManifest,
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate resources: name must not be empty: %w",
+			expectedError: fmt.Errorf("resources[]: name must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -369,7 +371,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 					"name": "",
 				},
 			},
-			expectedError: fmt.Errorf("failed to validate resources: link must not be empty: %w",
+			expectedError: fmt.Errorf("resources[name]: link must not be empty: %w",
 				commonerrors.ErrInvalidOption),
 		},
 		{
@@ -377,7 +379,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 			moduleConfig: &contentprovider.ModuleConfig{
 				Name:          "github.com/module-name",
 				Version:       "0.0.1",
-				Manifest:      contentprovider.MustUrlOrLocalFile("file://path/to/manifest"),
+				Manifest:      contentprovider.MustUrlOrLocalFiles("file://path/to/manifest"),
 				Repository:    exampleRepository,
 				Documentation: exampleDocumentation,
 				Icons: contentprovider.Icons{
@@ -385,7 +387,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate manifest: 'file://path/to/manifest' is not using https scheme: %w",
+				"manifest: 'file://path/to/manifest' is not using https scheme: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -403,7 +405,7 @@ func Test_ValidateModuleConfig(t *testing.T) {
 				},
 			},
 			expectedError: fmt.Errorf(
-				"failed to validate default CR: 'file://path/to/defaultCR' is not using https scheme: %w",
+				"defaultCR: 'file://path/to/defaultCR' is not using https scheme: %w",
 				commonerrors.ErrInvalidOption,
 			),
 		},
@@ -467,7 +469,7 @@ func Test_ValidateManager(t *testing.T) {
 					Kind:    "Deployment",
 				},
 			},
-			expectedError: fmt.Errorf("name must not be empty: %w", commonerrors.ErrInvalidOption),
+			expectedError: fmt.Errorf("manager.name: must not be empty: %w", commonerrors.ErrInvalidOption),
 		},
 		{
 			name: "invalid manager - empty kind",
@@ -479,7 +481,7 @@ func Test_ValidateManager(t *testing.T) {
 					Version: "v1",
 				},
 			},
-			expectedError: fmt.Errorf("kind must not be empty: %w", commonerrors.ErrInvalidOption),
+			expectedError: fmt.Errorf("manager.kind: must not be empty: %w", commonerrors.ErrInvalidOption),
 		},
 		{
 			name: "invalid manager - empty group",
@@ -491,7 +493,7 @@ func Test_ValidateManager(t *testing.T) {
 					Kind:    "Deployment",
 				},
 			},
-			expectedError: fmt.Errorf("group must not be empty: %w", commonerrors.ErrInvalidOption),
+			expectedError: fmt.Errorf("manager.group: must not be empty: %w", commonerrors.ErrInvalidOption),
 		},
 		{
 			name: "invalid manager - empty version",
@@ -503,7 +505,7 @@ func Test_ValidateManager(t *testing.T) {
 					Group: "apps",
 				},
 			},
-			expectedError: fmt.Errorf("version must not be empty: %w", commonerrors.ErrInvalidOption),
+			expectedError: fmt.Errorf("manager.version: must not be empty: %w", commonerrors.ErrInvalidOption),
 		},
 	}
 	for _, test := range tests {
@@ -570,6 +572,513 @@ func Test_ValidateAssociatedResources(t *testing.T) {
 	}
 }
 
+func Test_ValidateModuleConfig_ReportsAllViolations(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "invalid name",
+		Version:       "invalid version",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("/some/path/test.yaml"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		AssociatedResources: []*metav1.GroupVersionKind{
+			{Group: "apps", Kind: "Deployment"},
+		},
+		Manager: &contentprovider.Manager{
+			GroupVersionKind: metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	fieldPaths := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		fieldPaths = append(fieldPaths, violation.FieldPath)
+	}
+	require.Equal(t, []string{
+		"name", "version", "manifest", "icons", "associatedResources[0].version", "manager.name",
+	}, fieldPaths)
+}
+
+func Test_ValidateModuleConfig_ImageLocations(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("https://example.com/path/to/manifests"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		ImageLocations: []contentprovider.ImageLocation{
+			{
+				GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+				Paths:            []string{".data.image"},
+			},
+			{
+				GroupVersionKind: metav1.GroupVersionKind{Group: "operator.kyma-project.io"},
+				Paths:            []string{"{.spec.image}", ".spec.images[", ""},
+			},
+			{
+				GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Sample"},
+			},
+		},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	fieldPaths := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		fieldPaths = append(fieldPaths, violation.FieldPath)
+	}
+	require.Equal(t, []string{
+		"imageLocations[1].kind", "imageLocations[1].version", "imageLocations[1].paths[1]",
+		"imageLocations[1].paths[2]", "imageLocations[2].paths",
+	}, fieldPaths)
+}
+
+func Test_ValidateModuleConfig_Chart(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		Chart: &contentprovider.Chart{
+			Path:        "charts/sample",
+			ValuesFiles: []string{"values.yaml", "prod-values.yaml"},
+			ReleaseName: "sample",
+			Namespace:   "kyma-system",
+		},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	require.NoError(t, err)
+}
+
+func Test_ValidateModuleConfig_Chart_ReturnsError(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		Chart: &contentprovider.Chart{
+			Path:        "/charts/sample",
+			ValuesFiles: []string{"values.yaml", "https://example.com/values.yaml"},
+			Namespace:   "Invalid_Namespace",
+		},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	fieldPaths := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		fieldPaths = append(fieldPaths, violation.FieldPath)
+	}
+	require.Equal(t, []string{
+		"chart.path", "chart.valuesFiles[1]", "chart.releaseName", "chart.namespace", "chart",
+	}, fieldPaths)
+}
+
+func Test_ValidateModuleConfig_Kustomization(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Kustomization: "config/overlays/prod",
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	require.NoError(t, err)
+}
+
+func Test_ValidateModuleConfig_Kustomization_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      string
+		chart         *contentprovider.Chart
+		kustomization string
+		expectedPaths []string
+	}{
+		{
+			name:          "absolute path",
+			kustomization: "/config/overlays/prod",
+			expectedPaths: []string{"kustomization"},
+		},
+		{
+			name:          "URL",
+			kustomization: "https://github.com/kyma-project/template-operator/config/default",
+			expectedPaths: []string{"kustomization"},
+		},
+		{
+			name:          "set together with manifest",
+			manifest:      "manifest.yaml",
+			kustomization: "config/overlays/prod",
+			expectedPaths: []string{"kustomization"},
+		},
+		{
+			name: "set together with chart",
+			chart: &contentprovider.Chart{
+				Path:        "charts/sample",
+				ReleaseName: "sample",
+				Namespace:   "kyma-system",
+			},
+			kustomization: "config/overlays/prod",
+			expectedPaths: []string{"kustomization"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			moduleConfig := &contentprovider.ModuleConfig{
+				Name:          "github.com/module-name",
+				Version:       "0.0.1",
+				Chart:         test.chart,
+				Kustomization: test.kustomization,
+				Repository:    exampleRepository,
+				Documentation: exampleDocumentation,
+				Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+			}
+			if test.manifest != "" {
+				moduleConfig.Manifest = contentprovider.MustUrlOrLocalFiles(test.manifest)
+			}
+
+			err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+			var validationErr *moduleconfigreader.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fieldPaths := make([]string, 0, len(validationErr.Violations))
+			for _, violation := range validationErr.Violations {
+				fieldPaths = append(fieldPaths, violation.FieldPath)
+			}
+			require.Equal(t, test.expectedPaths, fieldPaths)
+		})
+	}
+}
+
+func Test_ValidateModuleConfig_Channels_ReturnsError(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		Channels:      []string{"regular", "Fast", "regular", "experimental"},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 2)
+	require.Equal(t, "channels[1]", validationErr.Violations[0].FieldPath)
+	require.Equal(t, "channels[2]", validationErr.Violations[1].FieldPath)
+	require.ErrorContains(t, validationErr.Violations[1], "listed more than once")
+}
+
+func Test_ValidateModuleConfig_LabelsAndAnnotations_ReturnsError(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		Labels: map[string]string{
+			"example.com/team":                       "kyma team",
+			"example.com/valid":                      "true",
+			"operator.kyma-project.io/beta":          "true",
+			"operator.kyma-project.io/module-name":   "other-module",
+			"example.com/" + strings.Repeat("a", 64): "true",
+		},
+		Annotations: map[string]string{
+			"example.com/doc url":                        "https://example.com",
+			"operator.kyma-project.io/doc-url":           "https://example.com",
+			"operator.kyma-project.io/is-cluster-scoped": "true",
+			"operator.kyma-project.io/content-digest":    "sha256:0000",
+		},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	fieldPaths := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		fieldPaths = append(fieldPaths, violation.FieldPath)
+	}
+	require.Equal(t, []string{
+		"labels[example.com/" + strings.Repeat("a", 64) + "]",
+		"labels[example.com/team]",
+		"labels[operator.kyma-project.io/beta]",
+		"labels[operator.kyma-project.io/module-name]",
+		"annotations[example.com/doc url]",
+		"annotations[operator.kyma-project.io/content-digest]",
+		"annotations[operator.kyma-project.io/is-cluster-scoped]",
+	}, fieldPaths)
+	require.ErrorContains(t, err, "invalid label value")
+	require.ErrorContains(t, err, "label operator.kyma-project.io/beta is set by modulectl and must not be overridden")
+}
+
+func Test_ValidateModuleConfig_Annotations_ReturnsError_WhenTooLarge(t *testing.T) {
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "github.com/module-name",
+		Version:       "0.0.1",
+		Manifest:      contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		Repository:    exampleRepository,
+		Documentation: exampleDocumentation,
+		Icons:         contentprovider.Icons{"module-icon": "https://example.com/path/to/some-icon"},
+		Annotations:   map[string]string{"example.com/doc": strings.Repeat("a", 256*1024)},
+	}
+
+	err := moduleconfigreader.ValidateModuleConfig(moduleConfig)
+
+	require.ErrorContains(t, err, "annotations: total size")
+}
+
+func Test_ParseAndValidateModuleConfig_ReportsSourcePositionOfLabel(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{content: `name: github.com/module-name
+version: 0.0.1
+manifest: https://example.com/path/to/manifests
+repository: https://example.com/path/to/repository
+documentation: https://example.com/path/to/documentation
+icons:
+  - name: module-icon
+    link: https://example.com/path/to/some-icon
+labels:
+  operator.kyma-project.io/internal: "true"
+`})
+	require.NoError(t, err)
+
+	_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+	require.ErrorContains(t, err, "labels[operator.kyma-project.io/internal] (line 10, column 38): "+
+		"label operator.kyma-project.io/internal is set by modulectl and must not be overridden")
+}
+
+func Test_ValidateReservedKeys(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{})
+	require.NoError(t, err)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Labels: map[string]string{
+			"example.com/team":                 "kyma",
+			"provenance.kyma-project.io/build": "1",
+		},
+		Annotations: map[string]string{
+			"operator.kyma-project.io/doc-url": "https://example.com",
+		},
+	}
+
+	warnings, err := svc.ValidateReservedKeys(moduleConfig, []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+		{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+	})
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 1)
+	require.Equal(t, "labels[provenance.kyma-project.io/build]: "+
+		`key prefix "provenance.kyma-project.io/" is reserved: invalid Option`, validationErr.Violations[0].Error())
+	require.Len(t, warnings, 1)
+	require.Equal(t, "annotations[operator.kyma-project.io/doc-url]", warnings[0].FieldPath)
+}
+
+func Test_ValidateReservedKeys_ReturnsNoViolations_WhenNoKeyIsReserved(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{})
+	require.NoError(t, err)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Labels: map[string]string{"example.com/team": "kyma"},
+	}
+
+	warnings, err := svc.ValidateReservedKeys(moduleConfig, []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+	})
+
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
+
+func Test_ParseAndValidateModuleConfig_ParsesManifestList(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{content: `name: github.com/module-name
+version: 0.0.1
+manifest:
+  - crds
+  - manifests/*.yaml
+  - https://example.com/rbac.yaml
+repository: https://example.com/path/to/repository
+documentation: https://example.com/path/to/documentation
+icons:
+  module-icon: https://example.com/path/to/some-icon
+`})
+	require.NoError(t, err)
+
+	moduleConfig, err := svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+	require.NoError(t, err)
+	require.Equal(t, contentprovider.MustUrlOrLocalFiles("crds", "manifests/*.yaml", "https://example.com/rbac.yaml"),
+		moduleConfig.Manifest)
+}
+
+func Test_ValidateModuleConfig_ManifestList_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifest      []string
+		expectedPaths []string
+	}{
+		{
+			name:          "single absolute path",
+			manifest:      []string{"/manifests"},
+			expectedPaths: []string{"manifest"},
+		},
+		{
+			name:          "invalid glob pattern",
+			manifest:      []string{"crds", "manifests/[a-.yaml"},
+			expectedPaths: []string{"manifest[1]"},
+		},
+		{
+			name:          "empty and http entries",
+			manifest:      []string{"", "manifests", "http://example.com/rbac.yaml"},
+			expectedPaths: []string{"manifest[0]", "manifest[2]"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries := make([]string, 0, len(test.manifest))
+			for _, entry := range test.manifest {
+				entries = append(entries, "\n  - \""+entry+"\"")
+			}
+			svc, err := moduleconfigreader.NewService(&fileContentStub{content: `name: github.com/module-name
+version: 0.0.1
+repository: https://example.com/path/to/repository
+documentation: https://example.com/path/to/documentation
+icons:
+  module-icon: https://example.com/path/to/some-icon
+manifest:` + strings.Join(entries, "")})
+			require.NoError(t, err)
+
+			_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+			var validationErr *moduleconfigreader.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			fieldPaths := make([]string, 0, len(validationErr.Violations))
+			for _, violation := range validationErr.Violations {
+				fieldPaths = append(fieldPaths, violation.FieldPath)
+			}
+			require.Equal(t, test.expectedPaths, fieldPaths)
+		})
+	}
+}
+
+func Test_ParseAndValidateModuleConfig_ReportsSourcePositions(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{content: `name: github.com/module-name
+version: 0.0.1
+manifest: https://example.com/path/to/manifests
+repository: https://example.com/path/to/repository
+documentation: https://example.com/path/to/documentation
+icons:
+  - name: module-icon
+    link: http://example.com/path/to/some-icon
+associatedResources:
+  - group: networking.istio.io
+    version: v1alpha3
+manager:
+  name: manager-name
+  namespace: Invalid_Namespace
+  group: apps
+  version: v1
+  kind: Deployment
+`})
+	require.NoError(t, err)
+
+	_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 3)
+	require.Equal(t, "icons[module-icon]", validationErr.Violations[0].FieldPath)
+	require.Equal(t, 7, validationErr.Violations[0].Line)
+	require.Equal(t, 5, validationErr.Violations[0].Column)
+	require.Equal(t, "associatedResources[0].kind", validationErr.Violations[1].FieldPath)
+	require.Equal(t, 10, validationErr.Violations[1].Line)
+	require.Equal(t, 5, validationErr.Violations[1].Column)
+	require.Equal(t, "manager.namespace", validationErr.Violations[2].FieldPath)
+	require.Equal(t, 14, validationErr.Violations[2].Line)
+	require.Equal(t, 14, validationErr.Violations[2].Column)
+	require.ErrorContains(t, err, "manager.namespace (line 14, column 14): ")
+}
+
+const moduleConfigWithUnknownFields = `name: github.com/module-name
+version: 0.0.1
+manifest: https://example.com/path/to/manifests
+repository: https://example.com/path/to/repository
+documentation: https://example.com/path/to/documentation
+icons:
+  - name: module-icon
+    link: https://example.com/path/to/some-icon
+requiresDowntme: true
+channel: regular
+manager:
+  name: manager-name
+  group: apps
+  version: v1
+  kinds: Deployment
+`
+
+func Test_ParseAndValidateModuleConfig_ReturnsError_WhenFileContainsUnknownFields(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{content: moduleConfigWithUnknownFields})
+	require.NoError(t, err)
+
+	_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 3)
+	require.Equal(t, "requiresDowntme (line 9, column 1): "+
+		`unknown field "requiresDowntme", did you mean "requiresDowntime"?: invalid Option`,
+		validationErr.Violations[0].Error())
+	require.Equal(t, "channel (line 10, column 1): "+
+		`unknown field "channel", did you mean "channels"?: invalid Option`,
+		validationErr.Violations[1].Error())
+	require.Equal(t, "manager.kinds (line 15, column 3): "+
+		`unknown field "kinds", did you mean "kind"?: invalid Option`,
+		validationErr.Violations[2].Error())
+}
+
+func Test_ParseAndValidateModuleConfig_IgnoresUnknownFields_WhenAllowed(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{content: moduleConfigWithUnknownFields})
+	require.NoError(t, err)
+
+	_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, true)
+
+	// the unknown keys are dropped, the misspelled manager kind is still reported as missing
+	var validationErr *moduleconfigreader.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Violations, 1)
+	require.Equal(t, "manager.kind", validationErr.Violations[0].FieldPath)
+	require.NotContains(t, err.Error(), "unknown field")
+}
+
+func Test_ParseAndValidateModuleConfig_ReturnsError_WhenFileIsEmpty(t *testing.T) {
+	svc, err := moduleconfigreader.NewService(&fileContentStub{})
+	require.NoError(t, err)
+
+	_, err = svc.ParseAndValidateModuleConfig(moduleConfigFile, false)
+
+	require.ErrorContains(t, err, "name: must not be empty")
+	require.ErrorContains(t, err, "version: must not be empty")
+}
+
 // Test Stubs
 
 type fileExistsStub struct{}
@@ -581,7 +1090,7 @@ func (*fileExistsStub) FileExists(_ string) (bool, error) {
 var expectedReturnedModuleConfig = contentprovider.ModuleConfig{
 	Name:          "github.com/module-name",
 	Version:       "0.0.1",
-	Manifest:      contentprovider.MustUrlOrLocalFile("https://example.com/path/to/manifests"),
+	Manifest:      contentprovider.MustUrlOrLocalFiles("https://example.com/path/to/manifests"),
 	Repository:    exampleRepository,
 	Documentation: exampleDocumentation,
 	Icons: contentprovider.Icons{
@@ -630,3 +1139,11 @@ var errReadingFile = errors.New("some error reading file")
 func (*fileDoesNotExistStub) ReadFile(_ string) ([]byte, error) {
 	return nil, errReadingFile
 }
+
+type fileContentStub struct {
+	content string
+}
+
+func (s *fileContentStub) ReadFile(_ string) ([]byte, error) {
+	return []byte(s.content), nil
+}
@@ -0,0 +1,153 @@
+package moduleconfigreader
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+// maxSuggestionDistance caps the edit distance for a known field to be suggested for an unknown one.
+// Shorter keys allow fewer edits so that unrelated fields are not suggested.
+const maxSuggestionDistance = 3
+
+var (
+	yamlUnmarshalerType         = reflect.TypeFor[yaml.Unmarshaler]()
+	obsoleteYamlUnmarshalerType = reflect.TypeFor[interface {
+		UnmarshalYAML(unmarshal func(any) error) error
+	}]()
+)
+
+// checkUnknownFields records a violation for every mapping key of the node that is not a field of the target type.
+// Types with a custom YAML unmarshaler and maps are not inspected.
+func checkUnknownFields(node *yaml.Node, target reflect.Type, fieldPath string, result *ValidationResult) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.DocumentNode {
+		for _, content := range node.Content {
+			checkUnknownFields(content, target, fieldPath, result)
+		}
+		return
+	}
+
+	for target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if hasCustomUnmarshaler(target) {
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := knownFields(target)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinFieldPath(fieldPath, key.Value)
+			fieldType, known := fields[key.Value]
+			if !known {
+				result.addAt(keyPath, key, unknownFieldError(key.Value, fields))
+				continue
+			}
+			checkUnknownFields(value, fieldType, keyPath, result)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for index, item := range node.Content {
+			checkUnknownFields(item, target.Elem(), fmt.Sprintf("%s[%d]", fieldPath, index), result)
+		}
+	default:
+	}
+}
+
+func hasCustomUnmarshaler(target reflect.Type) bool {
+	pointerType := reflect.PointerTo(target)
+	return pointerType.Implements(yamlUnmarshalerType) || pointerType.Implements(obsoleteYamlUnmarshalerType)
+}
+
+// knownFields returns the YAML keys of the struct fields following the yaml.v3 naming rules:
+// the name from the yaml tag or the lowercased field name, with inlined structs flattened.
+func knownFields(target reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := range target.NumField() {
+		field := target.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if strings.Contains(options, "inline") {
+			for inlinedName, inlinedType := range knownFields(field.Type) {
+				fields[inlinedName] = inlinedType
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func unknownFieldError(key string, fields map[string]reflect.Type) error {
+	if suggestion := suggestField(key, fields); suggestion != "" {
+		return fmt.Errorf("unknown field %q, did you mean %q?: %w", key, suggestion, commonerrors.ErrInvalidOption)
+	}
+	return fmt.Errorf("unknown field %q: %w", key, commonerrors.ErrInvalidOption)
+}
+
+// suggestField returns the known field closest to the key, or an empty string if none is close enough.
+func suggestField(key string, fields map[string]reflect.Type) string {
+	suggestion := ""
+	bestDistance := min(max(len(key)/3, 1), maxSuggestionDistance) + 1
+	for name := range fields {
+		distance := levenshteinDistance(strings.ToLower(key), strings.ToLower(name))
+		if distance < bestDistance || (distance == bestDistance && name < suggestion) {
+			suggestion = name
+			bestDistance = distance
+		}
+	}
+	return suggestion
+}
+
+func levenshteinDistance(first, second string) int {
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+func joinFieldPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,184 @@
+package moduleconfigreader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Violation is a single validation failure of a module config field.
+// Line and Column refer to the module config file and are 0 if the position is unknown.
+type Violation struct {
+	FieldPath string
+	Line      int
+	Column    int
+	Err       error
+}
+
+func (v Violation) Error() string {
+	if v.Line > 0 {
+		return fmt.Sprintf("%s (line %d, column %d): %v", v.FieldPath, v.Line, v.Column, v.Err)
+	}
+	return fmt.Sprintf("%s: %v", v.FieldPath, v.Err)
+}
+
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// ValidationError is returned when a module config has one or more violations.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("module config has %d violation(s):", len(e.Violations)))
+	for _, violation := range e.Violations {
+		builder.WriteString("\n  - ")
+		builder.WriteString(violation.Error())
+	}
+	return builder.String()
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		errs = append(errs, violation)
+	}
+	return errs
+}
+
+// ValidationResult collects the violations of a module config.
+// If the YAML document the config was decoded from is known, violations are annotated with their source position.
+type ValidationResult struct {
+	document   *yaml.Node
+	violations []Violation
+}
+
+func NewValidationResult(document *yaml.Node) *ValidationResult {
+	return &ValidationResult{document: document}
+}
+
+// Add records a violation for the given field path, e.g. "manager.namespace", "associatedResources[1].kind" or
+// "icons[module-icon].link" where the bracket either holds a list index or the name of a name/link entry.
+func (r *ValidationResult) Add(fieldPath string, err error) {
+	violation := Violation{FieldPath: fieldPath, Err: err}
+	if node := lookupNode(r.document, fieldPath); node != nil {
+		violation.Line = node.Line
+		violation.Column = node.Column
+	}
+	r.violations = append(r.violations, violation)
+}
+
+// addAt records a violation at the position of the given node.
+func (r *ValidationResult) addAt(fieldPath string, node *yaml.Node, err error) {
+	r.violations = append(r.violations, Violation{
+		FieldPath: fieldPath,
+		Line:      node.Line,
+		Column:    node.Column,
+		Err:       err,
+	})
+}
+
+func (r *ValidationResult) Violations() []Violation {
+	return r.violations
+}
+
+// Err returns a *ValidationError if any violation was recorded, nil otherwise.
+func (r *ValidationResult) Err() error {
+	if len(r.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: r.violations}
+}
+
+// lookupNode returns the deepest node of the document that matches the field path.
+// It returns nil if not even the first path segment can be found.
+func lookupNode(document *yaml.Node, fieldPath string) *yaml.Node {
+	if document == nil {
+		return nil
+	}
+
+	node := document
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	var found *yaml.Node
+	for _, segment := range splitFieldPath(fieldPath) {
+		next := childNode(node, segment)
+		if next == nil {
+			break
+		}
+		node = next
+		found = next
+	}
+
+	return found
+}
+
+func childNode(node *yaml.Node, segment string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i+1]
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(segment); err == nil {
+			if index >= 0 && index < len(node.Content) {
+				return node.Content[index]
+			}
+			return nil
+		}
+		// name/link entries, e.g. icons or resources, are addressed by their name
+		for _, item := range node.Content {
+			if name := childNode(item, "name"); name != nil && name.Value == segment {
+				return item
+			}
+		}
+	default:
+	}
+
+	return nil
+}
+
+// splitFieldPath splits "a.b[c.d].e" into "a", "b", "c.d" and "e".
+func splitFieldPath(fieldPath string) []string {
+	var segments []string
+	var current strings.Builder
+	inBrackets := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, char := range fieldPath {
+		switch {
+		case char == '[' && !inBrackets:
+			flush()
+			inBrackets = true
+		case char == ']' && inBrackets:
+			segments = append(segments, current.String())
+			current.Reset()
+			inBrackets = false
+		case char == '.' && !inBrackets:
+			flush()
+		default:
+			current.WriteRune(char)
+		}
+	}
+	flush()
+
+	return segments
+}
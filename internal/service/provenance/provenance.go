@@ -0,0 +1,171 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+const (
+	ModulectlVersionKey = common.ProvenanceBaseLabelKey + "/modulectl-version"
+	GitRepositoryKey    = common.ProvenanceBaseLabelKey + "/git-repository"
+	GitCommitKey        = common.ProvenanceBaseLabelKey + "/git-commit"
+	GitTagsKey          = common.ProvenanceBaseLabelKey + "/git-tags"
+	CommitTimeKey       = common.ProvenanceBaseLabelKey + "/commit-time"
+	digestKeySuffix     = "-digest"
+
+	unknownModulectlVersion = "unknown"
+	sha256Algorithm         = "sha256"
+)
+
+type GitService interface {
+	GetLatestCommit(gitRepoPath string) (string, error)
+	GetLatestCommitTime(gitRepoPath string) (time.Time, error)
+	GetTagsOfLatestCommit(gitRepoPath string) ([]string, error)
+}
+
+type FileSystem interface {
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path, content string) error
+}
+
+// Provenance describes which modulectl version built a module from which sources.
+type Provenance struct {
+	ModuleName       string
+	ModuleVersion    string
+	ModulectlVersion string
+	GitRepository    string
+	GitCommit        string
+	GitTags          []string
+	CommitTime       time.Time
+	// ResourceDigests maps the names of the module resources, e.g. the raw manifest, to their sha256 digests.
+	ResourceDigests map[string]string
+}
+
+// Label is a provenance entry, added as label to the component and as annotation to the ModuleTemplate.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Labels returns the provenance entries in a stable order. Git tags and resource digests are only included if set.
+func (p *Provenance) Labels() []Label {
+	labels := []Label{
+		{ModulectlVersionKey, p.ModulectlVersion},
+		{GitRepositoryKey, p.GitRepository},
+		{GitCommitKey, p.GitCommit},
+	}
+	if len(p.GitTags) > 0 {
+		labels = append(labels, Label{GitTagsKey, strings.Join(p.GitTags, ",")})
+	}
+	labels = append(labels, Label{CommitTimeKey, p.CommitTime.UTC().Format(time.RFC3339)})
+	for _, name := range slices.Sorted(maps.Keys(p.ResourceDigests)) {
+		labels = append(labels, Label{
+			Name:  common.ProvenanceBaseLabelKey + "/" + name + digestKeySuffix,
+			Value: sha256Algorithm + ":" + p.ResourceDigests[name],
+		})
+	}
+	return labels
+}
+
+// Annotations returns the provenance entries as ModuleTemplate annotations.
+func (p *Provenance) Annotations() map[string]string {
+	annotations := make(map[string]string)
+	for _, label := range p.Labels() {
+		annotations[label.Name] = label.Value
+	}
+	return annotations
+}
+
+type Service struct {
+	modulectlVersion string
+	gitService       GitService
+	fileSystem       FileSystem
+}
+
+// NewService creates the provenance service. An empty modulectlVersion, e.g. of a development build,
+// is recorded as "unknown".
+func NewService(modulectlVersion string, gitService GitService, fileSystem FileSystem) (*Service, error) {
+	if gitService == nil {
+		return nil, fmt.Errorf("gitService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if modulectlVersion == "" {
+		modulectlVersion = unknownModulectlVersion
+	}
+
+	return &Service{
+		modulectlVersion: modulectlVersion,
+		gitService:       gitService,
+		fileSystem:       fileSystem,
+	}, nil
+}
+
+// Collect gathers the provenance of the module from the Git repository and the resolved resource files.
+// The time of the build is not recorded, so that builds of the same commit do not differ.
+func (s *Service) Collect(moduleConfig *contentprovider.ModuleConfig,
+	gitRepoPath string,
+	resourcePaths *types.ResourcePaths,
+) (*Provenance, error) {
+	commit, err := s.gitService.GetLatestCommit(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest commit: %w", err)
+	}
+
+	tags, err := s.gitService.GetTagsOfLatestCommit(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags of latest commit: %w", err)
+	}
+
+	commitTime, err := s.gitService.GetLatestCommitTime(gitRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest commit time: %w", err)
+	}
+
+	resourceDigests := make(map[string]string)
+	for _, resource := range []struct{ name, filePath string }{
+		{common.RawManifestResourceName, resourcePaths.RawManifest},
+		{common.DefaultCRResourceName, resourcePaths.DefaultCR},
+	} {
+		if resource.filePath == "" {
+			continue
+		}
+		if resourceDigests[resource.name], err = s.fileDigest(resource.filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Provenance{
+		ModuleName:       moduleConfig.Name,
+		ModuleVersion:    moduleConfig.Version,
+		ModulectlVersion: s.modulectlVersion,
+		GitRepository:    moduleConfig.Repository,
+		GitCommit:        commit,
+		GitTags:          tags,
+		CommitTime:       commitTime.UTC().Truncate(time.Second),
+		ResourceDigests:  resourceDigests,
+	}, nil
+}
+
+func (s *Service) fileDigest(filePath string) (string, error) {
+	content, err := s.fileSystem.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %q for provenance: %w", filePath, err)
+	}
+
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:]), nil
+}
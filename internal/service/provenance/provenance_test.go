@@ -0,0 +1,284 @@
+package provenance_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+)
+
+const (
+	manifestDigest  = "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" // sha256 of "abc"
+	defaultCRDigest = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" // sha256 of "hello"
+)
+
+func Test_NewService_ReturnsError_WhenGitServiceIsNil(t *testing.T) {
+	_, err := provenance.NewService("1.0.0", nil, &fileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "gitService must not be nil")
+}
+
+func Test_NewService_ReturnsError_WhenFileSystemIsNil(t *testing.T) {
+	_, err := provenance.NewService("1.0.0", &gitServiceStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "fileSystem must not be nil")
+}
+
+func Test_Collect_ReturnsProvenance(t *testing.T) {
+	svc, err := provenance.NewService("1.2.0", &gitServiceStub{tags: []string{"1.0.0", "latest"}}, newFileSystemStub())
+	require.NoError(t, err)
+
+	result, err := svc.Collect(moduleConfig(), "path/to/repo",
+		types.NewResourcePaths("default-cr.yaml", "manifest.yaml", "template.yaml"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "kyma-project.io/module/template-operator", result.ModuleName)
+	assert.Equal(t, "1.0.0", result.ModuleVersion)
+	assert.Equal(t, "1.2.0", result.ModulectlVersion)
+	assert.Equal(t, "https://github.com/kyma-project/template-operator", result.GitRepository)
+	assert.Equal(t, "4c2e3f1d", result.GitCommit)
+	assert.Equal(t, []string{"1.0.0", "latest"}, result.GitTags)
+	assert.Equal(t, map[string]string{
+		"raw-manifest": manifestDigest[len("sha256:"):],
+		"default-cr":   defaultCRDigest[len("sha256:"):],
+	}, result.ResourceDigests)
+}
+
+func Test_Collect_RecordsCommitTime(t *testing.T) {
+	commitTime := time.Date(2024, time.May, 7, 10, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	svc, err := provenance.NewService("1.2.0", &gitServiceStub{commitTime: commitTime}, newFileSystemStub())
+	require.NoError(t, err)
+
+	result, err := svc.Collect(moduleConfig(), "path/to/repo",
+		types.NewResourcePaths("", "manifest.yaml", "template.yaml"))
+
+	require.NoError(t, err)
+	assert.Equal(t, commitTime.UTC(), result.CommitTime)
+	assert.Equal(t, map[string]string{"raw-manifest": manifestDigest[len("sha256:"):]}, result.ResourceDigests)
+}
+
+func Test_Collect_RecordsUnknownVersion_WhenModulectlVersionIsEmpty(t *testing.T) {
+	svc, err := provenance.NewService("", &gitServiceStub{}, newFileSystemStub())
+	require.NoError(t, err)
+
+	result, err := svc.Collect(moduleConfig(), "path/to/repo", types.NewResourcePaths("", "", ""))
+
+	require.NoError(t, err)
+	assert.Equal(t, "unknown", result.ModulectlVersion)
+	assert.Empty(t, result.ResourceDigests)
+}
+
+func Test_Collect_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name          string
+		gitService    provenance.GitService
+		resourcePaths *types.ResourcePaths
+		expectedError string
+	}{
+		{
+			name:          "commit can not be determined",
+			gitService:    &gitServiceStub{commitErr: errGit},
+			resourcePaths: types.NewResourcePaths("", "manifest.yaml", ""),
+			expectedError: "failed to get latest commit",
+		},
+		{
+			name:          "tags can not be determined",
+			gitService:    &gitServiceStub{tagsErr: errGit},
+			resourcePaths: types.NewResourcePaths("", "manifest.yaml", ""),
+			expectedError: "failed to get tags of latest commit",
+		},
+		{
+			name:          "commit time can not be determined",
+			gitService:    &gitServiceStub{commitTimeErr: errGit},
+			resourcePaths: types.NewResourcePaths("", "manifest.yaml", ""),
+			expectedError: "failed to get latest commit time",
+		},
+		{
+			name:          "resource can not be read",
+			gitService:    &gitServiceStub{},
+			resourcePaths: types.NewResourcePaths("missing.yaml", "manifest.yaml", ""),
+			expectedError: `failed to read "missing.yaml" for provenance`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := provenance.NewService("1.2.0", tt.gitService, newFileSystemStub())
+			require.NoError(t, err)
+
+			_, err = svc.Collect(moduleConfig(), "path/to/repo", tt.resourcePaths)
+
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_Labels_ReturnsProvenanceEntriesInStableOrder(t *testing.T) {
+	result := sampleProvenance()
+
+	assert.Equal(t, []provenance.Label{
+		{Name: "provenance.kyma-project.io/modulectl-version", Value: "1.2.0"},
+		{Name: "provenance.kyma-project.io/git-repository", Value: "https://github.com/kyma-project/template-operator"},
+		{Name: "provenance.kyma-project.io/git-commit", Value: "4c2e3f1d"},
+		{Name: "provenance.kyma-project.io/git-tags", Value: "1.0.0,latest"},
+		{Name: "provenance.kyma-project.io/commit-time", Value: "2024-05-07T08:30:00Z"},
+		{Name: "provenance.kyma-project.io/default-cr-digest", Value: defaultCRDigest},
+		{Name: "provenance.kyma-project.io/raw-manifest-digest", Value: manifestDigest},
+	}, result.Labels())
+	assert.Len(t, result.Annotations(), 7)
+	assert.Equal(t, "4c2e3f1d", result.Annotations()[provenance.GitCommitKey])
+}
+
+func Test_Labels_OmitsGitTags_WhenCommitIsNotTagged(t *testing.T) {
+	result := sampleProvenance()
+	result.GitTags = nil
+
+	assert.NotContains(t, result.Annotations(), provenance.GitTagsKey)
+}
+
+func Test_WriteStatement_WritesInTotoStatement(t *testing.T) {
+	fileSystem := newFileSystemStub()
+	svc, err := provenance.NewService("1.2.0", &gitServiceStub{}, fileSystem)
+	require.NoError(t, err)
+
+	err = svc.WriteStatement(sampleProvenance(), []string{"manifest.yaml"}, "provenance.json")
+
+	require.NoError(t, err)
+	var statement provenance.Statement
+	require.NoError(t, json.Unmarshal([]byte(fileSystem.written["provenance.json"]), &statement))
+	assert.Equal(t, provenance.StatementType, statement.Type)
+	assert.Equal(t, provenance.PredicateType, statement.PredicateType)
+	assert.Equal(t, []provenance.ResourceDescriptor{
+		{Name: "manifest.yaml", Digest: map[string]string{"sha256": manifestDigest[len("sha256:"):]}},
+	}, statement.Subject)
+	assert.Equal(t, provenance.BuildType, statement.Predicate.BuildDefinition.BuildType)
+	assert.Equal(t, map[string]string{
+		"module":  "kyma-project.io/module/template-operator",
+		"version": "1.0.0",
+	}, statement.Predicate.BuildDefinition.ExternalParameters)
+	assert.Equal(t, []provenance.ResourceDescriptor{
+		{
+			URI:         "git+https://github.com/kyma-project/template-operator",
+			Digest:      map[string]string{"gitCommit": "4c2e3f1d"},
+			Annotations: map[string]string{"commitTime": "2024-05-07T08:30:00Z", "tags": "1.0.0,latest"},
+		},
+		{Name: "default-cr", Digest: map[string]string{"sha256": defaultCRDigest[len("sha256:"):]}},
+		{Name: "raw-manifest", Digest: map[string]string{"sha256": manifestDigest[len("sha256:"):]}},
+	}, statement.Predicate.BuildDefinition.ResolvedDependencies)
+	assert.Equal(t, provenance.BuilderID, statement.Predicate.RunDetails.Builder.ID)
+	assert.Equal(t, map[string]string{"modulectl": "1.2.0"}, statement.Predicate.RunDetails.Builder.Version)
+}
+
+func Test_WriteStatement_ReturnsError_WhenArtifactCanNotBeRead(t *testing.T) {
+	svc, err := provenance.NewService("1.2.0", &gitServiceStub{}, newFileSystemStub())
+	require.NoError(t, err)
+
+	err = svc.WriteStatement(sampleProvenance(), []string{"missing.yaml"}, "provenance.json")
+
+	require.ErrorContains(t, err, `failed to read "missing.yaml" for provenance`)
+}
+
+func Test_WriteStatement_ReturnsError_WhenStatementCanNotBeWritten(t *testing.T) {
+	fileSystem := newFileSystemStub()
+	fileSystem.writeErr = errWrite
+	svc, err := provenance.NewService("1.2.0", &gitServiceStub{}, fileSystem)
+	require.NoError(t, err)
+
+	err = svc.WriteStatement(sampleProvenance(), []string{"manifest.yaml"}, "provenance.json")
+
+	require.ErrorIs(t, err, errWrite)
+	require.ErrorContains(t, err, "failed to write provenance statement")
+}
+
+func moduleConfig() *contentprovider.ModuleConfig {
+	return &contentprovider.ModuleConfig{
+		Name:       "kyma-project.io/module/template-operator",
+		Version:    "1.0.0",
+		Repository: "https://github.com/kyma-project/template-operator",
+	}
+}
+
+func sampleProvenance() *provenance.Provenance {
+	return &provenance.Provenance{
+		ModuleName:       "kyma-project.io/module/template-operator",
+		ModuleVersion:    "1.0.0",
+		ModulectlVersion: "1.2.0",
+		GitRepository:    "https://github.com/kyma-project/template-operator",
+		GitCommit:        "4c2e3f1d",
+		GitTags:          []string{"1.0.0", "latest"},
+		CommitTime:       time.Date(2024, time.May, 7, 8, 30, 0, 0, time.UTC),
+		ResourceDigests: map[string]string{
+			"raw-manifest": manifestDigest[len("sha256:"):],
+			"default-cr":   defaultCRDigest[len("sha256:"):],
+		},
+	}
+}
+
+// Test Stubs
+
+var (
+	errGit   = errors.New("git error")
+	errRead  = errors.New("file does not exist")
+	errWrite = errors.New("write error")
+)
+
+type gitServiceStub struct {
+	tags          []string
+	commitTime    time.Time
+	commitErr     error
+	commitTimeErr error
+	tagsErr       error
+}
+
+func (s *gitServiceStub) GetLatestCommit(_ string) (string, error) {
+	return "4c2e3f1d", s.commitErr
+}
+
+func (s *gitServiceStub) GetLatestCommitTime(_ string) (time.Time, error) {
+	return s.commitTime, s.commitTimeErr
+}
+
+func (s *gitServiceStub) GetTagsOfLatestCommit(_ string) ([]string, error) {
+	return s.tags, s.tagsErr
+}
+
+type fileSystemStub struct {
+	files    map[string]string
+	written  map[string]string
+	writeErr error
+}
+
+func newFileSystemStub() *fileSystemStub {
+	return &fileSystemStub{
+		files: map[string]string{
+			"manifest.yaml":   "abc",
+			"default-cr.yaml": "hello",
+		},
+		written: map[string]string{},
+	}
+}
+
+func (s *fileSystemStub) ReadFile(path string) ([]byte, error) {
+	content, found := s.files[path]
+	if !found {
+		return nil, errRead
+	}
+	return []byte(content), nil
+}
+
+func (s *fileSystemStub) WriteFile(path, content string) error {
+	if s.writeErr != nil {
+		return s.writeErr
+	}
+	s.written[path] = content
+	return nil
+}
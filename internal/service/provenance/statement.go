@@ -0,0 +1,122 @@
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://slsa.dev/provenance/v1"
+	BuildType     = "https://github.com/kyma-project/modulectl/create/v1"
+	BuilderID     = "https://github.com/kyma-project/modulectl"
+
+	gitCommitAlgorithm = "gitCommit"
+)
+
+// Statement is an in-toto statement with a SLSA provenance predicate.
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Predicate            `json:"predicate"`
+}
+
+type ResourceDescriptor struct {
+	Name        string            `json:"name,omitempty"`
+	URI         string            `json:"uri,omitempty"`
+	Digest      map[string]string `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   map[string]string    `json:"externalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies"`
+}
+
+type RunDetails struct {
+	Builder Builder `json:"builder"`
+}
+
+type Builder struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version"`
+}
+
+// WriteStatement writes the in-toto provenance statement covering the generated artifact files to statementOutput.
+func (s *Service) WriteStatement(provenance *Provenance, artifacts []string, statementOutput string) error {
+	subjects := make([]ResourceDescriptor, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		digest, err := s.fileDigest(artifact)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, ResourceDescriptor{
+			Name:   artifact,
+			Digest: map[string]string{sha256Algorithm: digest},
+		})
+	}
+
+	content, err := json.MarshalIndent(NewStatement(provenance, subjects), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal provenance statement: %w", err)
+	}
+
+	if err = s.fileSystem.WriteFile(statementOutput, string(content)+"\n"); err != nil {
+		return fmt.Errorf("failed to write provenance statement: %w", err)
+	}
+	return nil
+}
+
+// NewStatement returns the in-toto statement of the provenance for the given subjects.
+func NewStatement(provenance *Provenance, subjects []ResourceDescriptor) *Statement {
+	// The time of the build is not recorded, so the commit time is annotated to the source instead of a start time.
+	source := ResourceDescriptor{
+		URI:         "git+" + provenance.GitRepository,
+		Digest:      map[string]string{gitCommitAlgorithm: provenance.GitCommit},
+		Annotations: map[string]string{"commitTime": provenance.CommitTime.UTC().Format(time.RFC3339)},
+	}
+	if len(provenance.GitTags) > 0 {
+		source.Annotations["tags"] = strings.Join(provenance.GitTags, ",")
+	}
+
+	dependencies := []ResourceDescriptor{source}
+	for _, name := range slices.Sorted(maps.Keys(provenance.ResourceDigests)) {
+		dependencies = append(dependencies, ResourceDescriptor{
+			Name:   name,
+			Digest: map[string]string{sha256Algorithm: provenance.ResourceDigests[name]},
+		})
+	}
+
+	return &Statement{
+		Type:          StatementType,
+		Subject:       subjects,
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: map[string]string{
+					"module":  provenance.ModuleName,
+					"version": provenance.ModuleVersion,
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{
+					ID:      BuilderID,
+					Version: map[string]string{"modulectl": provenance.ModulectlVersion},
+				},
+			},
+		},
+	}
+}
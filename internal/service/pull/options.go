@@ -0,0 +1,38 @@
+package pull
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type Options struct {
+	Out                iotools.Out
+	Component          string
+	OutputDirectory    string
+	ConfigFile         string
+	AllowUnknownFields bool
+	types.RegistryAccess
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if _, _, err := types.SplitComponent(opts.Component); err != nil {
+		return err
+	}
+
+	if err := opts.RegistryAccess.Validate(); err != nil {
+		return err
+	}
+
+	if opts.OutputDirectory == "" {
+		return fmt.Errorf("opts.OutputDirectory must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package pull
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+
+	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+)
+
+const (
+	DescriptorFileName     = "component-descriptor.yaml"
+	ModuleTemplateFileName = "template.yaml"
+	resourceFileExtension  = ".yaml"
+)
+
+var (
+	ErrComponentMismatch = errors.New("module config does not match the component version")
+	ErrMissingResource   = errors.New("component version has no resource")
+	ErrInvalidResource   = errors.New("resource name is not a valid file name")
+	errEmptyArchive      = errors.New("archive does not contain a file")
+)
+
+type RegistryService interface {
+	PullComponentVersion(name, version string, insecure bool, userPasswordCreds, registryURL string,
+	) (*compdesc.ComponentDescriptor, map[string][]byte, error)
+}
+
+type CredentialService interface {
+	UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error)
+	ConfigureTargetRegistry(config credential.TargetRegistryConfig) error
+}
+
+type ModuleConfigService interface {
+	ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool) (*contentprovider.ModuleConfig, error)
+}
+
+type ModuleTemplateService interface {
+	GenerateModuleTemplate(moduleConfig *contentprovider.ModuleConfig,
+		descriptorToRender *compdesc.ComponentDescriptor,
+		data []byte,
+		isCrdClusterScoped bool,
+		provenanceAnnotations map[string]string,
+		templateOutput string,
+		reproducible bool,
+	) error
+}
+
+type CRDParserService interface {
+	IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error)
+}
+
+type FileSystem interface {
+	MkdirAll(path string) error
+	WriteFile(path, content string) error
+}
+
+// Service downloads a published component version of a module and regenerates its ModuleTemplate.
+type Service struct {
+	registryService       RegistryService
+	credentialService     CredentialService
+	moduleConfigService   ModuleConfigService
+	moduleTemplateService ModuleTemplateService
+	crdParserService      CRDParserService
+	fileSystem            FileSystem
+}
+
+func NewService(registryService RegistryService,
+	credentialService CredentialService,
+	moduleConfigService ModuleConfigService,
+	moduleTemplateService ModuleTemplateService,
+	crdParserService CRDParserService,
+	fileSystem FileSystem,
+) (*Service, error) {
+	if registryService == nil {
+		return nil, fmt.Errorf("registryService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if credentialService == nil {
+		return nil, fmt.Errorf("credentialService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if moduleConfigService == nil {
+		return nil, fmt.Errorf("moduleConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if moduleTemplateService == nil {
+		return nil, fmt.Errorf("moduleTemplateService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if crdParserService == nil {
+		return nil, fmt.Errorf("crdParserService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		registryService:       registryService,
+		credentialService:     credentialService,
+		moduleConfigService:   moduleConfigService,
+		moduleTemplateService: moduleTemplateService,
+		crdParserService:      crdParserService,
+		fileSystem:            fileSystem,
+	}, nil
+}
+
+// Run writes the component descriptor and the files of the local resources, e.g. the raw manifest and the
+// default CR, to the output directory. If a module config file is given, the ModuleTemplate is generated from
+// the module config and the downloaded component version.
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	name, version, err := types.SplitComponent(opts.Component)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := s.credentialService.UserPasswordCredentials(opts.Credentials, opts.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+
+	if err = s.credentialService.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    opts.RegistryURL,
+		TokenFile:      opts.RegistryTokenFile,
+		CAFile:         opts.RegistryCAFile,
+		ClientCertFile: opts.RegistryClientCert,
+		ClientKeyFile:  opts.RegistryClientKey,
+	}); err != nil {
+		return fmt.Errorf("failed to configure target registry: %w", err)
+	}
+
+	opts.Out.Write(fmt.Sprintf("- Pulling component %s in version %s\n", name, version))
+	descriptor, blobs, err := s.registryService.PullComponentVersion(name, version, opts.Insecure, credentials,
+		opts.RegistryURL)
+	if err != nil {
+		return fmt.Errorf("failed to pull component version: %w", err)
+	}
+
+	if err = s.fileSystem.MkdirAll(opts.OutputDirectory); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if err = s.writeDescriptor(descriptor, opts); err != nil {
+		return err
+	}
+
+	resources, err := s.writeResources(blobs, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.ConfigFile == "" {
+		return nil
+	}
+
+	opts.Out.Write("- Generating module template\n")
+	if err = s.generateModuleTemplate(descriptor, resources, opts); err != nil {
+		return fmt.Errorf("failed to generate module template: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) writeDescriptor(descriptor *compdesc.ComponentDescriptor, opts Options) error {
+	content, err := compdesc.Encode(descriptor, compdesc.DefaultYAMLCodec)
+	if err != nil {
+		return fmt.Errorf("failed to encode component descriptor: %w", err)
+	}
+
+	descriptorFile := filepath.Join(opts.OutputDirectory, DescriptorFileName)
+	opts.Out.Write(fmt.Sprintf("- Writing component descriptor to %s\n", descriptorFile))
+	if err = s.fileSystem.WriteFile(descriptorFile, string(content)); err != nil {
+		return fmt.Errorf("failed to write component descriptor: %w", err)
+	}
+	return nil
+}
+
+type resourceFile struct {
+	path    string
+	content []byte
+}
+
+// writeResources extracts the file archived in every local resource blob and writes it to the output directory,
+// named after the resource. Blobs that are no tar archive are written as they are.
+func (s *Service) writeResources(blobs map[string][]byte, opts Options) (map[string]resourceFile, error) {
+	resources := make(map[string]resourceFile, len(blobs))
+	for _, name := range slices.Sorted(maps.Keys(blobs)) {
+		// the name comes from the registry, so it must not point outside of the output directory
+		if !isFileName(name) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidResource, name)
+		}
+
+		content, err := extractFile(blobs[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract resource %s: %w", name, err)
+		}
+
+		resourcePath := filepath.Join(opts.OutputDirectory, name+resourceFileExtension)
+		opts.Out.Write(fmt.Sprintf("- Writing resource %s to %s\n", name, resourcePath))
+		if err = s.fileSystem.WriteFile(resourcePath, string(content)); err != nil {
+			return nil, fmt.Errorf("failed to write resource %s: %w", name, err)
+		}
+		resources[name] = resourceFile{path: resourcePath, content: content}
+	}
+	return resources, nil
+}
+
+func (s *Service) generateModuleTemplate(descriptor *compdesc.ComponentDescriptor,
+	resources map[string]resourceFile,
+	opts Options,
+) error {
+	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile, opts.AllowUnknownFields)
+	if err != nil {
+		return fmt.Errorf("failed to parse module config: %w", err)
+	}
+
+	if moduleConfig.Name != descriptor.GetName() || moduleConfig.Version != descriptor.GetVersion() {
+		return fmt.Errorf("%w: module config is for %s:%s, component version is %s:%s", ErrComponentMismatch,
+			moduleConfig.Name, moduleConfig.Version, descriptor.GetName(), descriptor.GetVersion())
+	}
+
+	rawManifest, ok := resources[common.RawManifestResourceName]
+	if !ok {
+		return fmt.Errorf("%w %s", ErrMissingResource, common.RawManifestResourceName)
+	}
+	defaultCR := resources[common.DefaultCRResourceName]
+
+	templateOutput := filepath.Join(opts.OutputDirectory, ModuleTemplateFileName)
+	resourcePaths := types.NewResourcePaths(defaultCR.path, rawManifest.path, templateOutput)
+	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths)
+	if err != nil {
+		return fmt.Errorf("failed to determine if CRD is cluster scoped: %w", err)
+	}
+
+	provenanceAnnotations, err := provenanceAnnotations(descriptor)
+	if err != nil {
+		return err
+	}
+
+	return s.moduleTemplateService.GenerateModuleTemplate(moduleConfig,
+		descriptor,
+		defaultCR.content,
+		isCRDClusterScoped,
+		provenanceAnnotations,
+		templateOutput,
+		false)
+}
+
+// provenanceAnnotations returns the provenance labels of the component, which create adds as annotations to the
+// ModuleTemplate as well.
+func provenanceAnnotations(descriptor *compdesc.ComponentDescriptor) (map[string]string, error) {
+	annotations := make(map[string]string)
+	for _, label := range descriptor.Labels {
+		if !strings.HasPrefix(label.Name, common.ProvenanceBaseLabelKey+"/") {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(label.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to parse provenance label %s: %w", label.Name, err)
+		}
+		annotations[label.Name] = value
+	}
+	return annotations, nil
+}
+
+// isFileName checks that the name is a single path element, so that it can be used as file name.
+func isFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`) &&
+		filepath.Base(name) == name
+}
+
+// extractFile returns the content of the first file of a tar archive. A blob that does not start with a tar
+// header, e.g. a plain YAML file, is returned as it is.
+func extractFile(archive []byte) ([]byte, error) {
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for isFirst := true; ; isFirst = false {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, errEmptyArchive
+		}
+		if err != nil && isFirst {
+			return archive, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", header.Name, err)
+		}
+		return content, nil
+	}
+}
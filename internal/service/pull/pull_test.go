@@ -0,0 +1,281 @@
+package pull_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/compdesc"
+
+	"github.com/kyma-project/modulectl/internal/common"
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/provenance"
+	"github.com/kyma-project/modulectl/internal/service/pull"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const (
+	moduleName    = "kyma-project.io/module/template-operator"
+	moduleVersion = "1.0.0"
+	rawManifest   = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: template-operator\n"
+	defaultCR     = "apiVersion: operator.kyma-project.io/v1alpha1\nkind: Sample\n"
+)
+
+func Test_NewService_ReturnsError_WhenRegistryServiceIsNil(t *testing.T) {
+	_, err := pull.NewService(nil, &credentialServiceStub{}, &moduleConfigServiceStub{},
+		&moduleTemplateServiceStub{}, &crdParserServiceStub{}, &fileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "registryService")
+}
+
+func Test_NewService_ReturnsError_WhenFileSystemIsNil(t *testing.T) {
+	_, err := pull.NewService(&registryServiceStub{}, &credentialServiceStub{}, &moduleConfigServiceStub{},
+		&moduleTemplateServiceStub{}, &crdParserServiceStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "fileSystem")
+}
+
+func Test_Run_ReturnsError_WhenComponentIsInvalid(t *testing.T) {
+	svc := newService(t, &registryServiceStub{}, &moduleTemplateServiceStub{}, &fileSystemStub{})
+
+	err := svc.Run(newOptions(func(opts *pull.Options) { opts.Component = moduleName }))
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "<name>:<version>")
+}
+
+func Test_Run_WritesDescriptorAndResources(t *testing.T) {
+	registryService := newRegistryServiceStub(t)
+	fileSystem := &fileSystemStub{}
+	moduleTemplateService := &moduleTemplateServiceStub{}
+	svc := newService(t, registryService, moduleTemplateService, fileSystem)
+
+	err := svc.Run(newOptions())
+
+	require.NoError(t, err)
+	assert.Equal(t, moduleName, registryService.name)
+	assert.Equal(t, moduleVersion, registryService.version)
+	assert.Equal(t, "user:password", registryService.credentials)
+	assert.Equal(t, "out", fileSystem.directory)
+	assert.Contains(t, fileSystem.files[filepath.Join("out", pull.DescriptorFileName)], "name: "+moduleName)
+	assert.Equal(t, rawManifest, fileSystem.files[filepath.Join("out", "raw-manifest.yaml")])
+	assert.Equal(t, defaultCR, fileSystem.files[filepath.Join("out", "default-cr.yaml")])
+	assert.False(t, moduleTemplateService.called)
+}
+
+func Test_Run_GeneratesModuleTemplate_WhenConfigFileIsSet(t *testing.T) {
+	moduleTemplateService := &moduleTemplateServiceStub{}
+	svc := newService(t, newRegistryServiceStub(t), moduleTemplateService, &fileSystemStub{})
+
+	err := svc.Run(newOptions(func(opts *pull.Options) { opts.ConfigFile = "module-config.yaml" }))
+
+	require.NoError(t, err)
+	require.True(t, moduleTemplateService.called)
+	assert.Equal(t, moduleName, moduleTemplateService.descriptor.GetName())
+	assert.Equal(t, defaultCR, string(moduleTemplateService.data))
+	assert.Equal(t, map[string]string{"provenance.kyma-project.io/git-commit": "abc123"},
+		moduleTemplateService.provenanceAnnotations)
+	assert.Equal(t, filepath.Join("out", pull.ModuleTemplateFileName), moduleTemplateService.templateOutput)
+}
+
+func Test_Run_ReturnsError_WhenModuleConfigDoesNotMatchComponentVersion(t *testing.T) {
+	registryService := newRegistryServiceStub(t)
+	registryService.descriptor.SetVersion("2.0.0")
+	svc := newService(t, registryService, &moduleTemplateServiceStub{}, &fileSystemStub{})
+
+	err := svc.Run(newOptions(func(opts *pull.Options) { opts.ConfigFile = "module-config.yaml" }))
+
+	require.ErrorIs(t, err, pull.ErrComponentMismatch)
+}
+
+func Test_Run_ReturnsError_WhenPullFails(t *testing.T) {
+	svc := newService(t, &registryServiceStub{err: errors.New("not found")}, &moduleTemplateServiceStub{},
+		&fileSystemStub{})
+
+	err := svc.Run(newOptions())
+
+	require.ErrorContains(t, err, "failed to pull component version")
+}
+
+func Test_Run_WritesBlob_WhenResourceIsNoArchive(t *testing.T) {
+	registryService := newRegistryServiceStub(t)
+	registryService.blobs[common.RawManifestResourceName] = []byte(rawManifest)
+	fileSystem := &fileSystemStub{}
+	svc := newService(t, registryService, &moduleTemplateServiceStub{}, fileSystem)
+
+	err := svc.Run(newOptions())
+
+	require.NoError(t, err)
+	assert.Equal(t, rawManifest, fileSystem.files[filepath.Join("out", "raw-manifest.yaml")])
+}
+
+func Test_Run_ReturnsError_WhenResourceNameIsNoFileName(t *testing.T) {
+	for _, name := range []string{"../raw-manifest", "nested/raw-manifest", "..", `nested\raw-manifest`} {
+		t.Run(name, func(t *testing.T) {
+			registryService := newRegistryServiceStub(t)
+			registryService.blobs[name] = archive(t, "manifest.yaml", rawManifest)
+			fileSystem := &fileSystemStub{}
+			svc := newService(t, registryService, &moduleTemplateServiceStub{}, fileSystem)
+
+			err := svc.Run(newOptions())
+
+			require.ErrorIs(t, err, pull.ErrInvalidResource)
+			assert.NotContains(t, fileSystem.files, filepath.Join("out", name+".yaml"))
+		})
+	}
+}
+
+func newService(t *testing.T, registryService pull.RegistryService, moduleTemplateService pull.ModuleTemplateService,
+	fileSystem pull.FileSystem,
+) *pull.Service {
+	t.Helper()
+	svc, err := pull.NewService(registryService, &credentialServiceStub{}, &moduleConfigServiceStub{},
+		moduleTemplateService, &crdParserServiceStub{}, fileSystem)
+	require.NoError(t, err)
+	return svc
+}
+
+func newOptions(modifiers ...func(opts *pull.Options)) pull.Options {
+	opts := pull.Options{
+		Out:             iotools.NewDefaultOut(io.Discard),
+		Component:       moduleName + ":" + moduleVersion,
+		OutputDirectory: "out",
+		RegistryAccess: types.RegistryAccess{
+			RegistryURL: "https://registry.kyma.cx",
+			Credentials: "user:password",
+		},
+	}
+	for _, modify := range modifiers {
+		modify(&opts)
+	}
+	return opts
+}
+
+func newRegistryServiceStub(t *testing.T) *registryServiceStub {
+	t.Helper()
+	descriptor, err := componentdescriptor.InitializeComponentDescriptor(moduleName, moduleVersion, false)
+	require.NoError(t, err)
+	require.NoError(t, componentdescriptor.AddProvenanceLabels(descriptor, []provenance.Label{
+		{Name: "provenance.kyma-project.io/git-commit", Value: "abc123"},
+	}))
+
+	return &registryServiceStub{
+		descriptor: descriptor,
+		blobs: map[string][]byte{
+			common.RawManifestResourceName: archive(t, "manifest.yaml", rawManifest),
+			common.DefaultCRResourceName:   archive(t, "default-cr.yaml", defaultCR),
+		},
+	}
+}
+
+func archive(t *testing.T, name, content string) []byte {
+	t.Helper()
+	buffer := &bytes.Buffer{}
+	writer := tar.NewWriter(buffer)
+	require.NoError(t, writer.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err := writer.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buffer.Bytes()
+}
+
+// Test Stubs
+
+type registryServiceStub struct {
+	descriptor  *compdesc.ComponentDescriptor
+	blobs       map[string][]byte
+	err         error
+	name        string
+	version     string
+	credentials string
+}
+
+func (s *registryServiceStub) PullComponentVersion(name, version string, _ bool, credentials, _ string,
+) (*compdesc.ComponentDescriptor, map[string][]byte, error) {
+	s.name, s.version, s.credentials = name, version, credentials
+	return s.descriptor, s.blobs, s.err
+}
+
+type credentialServiceStub struct{}
+
+func (*credentialServiceStub) UserPasswordCredentials(flagCredentials, _ string) (string, error) {
+	return flagCredentials, nil
+}
+
+func (*credentialServiceStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return nil
+}
+
+type moduleConfigServiceStub struct{}
+
+func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:    moduleName,
+		Version: moduleVersion,
+	}, nil
+}
+
+type moduleTemplateServiceStub struct {
+	called                bool
+	descriptor            *compdesc.ComponentDescriptor
+	data                  []byte
+	provenanceAnnotations map[string]string
+	templateOutput        string
+}
+
+func (s *moduleTemplateServiceStub) GenerateModuleTemplate(_ *contentprovider.ModuleConfig,
+	descriptor *compdesc.ComponentDescriptor,
+	data []byte,
+	_ bool,
+	provenanceAnnotations map[string]string,
+	templateOutput string,
+	_ bool,
+) error {
+	s.called = true
+	s.descriptor = descriptor
+	s.data = data
+	s.provenanceAnnotations = provenanceAnnotations
+	s.templateOutput = templateOutput
+	return nil
+}
+
+type crdParserServiceStub struct{}
+
+func (*crdParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+	return false, nil
+}
+
+type fileSystemStub struct {
+	directory string
+	files     map[string]string
+}
+
+func (s *fileSystemStub) MkdirAll(path string) error {
+	s.directory = path
+	return nil
+}
+
+func (s *fileSystemStub) WriteFile(path, content string) error {
+	if s.files == nil {
+		s.files = make(map[string]string)
+	}
+	s.files[path] = content
+	return nil
+}
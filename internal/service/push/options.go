@@ -0,0 +1,49 @@
+package push
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type Options struct {
+	Out                       iotools.Out
+	ArchivePath               string
+	OverwriteComponentVersion bool
+	CopyResources             bool
+	SigningKeyFile            string
+	SigningCertificateFile    string
+	SignatureName             string
+	types.RegistryAccess
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.ArchivePath == "" {
+		return fmt.Errorf("opts.ArchivePath must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if err := opts.RegistryAccess.Validate(); err != nil {
+		return err
+	}
+
+	if opts.SigningCertificateFile != "" && opts.SigningKeyFile == "" {
+		return fmt.Errorf("opts.SigningCertificateFile must not be set without opts.SigningKeyFile: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if opts.SigningKeyFile != "" && opts.SignatureName == "" {
+		return fmt.Errorf("opts.SignatureName must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.OverwriteComponentVersion {
+		opts.Out.Write("Warning: overwrite flag is set to true. This should ONLY be used for testing purposes.\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/cpi"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+)
+
+var ErrEmptyArchive = errors.New("archive does not contain a component version")
+
+type RegistryService interface {
+	PushArchive(archivePath string, insecure, overwrite, copyResources bool, credentials, registryURL string,
+		sign func(componentVersion cpi.ComponentVersionAccess) error,
+	) ([]string, error)
+}
+
+type CredentialService interface {
+	UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error)
+	ConfigureTargetRegistry(config credential.TargetRegistryConfig) error
+}
+
+type SignatureService interface {
+	Sign(componentVersion cpi.ComponentVersionAccess, config signature.SigningConfig,
+	) (*compdesc.ComponentDescriptor, error)
+}
+
+// Service pushes the component versions of an archive written by the create command to a registry.
+type Service struct {
+	registryService   RegistryService
+	credentialService CredentialService
+	signatureService  SignatureService
+}
+
+func NewService(registryService RegistryService,
+	credentialService CredentialService,
+	signatureService SignatureService,
+) (*Service, error) {
+	if registryService == nil {
+		return nil, fmt.Errorf("registryService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if credentialService == nil {
+		return nil, fmt.Errorf("credentialService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if signatureService == nil {
+		return nil, fmt.Errorf("signatureService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		registryService:   registryService,
+		credentialService: credentialService,
+		signatureService:  signatureService,
+	}, nil
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	credentials, err := s.credentialService.UserPasswordCredentials(opts.Credentials, opts.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+
+	if err = s.credentialService.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    opts.RegistryURL,
+		TokenFile:      opts.RegistryTokenFile,
+		CAFile:         opts.RegistryCAFile,
+		ClientCertFile: opts.RegistryClientCert,
+		ClientKeyFile:  opts.RegistryClientKey,
+	}); err != nil {
+		return fmt.Errorf("failed to configure target registry: %w", err)
+	}
+
+	opts.Out.Write(fmt.Sprintf("- Pushing archive %s\n", opts.ArchivePath))
+	if opts.CopyResources {
+		opts.Out.Write("\tCopying the OCI artifacts referenced by the resources\n")
+	}
+	pushed, err := s.registryService.PushArchive(opts.ArchivePath, opts.Insecure, opts.OverwriteComponentVersion,
+		opts.CopyResources, credentials, opts.RegistryURL, s.signFunc(opts))
+	for _, component := range pushed {
+		opts.Out.Write(fmt.Sprintf("\tPushed component version %s\n", component))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to push archive: %w", err)
+	}
+
+	if len(pushed) == 0 {
+		return fmt.Errorf("%s: %w", opts.ArchivePath, ErrEmptyArchive)
+	}
+	return nil
+}
+
+// signFunc returns the function signing every component version before it is pushed, nil if no signing key is set.
+func (s *Service) signFunc(opts Options) func(componentVersion cpi.ComponentVersionAccess) error {
+	if opts.SigningKeyFile == "" {
+		return nil
+	}
+
+	config := signature.SigningConfig{
+		Name:            opts.SignatureName,
+		PrivateKeyFile:  opts.SigningKeyFile,
+		CertificateFile: opts.SigningCertificateFile,
+	}
+	opts.Out.Write(fmt.Sprintf("- Signing component versions with signature %s\n", opts.SignatureName))
+	return func(componentVersion cpi.ComponentVersionAccess) error {
+		if _, err := s.signatureService.Sign(componentVersion, config); err != nil {
+			return fmt.Errorf("failed to sign component version: %w", err)
+		}
+		return nil
+	}
+}
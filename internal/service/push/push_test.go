@@ -0,0 +1,197 @@
+package push_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/cpi"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/push"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const componentVersion = "kyma-project.io/module/template-operator:1.0.0"
+
+func Test_NewService_ReturnsError_WhenRegistryServiceIsNil(t *testing.T) {
+	_, err := push.NewService(nil, &credentialServiceStub{}, &signatureServiceStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "registryService")
+}
+
+func Test_NewService_ReturnsError_WhenCredentialServiceIsNil(t *testing.T) {
+	_, err := push.NewService(&registryServiceStub{}, nil, &signatureServiceStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "credentialService")
+}
+
+func Test_NewService_ReturnsError_WhenSignatureServiceIsNil(t *testing.T) {
+	_, err := push.NewService(&registryServiceStub{}, &credentialServiceStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "signatureService")
+}
+
+func Test_Run_ReturnsError_WhenArchivePathIsEmpty(t *testing.T) {
+	svc, _ := push.NewService(&registryServiceStub{}, &credentialServiceStub{}, &signatureServiceStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *push.Options) { opts.ArchivePath = "" }))
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "opts.ArchivePath")
+}
+
+func Test_Run_PushesArchive(t *testing.T) {
+	registryService := &registryServiceStub{pushed: []string{componentVersion}}
+	svc, _ := push.NewService(registryService, &credentialServiceStub{}, &signatureServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out, func(opts *push.Options) { opts.OverwriteComponentVersion = true }))
+
+	require.NoError(t, err)
+	assert.Equal(t, "template-operator.tgz", registryService.archivePath)
+	assert.Equal(t, "user:password", registryService.credentials)
+	assert.Equal(t, "https://registry.kyma.cx", registryService.registryURL)
+	assert.True(t, registryService.overwrite)
+	assert.False(t, registryService.copyResources)
+	assert.Contains(t, out.String(), "Pushed component version "+componentVersion)
+	assert.False(t, registryService.signed)
+}
+
+func Test_Run_CopiesResources_WhenCopyResourcesIsSet(t *testing.T) {
+	registryService := &registryServiceStub{pushed: []string{componentVersion}}
+	svc, _ := push.NewService(registryService, &credentialServiceStub{}, &signatureServiceStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *push.Options) { opts.CopyResources = true }))
+
+	require.NoError(t, err)
+	assert.True(t, registryService.copyResources)
+}
+
+func Test_Run_SignsComponentVersions_WhenSigningKeyFileIsSet(t *testing.T) {
+	registryService := &registryServiceStub{pushed: []string{componentVersion}}
+	signatureService := &signatureServiceStub{}
+	svc, _ := push.NewService(registryService, &credentialServiceStub{}, signatureService)
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *push.Options) {
+		opts.SigningKeyFile = "private.pem"
+		opts.SigningCertificateFile = "chain.pem"
+		opts.SignatureName = "kyma-module"
+	}))
+
+	require.NoError(t, err)
+	assert.True(t, registryService.signed)
+	assert.Equal(t, signature.SigningConfig{
+		Name:            "kyma-module",
+		PrivateKeyFile:  "private.pem",
+		CertificateFile: "chain.pem",
+	}, signatureService.config)
+}
+
+func Test_Run_ReturnsError_WhenSigningFails(t *testing.T) {
+	registryService := &registryServiceStub{}
+	svc, _ := push.NewService(registryService, &credentialServiceStub{},
+		&signatureServiceStub{err: errors.New("invalid key")})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *push.Options) {
+		opts.SigningKeyFile = "private.pem"
+		opts.SignatureName = "kyma-module"
+	}))
+
+	require.ErrorContains(t, err, "failed to sign component version: invalid key")
+}
+
+func Test_Run_ReturnsError_WhenArchiveIsEmpty(t *testing.T) {
+	svc, _ := push.NewService(&registryServiceStub{}, &credentialServiceStub{}, &signatureServiceStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}))
+
+	require.ErrorIs(t, err, push.ErrEmptyArchive)
+}
+
+func Test_Run_ReturnsError_WhenPushFails(t *testing.T) {
+	registryService := &registryServiceStub{
+		pushed: []string{componentVersion},
+		err:    errors.New("component version already exists"),
+	}
+	svc, _ := push.NewService(registryService, &credentialServiceStub{}, &signatureServiceStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.ErrorContains(t, err, "failed to push archive: component version already exists")
+	assert.Contains(t, out.String(), "Pushed component version "+componentVersion)
+}
+
+func newOptions(out *bytes.Buffer, modifiers ...func(opts *push.Options)) push.Options {
+	opts := push.Options{
+		Out:         iotools.NewDefaultOut(out),
+		ArchivePath: "template-operator.tgz",
+		RegistryAccess: types.RegistryAccess{
+			RegistryURL: "https://registry.kyma.cx",
+			Credentials: "user:password",
+		},
+	}
+	for _, modify := range modifiers {
+		modify(&opts)
+	}
+	return opts
+}
+
+// Test Stubs
+
+type registryServiceStub struct {
+	pushed        []string
+	err           error
+	archivePath   string
+	overwrite     bool
+	copyResources bool
+	credentials   string
+	registryURL   string
+	signed        bool
+}
+
+func (s *registryServiceStub) PushArchive(archivePath string, _, overwrite, copyResources bool,
+	credentials, registryURL string,
+	sign func(componentVersion cpi.ComponentVersionAccess) error,
+) ([]string, error) {
+	s.archivePath, s.overwrite, s.credentials, s.registryURL = archivePath, overwrite, credentials, registryURL
+	s.copyResources = copyResources
+	if sign != nil {
+		s.signed = true
+		if err := sign(nil); err != nil {
+			return nil, err
+		}
+	}
+	return s.pushed, s.err
+}
+
+type credentialServiceStub struct{}
+
+func (*credentialServiceStub) UserPasswordCredentials(flagCredentials, _ string) (string, error) {
+	return flagCredentials, nil
+}
+
+func (*credentialServiceStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return nil
+}
+
+type signatureServiceStub struct {
+	config signature.SigningConfig
+	err    error
+}
+
+func (s *signatureServiceStub) Sign(_ cpi.ComponentVersionAccess, config signature.SigningConfig,
+) (*compdesc.ComponentDescriptor, error) {
+	s.config = config
+	return nil, s.err
+}
@@ -1,30 +1,53 @@
 package registry
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
+	"ocm.software/ocm/api/ocm/compdesc"
+	ocmv1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
 	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
 	"ocm.software/ocm/api/utils/runtime"
 
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/componentdescriptor"
 	"github.com/kyma-project/modulectl/tools/ocirepo"
 )
 
 type OCIRepository interface {
-	GetComponentVersion(archive *comparch.ComponentArchive, repo cpi.Repository) (cpi.ComponentVersionAccess, error)
-	PushComponentVersion(archive *comparch.ComponentArchive, repo cpi.Repository, overwrite bool) error
+	GetComponentVersion(archive ocirepo.ComponentArchiveMeta, repo cpi.Repository) (cpi.ComponentVersionAccess, error)
+	PushComponentVersion(archive cpi.ComponentVersionAccess, repo cpi.Repository, overwrite, copyResources bool) error
 	ExistsComponentVersion(archive ocirepo.ComponentArchiveMeta, repo cpi.Repository) (bool, error)
+	ListComponentVersions(name string, repo cpi.Repository) ([]string, error)
 }
 
+var errNoComponentLister = errors.New("archive does not support listing its components")
+
+const stagingArchivePath = "staging"
+
 type CredResolverFunc func(ctx cpi.Context, userPasswordCreds, registryURL string) (credentials.Credentials, error)
 
 type Service struct {
 	ociRepository OCIRepository
 	repo          cpi.Repository
+	repos         map[string]cpi.Repository
 	credResolver  CredResolverFunc
 }
 
@@ -39,6 +62,7 @@ func NewService(ociRepository OCIRepository, repo cpi.Repository, credResolverFu
 	return &Service{
 		ociRepository: ociRepository,
 		repo:          repo,
+		repos:         make(map[string]cpi.Repository),
 		credResolver:  credResolverFunc,
 	}, nil
 }
@@ -61,7 +85,9 @@ func (s *Service) ExistsComponentVersion(archive *comparch.ComponentArchive,
 	return exists, nil
 }
 
-func (s *Service) PushComponentVersion(archive *comparch.ComponentArchive, insecure, overwrite bool,
+// PushComponentVersion pushes the component version to the registry. If copyResources is set, the OCI artifacts
+// referenced by the resources are copied into the registry as well.
+func (s *Service) PushComponentVersion(archive *comparch.ComponentArchive, insecure, overwrite, copyResources bool,
 	credentials, registryURL string,
 ) error {
 	repo, err := s.getRepository(insecure, credentials, registryURL)
@@ -69,13 +95,149 @@ func (s *Service) PushComponentVersion(archive *comparch.ComponentArchive, insec
 		return fmt.Errorf("could not get repository: %w", err)
 	}
 
-	if err = s.ociRepository.PushComponentVersion(archive, repo, overwrite); err != nil {
+	if err = s.ociRepository.PushComponentVersion(archive, repo, overwrite, copyResources); err != nil {
 		return fmt.Errorf("could not push component version: %w", err)
 	}
 
 	return nil
 }
 
+// ExportComponentVersion writes the component version to the Common Transport Format archive at archivePath,
+// which is a directory or, if the path ends with .tgz or .tar.gz, a gzipped tar file.
+// An existing archive is extended by the component version. If copyResources is set, the OCI artifacts referenced
+// by the resources are copied into the archive as well.
+func (s *Service) ExportComponentVersion(archive *comparch.ComponentArchive, archivePath string,
+	overwrite, copyResources bool,
+) error {
+	archiveRepo, err := openArchive(archivePath, accessobj.ACC_WRITABLE|accessobj.ACC_CREATE)
+	if err != nil {
+		return err
+	}
+
+	if err = s.ociRepository.PushComponentVersion(archive, archiveRepo, overwrite, copyResources); err != nil {
+		archiveRepo.Close()
+		return fmt.Errorf("could not write component version to archive: %w", err)
+	}
+
+	if err = archiveRepo.Close(); err != nil {
+		return fmt.Errorf("could not close archive: %w", err)
+	}
+	return nil
+}
+
+// PushArchive pushes all component versions of the Common Transport Format archive at archivePath to the registry
+// and returns their references in the <name>:<version> format. If sign is set, every component version is signed
+// before it is pushed, the archive itself is not modified. If copyResources is set, the OCI artifacts referenced
+// by the resources are copied into the registry as well.
+func (s *Service) PushArchive(archivePath string, insecure, overwrite, copyResources bool,
+	credentials, registryURL string,
+	sign func(componentVersion cpi.ComponentVersionAccess) error,
+) ([]string, error) {
+	archiveRepo, err := openArchive(archivePath, accessobj.ACC_READONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer archiveRepo.Close()
+
+	lister := archiveRepo.ComponentLister()
+	if lister == nil {
+		return nil, errNoComponentLister
+	}
+	names, err := lister.GetComponents("", true)
+	if err != nil {
+		return nil, fmt.Errorf("could not list components of archive: %w", err)
+	}
+
+	repo, err := s.getRepository(insecure, credentials, registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not get repository: %w", err)
+	}
+
+	var pushed []string
+	for _, name := range names {
+		versions, listErr := s.ociRepository.ListComponentVersions(name, archiveRepo)
+		if listErr != nil {
+			return pushed, fmt.Errorf("could not list versions of component %s: %w", name, listErr)
+		}
+		for _, version := range versions {
+			if err = s.pushArchivedComponentVersion(componentVersionMeta{name, version}, archiveRepo, repo,
+				overwrite, copyResources, sign); err != nil {
+				return pushed, err
+			}
+			pushed = append(pushed, name+":"+version)
+		}
+	}
+
+	return pushed, nil
+}
+
+func (s *Service) pushArchivedComponentVersion(meta componentVersionMeta, archiveRepo, repo cpi.Repository,
+	overwrite, copyResources bool,
+	sign func(componentVersion cpi.ComponentVersionAccess) error,
+) error {
+	componentVersion, err := s.ociRepository.GetComponentVersion(meta, archiveRepo)
+	if err != nil {
+		return fmt.Errorf("could not get component version %s:%s from archive: %w", meta.name, meta.version, err)
+	}
+	defer componentVersion.Close()
+
+	if sign != nil {
+		return s.pushSignedComponentVersion(componentVersion, meta, repo, overwrite, copyResources, sign)
+	}
+
+	if err = s.ociRepository.PushComponentVersion(componentVersion, repo, overwrite, copyResources); err != nil {
+		return fmt.Errorf("could not push component version %s:%s: %w", meta.name, meta.version, err)
+	}
+	return nil
+}
+
+// pushSignedComponentVersion signs a copy of the component version in memory and pushes the signed copy, so that
+// the archive, e.g. one that passed an approval, is neither modified nor left partly signed.
+func (s *Service) pushSignedComponentVersion(componentVersion cpi.ComponentVersionAccess,
+	meta componentVersionMeta, repo cpi.Repository,
+	overwrite, copyResources bool,
+	sign func(componentVersion cpi.ComponentVersionAccess) error,
+) error {
+	stagingRepo, err := ctf.Open(cpi.DefaultContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, stagingArchivePath,
+		vfs.ModePerm, accessio.FormatDirectory, accessio.PathFileSystem(memoryfs.New()))
+	if err != nil {
+		return fmt.Errorf("could not create staging archive: %w", err)
+	}
+	defer stagingRepo.Close()
+
+	if err = s.ociRepository.PushComponentVersion(componentVersion, stagingRepo, false, false); err != nil {
+		return fmt.Errorf("could not copy component version %s:%s for signing: %w", meta.name, meta.version, err)
+	}
+	stagedComponentVersion, err := s.ociRepository.GetComponentVersion(meta, stagingRepo)
+	if err != nil {
+		return fmt.Errorf("could not get copy of component version %s:%s: %w", meta.name, meta.version, err)
+	}
+	defer stagedComponentVersion.Close()
+
+	if err = sign(stagedComponentVersion); err != nil {
+		return err
+	}
+
+	if err = s.ociRepository.PushComponentVersion(stagedComponentVersion, repo, overwrite, copyResources); err != nil {
+		return fmt.Errorf("could not push component version %s:%s: %w", meta.name, meta.version, err)
+	}
+	return nil
+}
+
+func openArchive(archivePath string, mode accessobj.AccessMode) (cpi.Repository, error) {
+	format := accessio.FormatDirectory
+	if strings.HasSuffix(archivePath, ".tgz") || strings.HasSuffix(archivePath, ".tar.gz") {
+		format = accessio.FormatTGZ
+	}
+
+	archiveRepo, err := ctf.Open(cpi.DefaultContext(), mode, archivePath, vfs.ModePerm, format,
+		accessio.PathFileSystem(osfs.New()))
+	if err != nil {
+		return nil, fmt.Errorf("could not open archive %s: %w", archivePath, err)
+	}
+	return archiveRepo, nil
+}
+
 func (s *Service) GetComponentVersion(archive *comparch.ComponentArchive, insecure bool,
 	userPasswordCreds, registryURL string,
 ) (cpi.ComponentVersionAccess, error) {
@@ -92,11 +254,248 @@ func (s *Service) GetComponentVersion(archive *comparch.ComponentArchive, insecu
 	return componentVersion, nil
 }
 
+// CompareComponentVersion compares the component version pushed to the registry with the one of the archive.
+// It returns the differences of their normalized content, none if both have the same normalized digest.
+func (s *Service) CompareComponentVersion(archive *comparch.ComponentArchive, insecure, copyResources bool,
+	userPasswordCreds, registryURL string,
+) ([]string, error) {
+	repo, err := s.getRepository(insecure, userPasswordCreds, registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not get repository: %w", err)
+	}
+
+	pushedComponentVersion, err := s.ociRepository.GetComponentVersion(archive, repo)
+	if err != nil {
+		return nil, fmt.Errorf("could not get component version: %w", err)
+	}
+	defer pushedComponentVersion.Close()
+
+	pushed, err := normalizeComponentVersion(pushedComponentVersion, copyResources, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize pushed component version: %w", err)
+	}
+	archived, err := normalizeComponentVersion(archive, copyResources, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize component version: %w", err)
+	}
+
+	pushedDigest, err := pushed.Digest()
+	if err != nil {
+		return nil, err
+	}
+	archivedDigest, err := archived.Digest()
+	if err != nil {
+		return nil, err
+	}
+	if pushedDigest == archivedDigest {
+		return nil, nil
+	}
+	return pushed.Diff(archived), nil
+}
+
+// normalizeComponentVersion normalizes the descriptor of the component version with the digests of its local
+// blobs, as their access differs between repositories. If the resources are copied by value, the access of the
+// external resources is rewritten on push, so they are compared by the digest of their OCI artifact instead.
+func normalizeComponentVersion(componentVersion cpi.ComponentVersionAccess, copyResources, pushed bool,
+) (componentdescriptor.NormalizedDescriptor, error) {
+	descriptor := componentVersion.GetDescriptor()
+	resources := componentVersion.GetResources()
+	contentDigests := make([]string, len(resources))
+	for i, resource := range resources {
+		if resource.Meta().Relation != ocmv1.LocalRelation {
+			if copyResources {
+				digest, err := artifactDigest(componentVersion.GetContext(), resource, descriptor.Resources[i].Access,
+					pushed)
+				if err != nil {
+					return nil, fmt.Errorf("could not get artifact digest of resource %s: %w", resource.Meta().Name, err)
+				}
+				contentDigests[i] = digest
+			}
+			continue
+		}
+		blob, err := getResourceBlob(resource)
+		if err != nil {
+			return nil, fmt.Errorf("could not get blob of resource %s: %w", resource.Meta().Name, err)
+		}
+		sum := sha256.Sum256(blob)
+		contentDigests[i] = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return componentdescriptor.Normalize(descriptor, contentDigests)
+}
+
+// digestSource is implemented by the access methods that resolve the digest of their artifact without
+// downloading it, like the one of OCI artifacts.
+type digestSource interface {
+	GetDigest() (digest.Digest, error)
+}
+
+// artifactDigest returns the digest of the OCI artifact of an external resource. A pushed resource that was copied
+// by value is a local blob named after the digest of its artifact. A pushed resource that still references an
+// external image, e.g. of a component version pushed without copying the resources, has no digest, so its access
+// is compared instead. The digest of the source image is taken from its reference or resolved in its registry.
+func artifactDigest(ctx cpi.Context, resource cpi.ResourceAccess, access compdesc.AccessSpec, pushed bool,
+) (string, error) {
+	spec, err := ctx.AccessSpecForSpec(access)
+	if err != nil {
+		return "", fmt.Errorf("could not get access spec: %w", err)
+	}
+
+	if pushed {
+		if localBlob, ok := spec.(*localblob.AccessSpec); ok && strings.HasPrefix(localBlob.LocalReference, "sha256:") {
+			return localBlob.LocalReference, nil
+		}
+		return "", nil
+	}
+
+	artifact, ok := spec.(*ociartifact.AccessSpec)
+	if !ok {
+		return "", nil
+	}
+	if _, imageDigest, found := strings.Cut(artifact.ImageReference, "@"); found {
+		return imageDigest, nil
+	}
+
+	accessMethod, err := resource.AccessMethod()
+	if err != nil {
+		return "", fmt.Errorf("failed to get access method: %w", err)
+	}
+	defer accessMethod.Close()
+
+	source, ok := accessMethod.(digestSource)
+	if !ok {
+		return "", fmt.Errorf("access method of %s does not resolve digests", artifact.ImageReference)
+	}
+	imageDigest, err := source.GetDigest()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve digest of %s: %w", artifact.ImageReference, err)
+	}
+	return imageDigest.String(), nil
+}
+
+// LookupComponentVersion returns the component version published to the registry. The caller must close it.
+func (s *Service) LookupComponentVersion(name, version string, insecure bool, userPasswordCreds, registryURL string,
+) (cpi.ComponentVersionAccess, error) {
+	repo, err := s.getRepository(insecure, userPasswordCreds, registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not get repository: %w", err)
+	}
+
+	componentVersion, err := s.ociRepository.GetComponentVersion(componentVersionMeta{name, version}, repo)
+	if err != nil {
+		return nil, fmt.Errorf("could not get component version: %w", err)
+	}
+	return componentVersion, nil
+}
+
+// PullComponentVersion returns the descriptor of the component version and the blobs of its local resources,
+// keyed by the resource name.
+func (s *Service) PullComponentVersion(name, version string, insecure bool, userPasswordCreds, registryURL string,
+) (*compdesc.ComponentDescriptor, map[string][]byte, error) {
+	repo, err := s.getRepository(insecure, userPasswordCreds, registryURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get repository: %w", err)
+	}
+
+	componentVersion, err := s.ociRepository.GetComponentVersion(componentVersionMeta{name, version}, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get component version: %w", err)
+	}
+	defer componentVersion.Close()
+
+	blobs := make(map[string][]byte)
+	for _, resource := range componentVersion.GetResources() {
+		if resource.Meta().Relation != ocmv1.LocalRelation {
+			continue
+		}
+		blob, err := getResourceBlob(resource)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get blob of resource %s: %w", resource.Meta().Name, err)
+		}
+		blobs[resource.Meta().Name] = blob
+	}
+
+	return componentVersion.GetDescriptor().Copy(), blobs, nil
+}
+
+// ComponentVersion is a version of a component listed in the registry. If the version cannot be read, Err is set
+// instead of the descriptor.
+type ComponentVersion struct {
+	Version    string
+	Descriptor *compdesc.ComponentDescriptor
+	Err        error
+}
+
+// ListComponentVersions lists the versions of the component with their descriptors. A version that cannot be read
+// is reported with its error instead of failing the whole listing.
+func (s *Service) ListComponentVersions(name string, insecure bool, userPasswordCreds, registryURL string,
+) ([]ComponentVersion, error) {
+	repo, err := s.getRepository(insecure, userPasswordCreds, registryURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not get repository: %w", err)
+	}
+
+	versions, err := s.ociRepository.ListComponentVersions(name, repo)
+	if err != nil {
+		return nil, fmt.Errorf("could not list component versions: %w", err)
+	}
+
+	componentVersions := make([]ComponentVersion, 0, len(versions))
+	for _, version := range versions {
+		componentVersion, err := s.ociRepository.GetComponentVersion(componentVersionMeta{name, version}, repo)
+		if err != nil {
+			componentVersions = append(componentVersions, ComponentVersion{Version: version, Err: err})
+			continue
+		}
+		componentVersions = append(componentVersions, ComponentVersion{
+			Version:    version,
+			Descriptor: componentVersion.GetDescriptor().Copy(),
+		})
+		componentVersion.Close()
+	}
+
+	return componentVersions, nil
+}
+
+func getResourceBlob(resource cpi.ResourceAccess) ([]byte, error) {
+	accessMethod, err := resource.AccessMethod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access method: %w", err)
+	}
+	defer accessMethod.Close()
+
+	blob, err := accessMethod.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return blob, nil
+}
+
+type componentVersionMeta struct {
+	name    string
+	version string
+}
+
+func (m componentVersionMeta) GetName() string {
+	return m.name
+}
+
+func (m componentVersionMeta) GetVersion() string {
+	return m.version
+}
+
+// getRepository returns the repository of the registry. The repositories are cached per registry, so that a
+// component version can be pushed to several registries in one run.
 func (s *Service) getRepository(insecure bool, userPasswordCreds, registryURL string) (cpi.Repository, error) {
 	if s.repo != nil {
 		return s.repo, nil
 	}
 
+	baseURL := ConstructRegistryUrl(registryURL, insecure)
+	if repo, ok := s.repos[baseURL]; ok {
+		return repo, nil
+	}
+
 	ctx := cpi.DefaultContext()
 
 	creds, err := s.credResolver(ctx, userPasswordCreds, registryURL)
@@ -106,7 +505,7 @@ func (s *Service) getRepository(insecure bool, userPasswordCreds, registryURL st
 
 	ociRepoSpec := &ocireg.RepositorySpec{
 		ObjectVersionedType: runtime.NewVersionedObjectType(ocireg.Type),
-		BaseURL:             ConstructRegistryUrl(registryURL, insecure),
+		BaseURL:             baseURL,
 	}
 
 	ociRepo, err := ctx.RepositoryTypes().Convert(ociRepoSpec)
@@ -119,7 +518,7 @@ func (s *Service) getRepository(insecure bool, userPasswordCreds, registryURL st
 		return nil, fmt.Errorf("could not create repository from spec: %w", err)
 	}
 
-	s.repo = repo
+	s.repos[baseURL] = repo
 
 	return repo, nil
 }
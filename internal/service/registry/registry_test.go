@@ -1,16 +1,32 @@
 package registry_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/stretchr/testify/require"
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/extensions/repositories/ocireg"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
 
+	"github.com/kyma-project/modulectl/internal/service/componentarchive"
 	"github.com/kyma-project/modulectl/internal/service/registry"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	"github.com/kyma-project/modulectl/internal/testutils"
+	"github.com/kyma-project/modulectl/tools/filesystem"
 	"github.com/kyma-project/modulectl/tools/ocirepo"
 )
 
@@ -38,7 +54,8 @@ func TestServiceExistsComponentVersion_WhenCredResolverReturnsError_ReturnsErr(t
 func TestServicePushComponentVersion_WhenCredResolverReturnsError_ReturnsErr(t *testing.T) {
 	svc, _ := registry.NewService(&ociRepositoryVersionExistsStub{}, nil, errResolverFunc)
 
-	err := svc.PushComponentVersion(&comparch.ComponentArchive{}, true, true, "creds", "ghcr.io/template-operator")
+	err := svc.PushComponentVersion(&comparch.ComponentArchive{}, true, true, false, "creds",
+		"ghcr.io/template-operator")
 
 	require.ErrorContains(t, err, "failed to resolve credentials")
 	require.ErrorContains(t, err, "could not get repository")
@@ -60,7 +77,7 @@ func TestService_PushComponentVersion_ReturnErrorWhenSameComponentVersionExists(
 
 	svc, _ := registry.NewService(&ociRepositoryVersionExistsStub{}, repo, defaultCredsResolverFunc)
 
-	err = svc.PushComponentVersion(componentArchive, true, false, "", "ghcr.io/template-operator")
+	err = svc.PushComponentVersion(componentArchive, true, false, false, "", "ghcr.io/template-operator")
 
 	require.ErrorContains(t, err, "could not push component version")
 }
@@ -72,7 +89,7 @@ func TestService_PushComponentVersion_ReturnNoErrorWhenSameComponentVersionExist
 
 	svc, _ := registry.NewService(&ociRepositoryStub{}, repo, defaultCredsResolverFunc)
 
-	err = svc.PushComponentVersion(componentArchive, true, true, "", "ghcr.io/template-operator")
+	err = svc.PushComponentVersion(componentArchive, true, true, false, "", "ghcr.io/template-operator")
 
 	require.NoError(t, err)
 }
@@ -83,10 +100,22 @@ func TestService_PushComponentVersion_ReturnNoErrorOnSuccess(t *testing.T) {
 	componentArchive := &comparch.ComponentArchive{}
 
 	svc, _ := registry.NewService(&ociRepositoryStub{}, repo, defaultCredsResolverFunc)
-	err = svc.PushComponentVersion(componentArchive, true, false, "", "ghcr.io/template-operator")
+	err = svc.PushComponentVersion(componentArchive, true, false, false, "", "ghcr.io/template-operator")
 	require.NoError(t, err)
 }
 
+func TestService_PushComponentVersion_PassesCopyResources(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	ociRepository := &ociRepositoryStub{}
+
+	svc, _ := registry.NewService(ociRepository, repo, defaultCredsResolverFunc)
+	err = svc.PushComponentVersion(&comparch.ComponentArchive{}, true, false, true, "", "ghcr.io/template-operator")
+
+	require.NoError(t, err)
+	require.True(t, ociRepository.copyResources)
+}
+
 func TestService_GetComponentVersion_ReturnCorrectData(t *testing.T) {
 	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
 	require.NoError(t, err)
@@ -108,6 +137,194 @@ func TestService_GetComponentVersion_ReturnErrorOnComponentVersionGetError(t *te
 	require.ErrorContains(t, err, "could not get component version")
 }
 
+func TestService_CompareComponentVersion_WhenCredResolverReturnsError_ReturnsErr(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, errResolverFunc)
+
+	_, err := svc.CompareComponentVersion(&comparch.ComponentArchive{}, true, false, "",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get repository")
+}
+
+func TestService_CompareComponentVersion_ReturnErrorOnComponentVersionGetError(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+
+	svc, _ := registry.NewService(&ociRepositoryNotExistStub{}, repo, defaultCredsResolverFunc)
+	_, err = svc.CompareComponentVersion(&comparch.ComponentArchive{}, true, false, "",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get component version")
+}
+
+func TestService_PullComponentVersion_WhenCredResolverReturnsError_ReturnsErr(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, errResolverFunc)
+
+	_, _, err := svc.PullComponentVersion("kyma-project.io/module/template-operator", "1.0.0", true, "creds",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get repository")
+}
+
+func TestService_PullComponentVersion_ReturnErrorOnComponentVersionGetError(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ociRepositoryNotExistStub{}, repo, defaultCredsResolverFunc)
+
+	_, _, err = svc.PullComponentVersion("kyma-project.io/module/template-operator", "1.0.0", true, "",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get component version")
+}
+
+func TestService_LookupComponentVersion_WhenCredResolverReturnsError_ReturnsErr(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, errResolverFunc)
+
+	_, err := svc.LookupComponentVersion("kyma-project.io/module/template-operator", "1.0.0", true, "creds",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get repository")
+}
+
+func TestService_LookupComponentVersion_ReturnErrorOnComponentVersionGetError(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ociRepositoryNotExistStub{}, repo, defaultCredsResolverFunc)
+
+	_, err = svc.LookupComponentVersion("kyma-project.io/module/template-operator", "1.0.0", true, "",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "could not get component version")
+}
+
+func TestService_ListComponentVersions_WhenCredResolverReturnsError_ReturnsErr(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, errResolverFunc)
+
+	_, err := svc.ListComponentVersions("kyma-project.io/module/template-operator", true, "creds",
+		"ghcr.io/template-operator")
+
+	require.ErrorContains(t, err, "failed to resolve credentials")
+	require.ErrorContains(t, err, "could not get repository")
+}
+
+func TestService_ListComponentVersions_ReturnErrorOnListError(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ociRepositoryStub{err: errors.New("test error")}, repo, defaultCredsResolverFunc)
+
+	_, err = svc.ListComponentVersions("kyma-project.io/module/template-operator", true, "",
+		"ghcr.io/template-operator")
+
+	require.Equal(t, "could not list component versions: test error", err.Error())
+}
+
+func TestService_ListComponentVersions_ReportsUnreadableVersion(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ociRepositoryNotExistStub{versions: []string{"1.0.0"}}, repo,
+		defaultCredsResolverFunc)
+
+	componentVersions, err := svc.ListComponentVersions("kyma-project.io/module/template-operator", true, "",
+		"ghcr.io/template-operator")
+
+	require.NoError(t, err)
+	require.Len(t, componentVersions, 1)
+	require.Equal(t, "1.0.0", componentVersions[0].Version)
+	require.Nil(t, componentVersions[0].Descriptor)
+	require.ErrorContains(t, componentVersions[0].Err, "failed to get component version")
+}
+
+func TestService_ListComponentVersions_ReturnEmptyList_WhenComponentHasNoVersions(t *testing.T) {
+	repo, err := ocireg.NewRepository(cpi.DefaultContext(), "URL")
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ociRepositoryNotExistStub{}, repo, defaultCredsResolverFunc)
+
+	componentVersions, err := svc.ListComponentVersions("kyma-project.io/module/template-operator", true, "",
+		"ghcr.io/template-operator")
+
+	require.NoError(t, err)
+	require.Empty(t, componentVersions)
+}
+
+func TestService_ExportComponentVersion_WritesArchive(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, errResolverFunc)
+
+	err := svc.ExportComponentVersion(&comparch.ComponentArchive{}, filepath.Join(t.TempDir(), "archive"), false,
+		false)
+
+	require.NoError(t, err)
+}
+
+func TestService_ExportComponentVersion_ReturnErrorOnPushError(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{err: errors.New("test error")}, nil, errResolverFunc)
+
+	err := svc.ExportComponentVersion(&comparch.ComponentArchive{}, filepath.Join(t.TempDir(), "archive.tgz"),
+		false, false)
+
+	require.ErrorContains(t, err, "could not write component version to archive: test error")
+}
+
+func TestService_PushArchive_ReturnErrorWhenArchiveDoesNotExist(t *testing.T) {
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil, defaultCredsResolverFunc)
+
+	_, err := svc.PushArchive(filepath.Join(t.TempDir(), "missing"), true, false, false, "",
+		"ghcr.io/template-operator", nil)
+
+	require.ErrorContains(t, err, "could not open archive")
+}
+
+func TestService_PushArchive_SignsWithoutModifyingArchive(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "archive.tgz")
+	exporter, _ := registry.NewService(&ocirepo.OCIRepo{}, nil, errResolverFunc)
+	require.NoError(t, exporter.ExportComponentVersion(newComponentArchive(t), archivePath, false, false))
+	archiveContent, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	target, err := ctf.Open(cpi.DefaultContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE,
+		filepath.Join(t.TempDir(), "target"), vfs.ModePerm, accessio.FormatDirectory,
+		accessio.PathFileSystem(osfs.New()))
+	require.NoError(t, err)
+	defer target.Close()
+	signatureService, err := signature.NewService(&filesystem.Helper{})
+	require.NoError(t, err)
+	svc, _ := registry.NewService(&ocirepo.OCIRepo{}, target, errResolverFunc)
+
+	pushed, err := svc.PushArchive(archivePath, false, false, false, "", "ghcr.io/template-operator",
+		func(componentVersion cpi.ComponentVersionAccess) error {
+			_, err := signatureService.Sign(componentVersion, signature.SigningConfig{
+				Name:           signature.DefaultName,
+				PrivateKeyFile: writePrivateKey(t),
+			})
+			return err
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"kyma-project.io/module/test:1.0.0"}, pushed)
+	unchangedContent, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	require.Equal(t, archiveContent, unchangedContent)
+	pushedComponentVersion, err := target.LookupComponentVersion("kyma-project.io/module/test", "1.0.0")
+	require.NoError(t, err)
+	defer pushedComponentVersion.Close()
+	require.GreaterOrEqual(t, pushedComponentVersion.GetDescriptor().GetSignatureIndex(signature.DefaultName), 0)
+}
+
+func TestService_ExistsComponentVersion_ResolvesCredentialsOncePerRegistry(t *testing.T) {
+	var registryURLs []string
+	svc, _ := registry.NewService(&ociRepositoryStub{}, nil,
+		func(_ cpi.Context, _ string, registryURL string) (credentials.Credentials, error) {
+			registryURLs = append(registryURLs, registryURL)
+			return credentials.NewCredentials(nil), nil
+		})
+
+	for _, registryURL := range []string{"https://primary.example.com", "https://mirror.example.com",
+		"https://primary.example.com"} {
+		_, err := svc.ExistsComponentVersion(&comparch.ComponentArchive{}, false, "", registryURL)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []string{"https://primary.example.com", "https://mirror.example.com"}, registryURLs)
+}
+
 func Test_ConstructRegistryUrl_ReturnsCorrectWithHTTPAndNotInsecure(t *testing.T) {
 	scheme := registry.ConstructRegistryUrl("http://ghcr.io", false)
 
@@ -180,15 +397,15 @@ func Test_ExistsComponentVersion_Error(t *testing.T) {
 
 type ociRepositoryVersionExistsStub struct{}
 
-func (*ociRepositoryVersionExistsStub) GetComponentVersion(_ *comparch.ComponentArchive,
+func (*ociRepositoryVersionExistsStub) GetComponentVersion(_ ocirepo.ComponentArchiveMeta,
 	_ cpi.Repository,
 ) (cpi.ComponentVersionAccess, error) {
 	componentVersion := &comparch.ComponentArchive{}
 	return componentVersion, nil
 }
 
-func (*ociRepositoryVersionExistsStub) PushComponentVersion(_ *comparch.ComponentArchive,
-	_ cpi.Repository, _ bool,
+func (*ociRepositoryVersionExistsStub) PushComponentVersion(_ cpi.ComponentVersionAccess,
+	_ cpi.Repository, _, _ bool,
 ) error {
 	return errors.New("component version already exists")
 }
@@ -199,20 +416,26 @@ func (*ociRepositoryVersionExistsStub) ExistsComponentVersion(_ ocirepo.Componen
 	return true, nil
 }
 
+func (*ociRepositoryVersionExistsStub) ListComponentVersions(_ string, _ cpi.Repository) ([]string, error) {
+	return []string{"1.0.0"}, nil
+}
+
 type ociRepositoryStub struct {
-	err error
+	err           error
+	copyResources bool
 }
 
-func (s *ociRepositoryStub) GetComponentVersion(_ *comparch.ComponentArchive,
+func (s *ociRepositoryStub) GetComponentVersion(_ ocirepo.ComponentArchiveMeta,
 	_ cpi.Repository,
 ) (cpi.ComponentVersionAccess, error) {
 	componentVersion := &comparch.ComponentArchive{}
 	return componentVersion, s.err
 }
 
-func (s *ociRepositoryStub) PushComponentVersion(_ *comparch.ComponentArchive,
-	_ cpi.Repository, _ bool,
+func (s *ociRepositoryStub) PushComponentVersion(_ cpi.ComponentVersionAccess,
+	_ cpi.Repository, _, copyResources bool,
 ) error {
+	s.copyResources = copyResources
 	return s.err
 }
 
@@ -222,16 +445,22 @@ func (s *ociRepositoryStub) ExistsComponentVersion(_ ocirepo.ComponentArchiveMet
 	return false, s.err
 }
 
-type ociRepositoryNotExistStub struct{}
+func (s *ociRepositoryStub) ListComponentVersions(_ string, _ cpi.Repository) ([]string, error) {
+	return nil, s.err
+}
 
-func (*ociRepositoryNotExistStub) GetComponentVersion(_ *comparch.ComponentArchive,
+type ociRepositoryNotExistStub struct {
+	versions []string
+}
+
+func (*ociRepositoryNotExistStub) GetComponentVersion(_ ocirepo.ComponentArchiveMeta,
 	_ cpi.Repository,
 ) (cpi.ComponentVersionAccess, error) {
 	return nil, errors.New("failed to get component version")
 }
 
-func (*ociRepositoryNotExistStub) PushComponentVersion(_ *comparch.ComponentArchive,
-	_ cpi.Repository, _ bool,
+func (*ociRepositoryNotExistStub) PushComponentVersion(_ cpi.ComponentVersionAccess,
+	_ cpi.Repository, _, _ bool,
 ) error {
 	return nil
 }
@@ -242,6 +471,35 @@ func (*ociRepositoryNotExistStub) ExistsComponentVersion(_ ocirepo.ComponentArch
 	return false, nil
 }
 
+func (s *ociRepositoryNotExistStub) ListComponentVersions(_ string, _ cpi.Repository) ([]string, error) {
+	return s.versions, nil
+}
+
+func newComponentArchive(t *testing.T) *comparch.ComponentArchive {
+	t.Helper()
+	archiveFileSystem, err := filesystem.NewArchiveFileSystem(memoryfs.New(), osfs.New())
+	require.NoError(t, err)
+	componentArchiveService, err := componentarchive.NewService(archiveFileSystem)
+	require.NoError(t, err)
+
+	archive, err := componentArchiveService.CreateComponentArchive(
+		testutils.CreateComponentDescriptor("kyma-project.io/module/test", "1.0.0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = archive.Close() })
+	return archive
+}
+
+func writePrivateKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKeyFile := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(t, os.WriteFile(privateKeyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	return privateKeyFile
+}
+
 func errResolverFunc(_ cpi.Context, _ string, _ string) (credentials.Credentials, error) {
 	return nil, errors.New("nil resolver function called")
 }
@@ -0,0 +1,39 @@
+package releasemeta
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type Options struct {
+	Out                iotools.Out
+	ConfigFile         string
+	Channels           []string
+	Output             string
+	AllowUnknownFields bool
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.ConfigFile == "" {
+		return fmt.Errorf("opts.ConfigFile must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.Output == "" {
+		return fmt.Errorf("opts.Output must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	for _, channel := range opts.Channels {
+		if err := validation.ValidateChannel(channel); err != nil {
+			return fmt.Errorf("opts.Channels contains an invalid channel %q: %w", channel, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package releasemeta
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	apimetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+var (
+	ErrNoChannels         = errors.New("no channels to assign")
+	ErrInvalidReleaseMeta = errors.New("invalid ModuleReleaseMeta")
+
+	channelVersionRegex = regexp.MustCompile(validation.ChannelVersionPattern)
+)
+
+type ModuleConfigService interface {
+	ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool) (*contentprovider.ModuleConfig, error)
+}
+
+type FileSystem interface {
+	FileExists(path string) (bool, error)
+	ReadFile(path string) ([]byte, error)
+	WriteFile(path, content string) error
+}
+
+// Service assigns the version of a module to channels in a ModuleReleaseMeta CR.
+// An existing ModuleReleaseMeta is updated in place, assignments of other channels are kept.
+type Service struct {
+	moduleConfigService ModuleConfigService
+	fileSystem          FileSystem
+}
+
+func NewService(moduleConfigService ModuleConfigService, fileSystem FileSystem) (*Service, error) {
+	if moduleConfigService == nil {
+		return nil, fmt.Errorf("moduleConfigService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		moduleConfigService: moduleConfigService,
+		fileSystem:          fileSystem,
+	}, nil
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	opts.Out.Write("- Validating module config\n")
+	moduleConfig, err := s.moduleConfigService.ParseAndValidateModuleConfig(opts.ConfigFile, opts.AllowUnknownFields)
+	if err != nil {
+		return fmt.Errorf("failed to parse module config: %w", err)
+	}
+
+	// channels passed as flags take precedence over the channels of the module config
+	channels := opts.Channels
+	if len(channels) == 0 {
+		channels = moduleConfig.Channels
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("%w: neither the module config nor the flags specify channels", ErrNoChannels)
+	}
+
+	if !channelVersionRegex.MatchString(moduleConfig.Version) {
+		return fmt.Errorf("%w: version %s can not be assigned to a channel, it must follow the pattern %s",
+			commonerrors.ErrInvalidOption, moduleConfig.Version, validation.ChannelVersionPattern)
+	}
+
+	moduleName := path.Base(moduleConfig.Name)
+	releaseMeta, err := s.loadReleaseMeta(opts, moduleName)
+	if err != nil {
+		return err
+	}
+
+	releaseMeta.Spec.ModuleName = moduleName
+	releaseMeta.Spec.OcmComponentName = moduleConfig.Name
+	releaseMeta.Spec.Beta = moduleConfig.Beta
+	releaseMeta.Spec.Internal = moduleConfig.Internal
+
+	opts.Out.Write("- Assigning channels\n")
+	for _, channel := range channels {
+		previous := assignChannel(releaseMeta, channel, moduleConfig.Version)
+		if previous == "" {
+			opts.Out.Write(fmt.Sprintf("\t%s: %s\n", channel, moduleConfig.Version))
+		} else {
+			opts.Out.Write(fmt.Sprintf("\t%s: %s -> %s\n", channel, previous, moduleConfig.Version))
+		}
+	}
+	slices.SortFunc(releaseMeta.Spec.Channels, func(a, b v1beta2.ChannelVersionAssignment) int {
+		return strings.Compare(a.Channel, b.Channel)
+	})
+
+	opts.Out.Write("- Writing ModuleReleaseMeta\n")
+	content, err := yaml.Marshal(releaseMeta)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ModuleReleaseMeta: %w", err)
+	}
+	if err = s.fileSystem.WriteFile(opts.Output, string(content)); err != nil {
+		return fmt.Errorf("failed to write ModuleReleaseMeta: %w", err)
+	}
+
+	opts.Out.Write(fmt.Sprintf("ModuleReleaseMeta for module %s written to %s\n", moduleName, opts.Output))
+	return nil
+}
+
+// loadReleaseMeta reads the ModuleReleaseMeta from the output file if it exists, otherwise a new one is returned.
+func (s *Service) loadReleaseMeta(opts Options, moduleName string) (*v1beta2.ModuleReleaseMeta, error) {
+	exists, err := s.fileSystem.FileExists(opts.Output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if ModuleReleaseMeta exists: %w", err)
+	}
+
+	if !exists {
+		return &v1beta2.ModuleReleaseMeta{
+			TypeMeta: apimetav1.TypeMeta{
+				APIVersion: v1beta2.GroupVersion.String(),
+				Kind:       string(shared.ModuleReleaseMetaKind),
+			},
+			ObjectMeta: apimetav1.ObjectMeta{
+				Name: moduleName,
+			},
+		}, nil
+	}
+
+	opts.Out.Write(fmt.Sprintf("- Reading existing ModuleReleaseMeta %s\n", opts.Output))
+	content, err := s.fileSystem.ReadFile(opts.Output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ModuleReleaseMeta: %w", err)
+	}
+
+	releaseMeta := &v1beta2.ModuleReleaseMeta{}
+	if err = yaml.UnmarshalStrict(content, releaseMeta); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidReleaseMeta, opts.Output, err)
+	}
+
+	if releaseMeta.Kind != string(shared.ModuleReleaseMetaKind) {
+		return nil, fmt.Errorf("%w: %s contains kind %q instead of %q", ErrInvalidReleaseMeta, opts.Output,
+			releaseMeta.Kind, shared.ModuleReleaseMetaKind)
+	}
+
+	if releaseMeta.Spec.ModuleName != moduleName {
+		return nil, fmt.Errorf("%w: %s belongs to module %q instead of %q", ErrInvalidReleaseMeta, opts.Output,
+			releaseMeta.Spec.ModuleName, moduleName)
+	}
+
+	if releaseMeta.Spec.Mandatory != nil {
+		return nil, fmt.Errorf("%w: %s belongs to a mandatory module, which can not be assigned to channels",
+			ErrInvalidReleaseMeta, opts.Output)
+	}
+
+	return releaseMeta, nil
+}
+
+// assignChannel assigns the version to the channel and returns the version previously assigned to it.
+func assignChannel(releaseMeta *v1beta2.ModuleReleaseMeta, channel, version string) string {
+	for i, assignment := range releaseMeta.Spec.Channels {
+		if assignment.Channel == channel {
+			releaseMeta.Spec.Channels[i].Version = version
+			return assignment.Version
+		}
+	}
+
+	releaseMeta.Spec.Channels = append(releaseMeta.Spec.Channels, v1beta2.ChannelVersionAssignment{
+		Channel: channel,
+		Version: version,
+	})
+	return ""
+}
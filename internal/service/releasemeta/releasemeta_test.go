@@ -0,0 +1,334 @@
+package releasemeta_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/releasemeta"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const existingReleaseMeta = `apiVersion: operator.kyma-project.io/v1beta2
+kind: ModuleReleaseMeta
+metadata:
+  name: template-operator
+spec:
+  moduleName: template-operator
+  ocmComponentName: kyma-project.io/module/template-operator
+  channels:
+  - channel: regular
+    version: 1.0.0
+  - channel: experimental
+    version: 1.1.0-rc1
+  beta: false
+  internal: false
+`
+
+func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
+	_, err := releasemeta.NewService(nil, &fileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "moduleConfigService must not be nil")
+}
+
+func Test_NewService_ReturnsError_WhenFileSystemIsNil(t *testing.T) {
+	_, err := releasemeta.NewService(&moduleConfigServiceStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	assert.Contains(t, err.Error(), "fileSystem must not be nil")
+}
+
+func Test_Run_ReturnsError_WhenOptionsAreInvalid(t *testing.T) {
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{}, &fileSystemStub{})
+
+	tests := []struct {
+		name    string
+		opts    releasemeta.Options
+		wantErr string
+	}{
+		{
+			name:    "Out is nil",
+			opts:    releasemeta.Options{ConfigFile: "module-config.yaml", Output: "mrm.yaml"},
+			wantErr: "opts.Out must not be nil",
+		},
+		{
+			name:    "ConfigFile is empty",
+			opts:    releasemeta.Options{Out: iotools.NewDefaultOut(io.Discard), Output: "mrm.yaml"},
+			wantErr: "opts.ConfigFile must not be empty",
+		},
+		{
+			name:    "Output is empty",
+			opts:    releasemeta.Options{Out: iotools.NewDefaultOut(io.Discard), ConfigFile: "module-config.yaml"},
+			wantErr: "opts.Output must not be empty",
+		},
+		{
+			name: "Channel is invalid",
+			opts: releasemeta.Options{
+				Out:        iotools.NewDefaultOut(io.Discard),
+				ConfigFile: "module-config.yaml",
+				Output:     "mrm.yaml",
+				Channels:   []string{"Fast"},
+			},
+			wantErr: "opts.Channels contains an invalid channel",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := svc.Run(test.opts)
+
+			require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func Test_Run_CreatesReleaseMeta_WhenOutputDoesNotExist(t *testing.T) {
+	fileSystem := &fileSystemStub{}
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+		moduleConfig: newModuleConfig("1.0.0", "regular", "fast"),
+	}, fileSystem)
+
+	err := svc.Run(newOptions())
+
+	require.NoError(t, err)
+	assert.Equal(t, "mrm.yaml", fileSystem.writtenPath)
+	assert.Equal(t, `apiVersion: operator.kyma-project.io/v1beta2
+kind: ModuleReleaseMeta
+metadata:
+  name: template-operator
+spec:
+  beta: true
+  channels:
+  - channel: fast
+    version: 1.0.0
+  - channel: regular
+    version: 1.0.0
+  internal: false
+  moduleName: template-operator
+  ocmComponentName: kyma-project.io/module/template-operator
+`, fileSystem.writtenContent)
+}
+
+func Test_Run_MergesIntoExistingReleaseMeta(t *testing.T) {
+	fileSystem := &fileSystemStub{content: existingReleaseMeta}
+	out := &outStub{}
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+		moduleConfig: newModuleConfig("1.1.0", "regular", "fast"),
+	}, fileSystem)
+	opts := newOptions()
+	opts.Out = out
+
+	err := svc.Run(opts)
+
+	require.NoError(t, err)
+	assert.Contains(t, fileSystem.writtenContent, `  channels:
+  - channel: experimental
+    version: 1.1.0-rc1
+  - channel: fast
+    version: 1.1.0
+  - channel: regular
+    version: 1.1.0
+`)
+	assert.Contains(t, out.messages, "\tregular: 1.0.0 -> 1.1.0\n")
+	assert.Contains(t, out.messages, "\tfast: 1.1.0\n")
+}
+
+func Test_Run_PrefersChannelsOfOptions(t *testing.T) {
+	fileSystem := &fileSystemStub{}
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+		moduleConfig: newModuleConfig("1.0.0", "regular"),
+	}, fileSystem)
+	opts := newOptions()
+	opts.Channels = []string{"experimental"}
+
+	err := svc.Run(opts)
+
+	require.NoError(t, err)
+	assert.Contains(t, fileSystem.writtenContent, "- channel: experimental\n")
+	assert.NotContains(t, fileSystem.writtenContent, "regular")
+}
+
+func Test_Run_ReturnsError_WhenNoChannelsAreGiven(t *testing.T) {
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+		moduleConfig: newModuleConfig("1.0.0"),
+	}, &fileSystemStub{})
+
+	err := svc.Run(newOptions())
+
+	require.ErrorIs(t, err, releasemeta.ErrNoChannels)
+}
+
+func Test_Run_ReturnsError_WhenVersionCanNotBeAssignedToChannel(t *testing.T) {
+	svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+		moduleConfig: newModuleConfig("1.0.0+build.1", "regular"),
+	}, &fileSystemStub{})
+
+	err := svc.Run(newOptions())
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	assert.Contains(t, err.Error(), "version 1.0.0+build.1 can not be assigned to a channel")
+}
+
+func Test_Run_ReturnsError_WhenModuleConfigIsInvalid(t *testing.T) {
+	svc, _ := releasemeta.NewService(&moduleConfigServiceErrorStub{}, &fileSystemStub{})
+
+	err := svc.Run(newOptions())
+
+	require.ErrorIs(t, err, errSomeTestError)
+	assert.Contains(t, err.Error(), "failed to parse module config")
+}
+
+func Test_Run_ReturnsError_WhenExistingReleaseMetaIsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			content: existingReleaseMeta + "status: {}\n",
+			wantErr: "failed to parse mrm.yaml",
+		},
+		{
+			name:    "wrong kind",
+			content: "kind: ModuleTemplate\n",
+			wantErr: `mrm.yaml contains kind "ModuleTemplate" instead of "ModuleReleaseMeta"`,
+		},
+		{
+			name:    "other module",
+			content: "kind: ModuleReleaseMeta\nspec:\n  moduleName: other-module\n",
+			wantErr: `mrm.yaml belongs to module "other-module" instead of "template-operator"`,
+		},
+		{
+			name: "mandatory module",
+			content: "kind: ModuleReleaseMeta\nspec:\n  moduleName: template-operator\n" +
+				"  mandatory:\n    version: 1.0.0\n",
+			wantErr: "mrm.yaml belongs to a mandatory module",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+				moduleConfig: newModuleConfig("1.0.0", "regular"),
+			}, &fileSystemStub{content: test.content})
+
+			err := svc.Run(newOptions())
+
+			require.ErrorIs(t, err, releasemeta.ErrInvalidReleaseMeta)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func Test_Run_ReturnsError_WhenFileSystemFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		fileSystem *fileSystemStub
+		wantErr    string
+	}{
+		{
+			name:       "exists check fails",
+			fileSystem: &fileSystemStub{existsErr: errSomeTestError},
+			wantErr:    "failed to check if ModuleReleaseMeta exists",
+		},
+		{
+			name:       "read fails",
+			fileSystem: &fileSystemStub{content: existingReleaseMeta, readErr: errSomeTestError},
+			wantErr:    "failed to read ModuleReleaseMeta",
+		},
+		{
+			name:       "write fails",
+			fileSystem: &fileSystemStub{writeErr: errSomeTestError},
+			wantErr:    "failed to write ModuleReleaseMeta",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svc, _ := releasemeta.NewService(&moduleConfigServiceStub{
+				moduleConfig: newModuleConfig("1.0.0", "regular"),
+			}, test.fileSystem)
+
+			err := svc.Run(newOptions())
+
+			require.ErrorIs(t, err, errSomeTestError)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func newModuleConfig(version string, channels ...string) *contentprovider.ModuleConfig {
+	return &contentprovider.ModuleConfig{
+		Name:     "kyma-project.io/module/template-operator",
+		Version:  version,
+		Beta:     true,
+		Channels: channels,
+	}
+}
+
+func newOptions() releasemeta.Options {
+	return releasemeta.Options{
+		Out:        iotools.NewDefaultOut(io.Discard),
+		ConfigFile: "module-config.yaml",
+		Output:     "mrm.yaml",
+	}
+}
+
+// Test Stubs
+
+var errSomeTestError = errors.New("some test error")
+
+type moduleConfigServiceStub struct {
+	moduleConfig *contentprovider.ModuleConfig
+}
+
+func (s *moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return s.moduleConfig, nil
+}
+
+type moduleConfigServiceErrorStub struct{}
+
+func (*moduleConfigServiceErrorStub) ParseAndValidateModuleConfig(_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return nil, errSomeTestError
+}
+
+type fileSystemStub struct {
+	content        string
+	existsErr      error
+	readErr        error
+	writeErr       error
+	writtenPath    string
+	writtenContent string
+}
+
+func (s *fileSystemStub) FileExists(_ string) (bool, error) {
+	return s.content != "", s.existsErr
+}
+
+func (s *fileSystemStub) ReadFile(_ string) ([]byte, error) {
+	if s.readErr != nil {
+		return nil, s.readErr
+	}
+	return []byte(s.content), nil
+}
+
+func (s *fileSystemStub) WriteFile(path, content string) error {
+	s.writtenPath = path
+	s.writtenContent = content
+	return s.writeErr
+}
+
+type outStub struct {
+	messages []string
+}
+
+func (s *outStub) Write(msg string) {
+	s.messages = append(s.messages, msg)
+}
@@ -31,7 +31,7 @@ func Test_Validate_Options(t *testing.T) {
 				ModuleName: "",
 			},
 			wantErr: true,
-			errMsg:  "opts.ModuleName must not be empty",
+			errMsg:  "opts.ModuleName: must not be empty",
 		},
 		{
 			name: "ModuleName exceeds length",
@@ -40,7 +40,7 @@ func Test_Validate_Options(t *testing.T) {
 				ModuleName: strings.Repeat("a", 256),
 			},
 			wantErr: true,
-			errMsg:  "opts.ModuleName length must not exceed",
+			errMsg:  "opts.ModuleName: length must not exceed",
 		},
 		{
 			name: "ModuleName invalid pattern",
@@ -49,7 +49,7 @@ func Test_Validate_Options(t *testing.T) {
 				ModuleName: "invalid_name",
 			},
 			wantErr: true,
-			errMsg:  "opts.ModuleName must match the required pattern",
+			errMsg:  "opts.ModuleName: must match the required pattern",
 		},
 		{
 			name: "Directory is empty",
@@ -70,7 +70,7 @@ func Test_Validate_Options(t *testing.T) {
 				ModuleVersion: "",
 			},
 			wantErr: true,
-			errMsg:  "opts.ModuleVersion must not be empty",
+			errMsg:  "opts.ModuleVersion: must not be empty",
 		},
 		{
 			name: "ModuleVersion invalid",
@@ -81,7 +81,7 @@ func Test_Validate_Options(t *testing.T) {
 				ModuleVersion: "invalid",
 			},
 			wantErr: true,
-			errMsg:  "opts.ModuleVersion failed to be parsed as semantic version",
+			errMsg:  "opts.ModuleVersion: failed to be parsed as semantic version",
 		},
 		{
 			name: "ModuleConfigFileName is empty",
@@ -0,0 +1,196 @@
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONSchema is the subset of JSON Schema draft-07 needed to describe the modulectl config files.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	MaxLength            int                    `json:"maxLength,omitempty"`
+	MinItems             int                    `json:"minItems,omitempty"`
+	MinProperties        int                    `json:"minProperties,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+}
+
+// fieldConstraint carries the checks of the validation package that the struct definition does not express.
+type fieldConstraint struct {
+	Pattern   string
+	Format    string
+	MaxLength int
+	Enum      []string
+	Required  bool
+}
+
+// generator derives a JSON Schema from a struct using its yaml and comment tags.
+// Types with a custom YAML representation are described by overrides, field constraints are keyed by
+// field path, e.g. "manager.namespace" or "associatedResources[].kind".
+type generator struct {
+	overrides   map[reflect.Type]func() *JSONSchema
+	constraints map[string]fieldConstraint
+	strict      bool
+}
+
+func (g *generator) generate(target reflect.Type, fieldPath string) *JSONSchema {
+	for target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if override, ok := g.overrides[target]; ok {
+		return override()
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		return g.generateObject(target, fieldPath)
+	case reflect.Slice, reflect.Array:
+		items := g.generate(target.Elem(), fieldPath+"[]")
+		if constraint, ok := g.constraints[fieldPath+"[]"]; ok {
+			constraint.applyTo(items)
+		}
+		return &JSONSchema{Type: "array", Items: items}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.generate(target.Elem(), fieldPath+"[]")}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	default:
+		return &JSONSchema{}
+	}
+}
+
+func (g *generator) generateObject(target reflect.Type, fieldPath string) *JSONSchema {
+	object := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+	if g.strict {
+		object.AdditionalProperties = false
+	}
+	g.addFields(object, target, fieldPath, false)
+	return object
+}
+
+// addFields adds the fields of the struct as properties of the object, inlined structs are flattened.
+func (g *generator) addFields(object *JSONSchema, target reflect.Type, fieldPath string, inlineRequired bool) {
+	for i := range target.NumField() {
+		field := target.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		comment := parseCommentTag(field.Tag.Get("comment"))
+
+		if strings.Contains(options, "inline") {
+			g.addFields(object, field.Type, fieldPath, comment.required)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		propertyPath := joinFieldPath(fieldPath, name)
+		property := g.generate(field.Type, propertyPath)
+		property.Description = comment.description
+		if comment.defaultValue != "" {
+			property.Default = parseDefault(comment.defaultValue, property.Type)
+		}
+
+		required := comment.required || inlineRequired
+		if constraint, ok := g.constraints[propertyPath]; ok {
+			constraint.applyTo(property)
+			required = required || constraint.Required
+		}
+		if required && !slices.Contains(object.Required, name) {
+			object.Required = append(object.Required, name)
+		}
+
+		object.Properties[name] = property
+	}
+}
+
+func (c fieldConstraint) applyTo(property *JSONSchema) {
+	if c.Pattern != "" {
+		property.Pattern = c.Pattern
+	}
+	if c.Format != "" {
+		property.Format = c.Format
+	}
+	if c.MaxLength > 0 {
+		property.MaxLength = c.MaxLength
+	}
+	if len(c.Enum) > 0 {
+		property.Enum = c.Enum
+	}
+}
+
+type commentTag struct {
+	required     bool
+	defaultValue string
+	description  string
+}
+
+// parseCommentTag splits a comment tag like "optional, default=false, indicates whether ..." into its markers
+// and the description. Leading type hints like "string" or "list" are dropped as the schema carries the type.
+func parseCommentTag(comment string) commentTag {
+	var tag commentTag
+	parts := strings.Split(comment, ", ")
+	for len(parts) > 0 {
+		part := strings.TrimSpace(parts[0])
+		switch {
+		case part == "required":
+			tag.required = true
+		case part == "optional", part == "string", part == "list":
+		case strings.HasPrefix(part, "default="):
+			tag.defaultValue = strings.TrimPrefix(part, "default=")
+		default:
+			tag.description = strings.Join(parts, ", ")
+			return tag
+		}
+		parts = parts[1:]
+	}
+	return tag
+}
+
+func parseDefault(value, schemaType string) any {
+	switch schemaType {
+	case "boolean":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	case "integer":
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	default:
+	}
+	return value
+}
+
+func joinFieldPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const (
+	KindModuleConfig   = "module-config"
+	KindSecurityConfig = "security-config"
+)
+
+// Kinds returns the config file kinds a schema can be generated for.
+func Kinds() []string {
+	return []string{KindModuleConfig, KindSecurityConfig}
+}
+
+type Options struct {
+	Out  iotools.Out
+	Kind string
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if !slices.Contains(Kinds(), opts.Kind) {
+		return fmt.Errorf("opts.Kind must be one of [%s], got %q: %w",
+			strings.Join(Kinds(), ", "), opts.Kind, commonerrors.ErrInvalidOption)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package schema_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/internal/service/schema"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func Test_Validate_Options(t *testing.T) {
+	tests := []struct {
+		name    string
+		options schema.Options
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "Out is nil",
+			options: schema.Options{Out: nil, Kind: schema.KindModuleConfig},
+			wantErr: true,
+			errMsg:  "opts.Out must not be nil",
+		},
+		{
+			name:    "Kind is empty",
+			options: schema.Options{Out: iotools.NewDefaultOut(io.Discard)},
+			wantErr: true,
+			errMsg:  `opts.Kind must be one of [module-config, security-config], got ""`,
+		},
+		{
+			name:    "Kind is unknown",
+			options: schema.Options{Out: iotools.NewDefaultOut(io.Discard), Kind: "manifest"},
+			wantErr: true,
+			errMsg:  `got "manifest"`,
+		},
+		{
+			name:    "All options are valid",
+			options: schema.Options{Out: iotools.NewDefaultOut(io.Discard), Kind: schema.KindSecurityConfig},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
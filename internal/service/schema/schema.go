@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+)
+
+const (
+	draft07SchemaURL = "http://json-schema.org/draft-07/schema#"
+
+	httpsURLPattern = "^https://"
+	// fileReferencePattern matches an https URL or a relative local file path.
+	fileReferencePattern = "^(https://.+|[^/:][^:]*)$"
+	// localPathPattern matches a relative local file path.
+	localPathPattern = "^[^/:][^:]*$"
+	// semanticVersionPattern is the pattern recommended by https://semver.org.
+	semanticVersionPattern = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+)
+
+type Service struct {
+	definitions map[string]func() *JSONSchema
+}
+
+func NewService() *Service {
+	return &Service{
+		definitions: map[string]func() *JSONSchema{
+			KindModuleConfig:   moduleConfigSchema,
+			KindSecurityConfig: securityConfigSchema,
+		},
+	}
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("validation failed for options: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.definitions[opts.Kind](), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s schema: %w", opts.Kind, err)
+	}
+
+	opts.Out.Write(string(data) + "\n")
+	return nil
+}
+
+func moduleConfigSchema() *JSONSchema {
+	gen := &generator{
+		overrides: map[reflect.Type]func() *JSONSchema{
+			reflect.TypeFor[contentprovider.UrlOrLocalFile](): func() *JSONSchema {
+				return &JSONSchema{Type: "string", Pattern: fileReferencePattern}
+			},
+			reflect.TypeFor[contentprovider.UrlOrLocalFiles](): func() *JSONSchema {
+				return &JSONSchema{OneOf: []*JSONSchema{
+					{Type: "string", Pattern: fileReferencePattern},
+					{Type: "array", MinItems: 1, Items: &JSONSchema{Type: "string", Pattern: fileReferencePattern}},
+				}}
+			},
+			reflect.TypeFor[contentprovider.Icons](): func() *JSONSchema {
+				return nameLinkSchema(1)
+			},
+			reflect.TypeFor[contentprovider.Resources](): func() *JSONSchema {
+				return nameLinkSchema(0)
+			},
+		},
+		constraints: map[string]fieldConstraint{
+			"name": {
+				Pattern:   validation.ModuleNamePattern,
+				MaxLength: validation.ModuleNameMaxLength,
+			},
+			"version":       {Pattern: semanticVersionPattern},
+			"repository":    {Pattern: httpsURLPattern, Format: "uri"},
+			"documentation": {Pattern: httpsURLPattern, Format: "uri"},
+			"manager.namespace": {
+				Pattern:   validation.NamespacePattern,
+				MaxLength: validation.NamespaceMaxLength,
+			},
+			"chart.path":          {Pattern: localPathPattern},
+			"kustomization":       {Pattern: localPathPattern},
+			"chart.valuesFiles[]": {Pattern: localPathPattern},
+			"chart.namespace": {
+				Pattern:   validation.NamespacePattern,
+				MaxLength: validation.NamespaceMaxLength,
+			},
+			"channels[]":                    {Pattern: validation.ChannelPattern},
+			"associatedResources[].group":   {Required: true},
+			"associatedResources[].version": {Required: true},
+			"associatedResources[].kind":    {Required: true},
+		},
+		strict: true,
+	}
+
+	schema := gen.generate(reflect.TypeFor[contentprovider.ModuleConfig](), "")
+	// the manifest is either referenced directly or rendered from a chart or a kustomization
+	schema.OneOf = []*JSONSchema{
+		{Required: []string{"manifest"}},
+		{Required: []string{"chart"}},
+		{Required: []string{"kustomization"}},
+	}
+	schema.Schema = draft07SchemaURL
+	schema.Title = "Kyma module config"
+	schema.Description = "The module config file used by the modulectl create and validate commands."
+	return schema
+}
+
+func securityConfigSchema() *JSONSchema {
+	gen := &generator{}
+
+	schema := gen.generate(reflect.TypeFor[contentprovider.SecurityScanConfig](), "")
+	schema.Schema = draft07SchemaURL
+	schema.Title = "Kyma module security scanners config"
+	schema.Description = "The security scanners config file referenced by the security field of the module config."
+	return schema
+}
+
+// nameLinkSchema describes Icons and Resources, which accept a list of name/link entries or a map of names to links.
+func nameLinkSchema(minEntries int) *JSONSchema {
+	link := &JSONSchema{Type: "string", Format: "uri", Pattern: httpsURLPattern}
+	return &JSONSchema{
+		OneOf: []*JSONSchema{
+			{
+				Type:     "array",
+				MinItems: minEntries,
+				Items: &JSONSchema{
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"name": {Type: "string", Description: "the name of the entry"},
+						"link": {Type: "string", Description: "the link of the entry", Format: "uri",
+							Pattern: httpsURLPattern},
+					},
+					Required:             []string{"name", "link"},
+					AdditionalProperties: false,
+				},
+			},
+			{
+				Type:                 "object",
+				MinProperties:        minEntries,
+				AdditionalProperties: link,
+			},
+		},
+	}
+}
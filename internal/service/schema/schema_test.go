@@ -0,0 +1,155 @@
+package schema_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/schema"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func Test_Run_ReturnsError_WhenOptionsAreInvalid(t *testing.T) {
+	svc := schema.NewService()
+
+	err := svc.Run(schema.Options{Out: iotools.NewDefaultOut(&bytes.Buffer{}), Kind: "manifest"})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+}
+
+func Test_Run_WritesModuleConfigSchema(t *testing.T) {
+	moduleConfigSchema := runSchema(t, schema.KindModuleConfig)
+
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", moduleConfigSchema.Schema)
+	assert.Equal(t, "object", moduleConfigSchema.Type)
+	assert.Equal(t, false, moduleConfigSchema.AdditionalProperties)
+	assert.Equal(t, []string{"name", "version", "repository", "documentation", "icons"},
+		moduleConfigSchema.Required)
+	require.Len(t, moduleConfigSchema.OneOf, 3)
+	assert.Equal(t, []string{"manifest"}, moduleConfigSchema.OneOf[0].Required)
+	assert.Equal(t, []string{"chart"}, moduleConfigSchema.OneOf[1].Required)
+	assert.Equal(t, []string{"kustomization"}, moduleConfigSchema.OneOf[2].Required)
+	assert.NotRegexp(t, moduleConfigSchema.Properties["kustomization"].Pattern, "/config/default")
+
+	name := moduleConfigSchema.Properties["name"]
+	assert.Equal(t, "string", name.Type)
+	assert.Equal(t, "the name of the module", name.Description)
+	assert.Equal(t, 255, name.MaxLength)
+	assert.Regexp(t, name.Pattern, "kyma-project.io/module/template-operator")
+	assert.NotRegexp(t, name.Pattern, "template-operator")
+
+	version := moduleConfigSchema.Properties["version"]
+	assert.Regexp(t, version.Pattern, "1.0.0-rc.1+build")
+	assert.NotRegexp(t, version.Pattern, "v1.0")
+
+	manifest := moduleConfigSchema.Properties["manifest"].OneOf[0]
+	assert.Equal(t, "string", manifest.Type)
+	assert.Regexp(t, manifest.Pattern, "https://example.com/manifest.yaml")
+	assert.Regexp(t, manifest.Pattern, "path/to/manifest.yaml")
+	assert.Regexp(t, manifest.Pattern, "manifests/*.yaml")
+	assert.NotRegexp(t, manifest.Pattern, "http://example.com/manifest.yaml")
+	assert.NotRegexp(t, manifest.Pattern, "/path/to/manifest.yaml")
+	manifestList := moduleConfigSchema.Properties["manifest"].OneOf[1]
+	assert.Equal(t, "array", manifestList.Type)
+	assert.Equal(t, 1, manifestList.MinItems)
+	assert.Equal(t, manifest.Pattern, manifestList.Items.Pattern)
+
+	requiresDowntime := moduleConfigSchema.Properties["requiresDowntime"]
+	assert.Equal(t, "boolean", requiresDowntime.Type)
+	assert.Equal(t, false, requiresDowntime.Default)
+	assert.Equal(t, "indicates whether the module requires downtime to support maintenance windows "+
+		"during module upgrades", requiresDowntime.Description)
+	assert.Equal(t, true, moduleConfigSchema.Properties["securityScanEnabled"].Default)
+
+	channels := moduleConfigSchema.Properties["channels"]
+	assert.Equal(t, "array", channels.Type)
+	assert.Regexp(t, channels.Items.Pattern, "regular")
+	assert.NotRegexp(t, channels.Items.Pattern, "Fast")
+
+	icons := moduleConfigSchema.Properties["icons"]
+	require.Len(t, icons.OneOf, 2)
+	assert.Equal(t, "array", icons.OneOf[0].Type)
+	assert.Equal(t, 1, icons.OneOf[0].MinItems)
+	assert.Equal(t, []string{"name", "link"}, icons.OneOf[0].Items.Required)
+	assert.Equal(t, "^https://", icons.OneOf[0].Items.Properties["link"].Pattern)
+	assert.Equal(t, "object", icons.OneOf[1].Type)
+	assert.Equal(t, 0, moduleConfigSchema.Properties["resources"].OneOf[0].MinItems)
+
+	manager := moduleConfigSchema.Properties["manager"]
+	assert.Equal(t, []string{"group", "version", "kind", "name"}, manager.Required)
+	// the reader accepts any manager kind, so the schema must not restrict it
+	assert.Empty(t, manager.Properties["kind"].Enum)
+	assert.Regexp(t, manager.Properties["namespace"].Pattern, "kcp-system")
+	assert.NotRegexp(t, manager.Properties["namespace"].Pattern, "Invalid_Namespace")
+
+	chart := moduleConfigSchema.Properties["chart"]
+	assert.Equal(t, []string{"path", "releaseName", "namespace"}, chart.Required)
+	assert.Regexp(t, chart.Properties["path"].Pattern, "charts/sample-0.1.0.tgz")
+	assert.NotRegexp(t, chart.Properties["path"].Pattern, "/charts/sample")
+	assert.NotRegexp(t, chart.Properties["valuesFiles"].Items.Pattern, "https://example.com/values.yaml")
+	assert.NotRegexp(t, chart.Properties["namespace"].Pattern, "Invalid_Namespace")
+
+	associatedResources := moduleConfigSchema.Properties["associatedResources"]
+	assert.Equal(t, "array", associatedResources.Type)
+	assert.Equal(t, []string{"group", "version", "kind"}, associatedResources.Items.Required)
+}
+
+func Test_Run_WritesSecurityConfigSchema(t *testing.T) {
+	securityConfigSchema := runSchema(t, schema.KindSecurityConfig)
+
+	assert.Equal(t, "object", securityConfigSchema.Type)
+	assert.Nil(t, securityConfigSchema.AdditionalProperties)
+	assert.Empty(t, securityConfigSchema.Required)
+	assert.Equal(t, "name of your module", securityConfigSchema.Properties["module-name"].Description)
+	assert.Equal(t, "array", securityConfigSchema.Properties["bdba"].Type)
+	assert.Equal(t, "string", securityConfigSchema.Properties["bdba"].Items.Type)
+
+	mend := securityConfigSchema.Properties["mend"]
+	assert.Equal(t, "Mend security scanner specific configuration", mend.Description)
+	assert.Equal(t, "object", mend.Type)
+	assert.Contains(t, mend.Properties, "language")
+	assert.Contains(t, mend.Properties, "subprojects")
+	assert.Contains(t, mend.Properties, "exclude")
+}
+
+func Test_Run_PatternsCompile(t *testing.T) {
+	for _, kind := range schema.Kinds() {
+		assertPatternsCompile(t, runSchema(t, kind))
+	}
+}
+
+func runSchema(t *testing.T, kind string) *schema.JSONSchema {
+	t.Helper()
+	buffer := &bytes.Buffer{}
+	svc := schema.NewService()
+
+	err := svc.Run(schema.Options{Out: iotools.NewDefaultOut(buffer), Kind: kind})
+	require.NoError(t, err)
+
+	var result schema.JSONSchema
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &result))
+	return &result
+}
+
+func assertPatternsCompile(t *testing.T, jsonSchema *schema.JSONSchema) {
+	t.Helper()
+	if jsonSchema == nil {
+		return
+	}
+	if jsonSchema.Pattern != "" {
+		_, err := regexp.Compile(jsonSchema.Pattern)
+		require.NoError(t, err, jsonSchema.Pattern)
+	}
+	for _, property := range jsonSchema.Properties {
+		assertPatternsCompile(t, property)
+	}
+	for _, option := range jsonSchema.OneOf {
+		assertPatternsCompile(t, option)
+	}
+	assertPatternsCompile(t, jsonSchema.Items)
+}
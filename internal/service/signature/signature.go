@@ -0,0 +1,121 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/cpi"
+	ocmsigning "ocm.software/ocm/api/ocm/tools/signing"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+)
+
+// DefaultName is the name of the signature if no other name is configured.
+const DefaultName = "kyma-module"
+
+var (
+	ErrSignatureNotFound  = errors.New("component version has no signature")
+	ErrVerificationFailed = errors.New("signature verification failed")
+)
+
+type FileSystem interface {
+	ReadFile(path string) ([]byte, error)
+}
+
+// SigningConfig configures the key a component version is signed with. The certificate file is optional and
+// contains the certificate chain of the key, which is embedded in the signature.
+type SigningConfig struct {
+	Name            string
+	PrivateKeyFile  string
+	CertificateFile string
+}
+
+// Service signs component versions with RSA keys and verifies their signatures.
+type Service struct {
+	fileSystem FileSystem
+}
+
+func NewService(fileSystem FileSystem) (*Service, error) {
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		fileSystem: fileSystem,
+	}, nil
+}
+
+// Sign calculates the digests of the component version, signs it and adds the signature to its descriptor.
+// It returns the signed descriptor.
+func (s *Service) Sign(componentVersion cpi.ComponentVersionAccess, config SigningConfig,
+) (*compdesc.ComponentDescriptor, error) {
+	privateKey, err := s.fileSystem.ReadFile(config.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	options := []ocmsigning.Option{
+		ocmsigning.Sign(signing.DefaultHandlerRegistry().GetSigner(rsa.Algorithm), config.Name),
+		ocmsigning.PrivateKey(config.Name, privateKey),
+		ocmsigning.Update(),
+	}
+	if config.CertificateFile != "" {
+		certificate, err := s.fileSystem.ReadFile(config.CertificateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate: %w", err)
+		}
+		options = append(options, ocmsigning.PublicKey(config.Name, certificate))
+	}
+
+	if _, err = ocmsigning.SignComponentVersion(componentVersion, config.Name, options...); err != nil {
+		return nil, fmt.Errorf("failed to sign component version %s:%s: %w", componentVersion.GetName(),
+			componentVersion.GetVersion(), err)
+	}
+	return componentVersion.GetDescriptor(), nil
+}
+
+// VerifyComponentVersion verifies the digests of the component version and its signature with the given name
+// against the public key.
+func (s *Service) VerifyComponentVersion(componentVersion cpi.ComponentVersionAccess,
+	name, publicKeyFile string,
+) error {
+	if componentVersion.GetDescriptor().GetSignatureIndex(name) < 0 {
+		return fmt.Errorf("%w: %s", ErrSignatureNotFound, name)
+	}
+
+	publicKey, err := s.fileSystem.ReadFile(publicKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	if _, err = ocmsigning.VerifyComponentVersion(componentVersion, name,
+		ocmsigning.PublicKey(name, publicKey)); err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	return nil
+}
+
+// VerifyDescriptor verifies the signature with the given name of a descriptor without access to its resources,
+// e.g. the descriptor rendered into a ModuleTemplate. The digests of the resources are part of the signed content,
+// but are not recalculated.
+func (s *Service) VerifyDescriptor(descriptor *compdesc.ComponentDescriptor, name, publicKeyFile string) error {
+	if descriptor.GetSignatureIndex(name) < 0 {
+		return fmt.Errorf("%w: %s", ErrSignatureNotFound, name)
+	}
+
+	publicKey, err := s.fileSystem.ReadFile(publicKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	keys := signing.NewKeyRegistry()
+	keys.RegisterPublicKey(name, publicKey)
+	if err = compdesc.Verify(descriptor, signing.NewRegistry(signing.DefaultHandlerRegistry(), keys),
+		name); err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package signature_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/stretchr/testify/require"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/service/componentarchive"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	"github.com/kyma-project/modulectl/internal/testutils"
+	"github.com/kyma-project/modulectl/tools/filesystem"
+)
+
+func TestNewService_ReturnsError_WhenFileSystemIsNil(t *testing.T) {
+	_, err := signature.NewService(nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+}
+
+func TestSign_SignsComponentVersion_ThatVerifiesWithPublicKey(t *testing.T) {
+	privateKeyFile, publicKeyFile := writeKeyPair(t)
+	svc := newService(t)
+	archive := newComponentArchive(t)
+
+	descriptor, err := svc.Sign(archive, signature.SigningConfig{
+		Name:           signature.DefaultName,
+		PrivateKeyFile: privateKeyFile,
+	})
+
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, descriptor.GetSignatureIndex(signature.DefaultName), 0)
+	require.NoError(t, svc.VerifyComponentVersion(archive, signature.DefaultName, publicKeyFile))
+	require.NoError(t, svc.VerifyDescriptor(descriptor.Copy(), signature.DefaultName, publicKeyFile))
+}
+
+func TestVerify_ReturnsError_WhenPublicKeyDoesNotMatch(t *testing.T) {
+	privateKeyFile, _ := writeKeyPair(t)
+	_, otherPublicKeyFile := writeKeyPair(t)
+	svc := newService(t)
+	archive := newComponentArchive(t)
+	descriptor, err := svc.Sign(archive, signature.SigningConfig{
+		Name:           signature.DefaultName,
+		PrivateKeyFile: privateKeyFile,
+	})
+	require.NoError(t, err)
+
+	err = svc.VerifyComponentVersion(archive, signature.DefaultName, otherPublicKeyFile)
+	require.ErrorIs(t, err, signature.ErrVerificationFailed)
+
+	err = svc.VerifyDescriptor(descriptor.Copy(), signature.DefaultName, otherPublicKeyFile)
+	require.ErrorIs(t, err, signature.ErrVerificationFailed)
+}
+
+func TestVerifyDescriptor_ReturnsError_WhenSignatureIsMissing(t *testing.T) {
+	_, publicKeyFile := writeKeyPair(t)
+
+	err := newService(t).VerifyDescriptor(testutils.CreateComponentDescriptor("kyma-project.io/module/test", "1.0.0"),
+		signature.DefaultName, publicKeyFile)
+
+	require.ErrorIs(t, err, signature.ErrSignatureNotFound)
+}
+
+func TestSign_ReturnsError_WhenPrivateKeyFileDoesNotExist(t *testing.T) {
+	_, err := newService(t).Sign(newComponentArchive(t), signature.SigningConfig{
+		Name:           signature.DefaultName,
+		PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+	})
+
+	require.ErrorContains(t, err, "failed to read private key")
+}
+
+func newService(t *testing.T) *signature.Service {
+	t.Helper()
+	svc, err := signature.NewService(&filesystem.Helper{})
+	require.NoError(t, err)
+	return svc
+}
+
+func newComponentArchive(t *testing.T) *comparch.ComponentArchive {
+	t.Helper()
+	archiveFileSystem, err := filesystem.NewArchiveFileSystem(memoryfs.New(), osfs.New())
+	require.NoError(t, err)
+	componentArchiveService, err := componentarchive.NewService(archiveFileSystem)
+	require.NoError(t, err)
+
+	archive, err := componentArchiveService.CreateComponentArchive(
+		testutils.CreateComponentDescriptor("kyma-project.io/module/test", "1.0.0"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = archive.Close() })
+	return archive
+}
+
+func writeKeyPair(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privateKeyFile, publicKeyFile := filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+	require.NoError(t, os.WriteFile(privateKeyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600))
+	require.NoError(t, os.WriteFile(publicKeyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0o600))
+	return privateKeyFile, publicKeyFile
+}
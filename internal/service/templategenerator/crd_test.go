@@ -0,0 +1,79 @@
+package templategenerator_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/kyma-project/modulectl/internal/service/crdschema"
+)
+
+// The embedded ModuleTemplate CRD only contains the served version, so it is checked against the API types of the
+// pinned lifecycle-manager dependency. A failure means the dependency was updated without updating the CRD.
+func Test_ModuleTemplateCRD_MatchesLifecycleManagerAPI(t *testing.T) {
+	data, err := os.ReadFile("crd/operator.kyma-project.io_moduletemplates.yaml")
+	require.NoError(t, err)
+	crd, err := crdschema.ParseCRD(data)
+	require.NoError(t, err)
+	require.Len(t, crd.Spec.Versions, 1)
+	version := crd.Spec.Versions[0]
+	require.Equal(t, v1beta2.GroupVersion.Version, version.Name)
+	require.Equal(t, v1beta2.GroupVersion.Group, crd.Spec.Group)
+
+	schemaFields := map[string]struct{}{}
+	collectSchemaFields(version.Schema.OpenAPIV3Schema.Properties["spec"], "spec", schemaFields)
+	typeFields := map[string]struct{}{}
+	collectTypeFields(reflect.TypeFor[v1beta2.ModuleTemplateSpec](), "spec", typeFields)
+
+	require.Equal(t, typeFields, schemaFields)
+}
+
+func collectSchemaFields(props apiextensionsv1.JSONSchemaProps, path string, fields map[string]struct{}) {
+	if props.XPreserveUnknownFields != nil && *props.XPreserveUnknownFields {
+		return
+	}
+	if props.Items != nil && props.Items.Schema != nil {
+		collectSchemaFields(*props.Items.Schema, path+"[]", fields)
+	}
+	for name, property := range props.Properties {
+		fields[path+"."+name] = struct{}{}
+		collectSchemaFields(property, path+"."+name, fields)
+	}
+}
+
+// collectTypeFields collects the JSON field paths of the type. Types with a custom JSON encoding, e.g. raw
+// extensions, are opaque like the preserved unknown fields of the schema.
+func collectTypeFields(typ reflect.Type, path string, fields map[string]struct{}) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	marshaler := reflect.TypeFor[json.Marshaler]()
+	if typ.Implements(marshaler) || reflect.PointerTo(typ).Implements(marshaler) {
+		return
+	}
+
+	switch typ.Kind() { //nolint:exhaustive // only the composite kinds have nested fields
+	case reflect.Slice:
+		collectTypeFields(typ.Elem(), path+"[]", fields)
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" && (field.Anonymous || strings.Contains(options, "inline")) {
+				collectTypeFields(field.Type, path, fields)
+				continue
+			}
+			fields[path+"."+name] = struct{}{}
+			collectTypeFields(field.Type, path+"."+name, fields)
+		}
+	}
+}
@@ -1,31 +1,44 @@
 package templategenerator
 
 import (
-	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
-	"text/template"
 
 	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/ocm/compdesc"
 	"sigs.k8s.io/yaml"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/crdschema"
+
+	_ "embed"
 )
 
 var ErrEmptyModuleConfig = errors.New("can not generate module template from empty module config")
 
+//go:embed crd/operator.kyma-project.io_moduletemplates.yaml
+var moduleTemplateCRD []byte
+
 type FileSystem interface {
 	WriteFile(path, content string) error
 }
 
 type Service struct {
 	fileSystem FileSystem
+	validator  *crdschema.Validator
 }
 
 func NewService(fileSystem FileSystem) (*Service, error) {
@@ -33,179 +46,178 @@ func NewService(fileSystem FileSystem) (*Service, error) {
 		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
 	}
 
+	validator, err := newModuleTemplateValidator()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Service{
 		fileSystem: fileSystem,
+		validator:  validator,
 	}, nil
 }
 
-const (
-	modTemplate = `apiVersion: operator.kyma-project.io/v1beta2
-kind: ModuleTemplate
-metadata:
-  name: {{.ResourceName}}
-  namespace: ""
-{{- with .Labels}}
-  labels:
-    {{- range $key, $value := . }}
-    {{ printf "%q" $key }}: {{ printf "%q" $value }}
-    {{- end}}
-{{- end}} 
-{{- with .Annotations}}
-  annotations:
-    {{- range $key, $value := . }}
-    {{ printf "%q" $key }}: {{ printf "%q" $value }}
-    {{- end}}
-{{- end}} 
-spec:
-  moduleName: {{.ModuleName}}
-  version: {{.ModuleVersion}}
-  requiresDowntime: {{.RequiresDowntime}}
-  info:
-    repository: {{.Repository}}
-    documentation: {{.Documentation}}
-    {{- with .Icons}}
-    icons:
-      {{- range $key, $value := . }}
-    - name: {{ $key }}
-      link: {{ $value }}
-      {{- end}}
-    {{- end}}
-{{- with .AssociatedResources}}
-  associatedResources:
-  {{- range .}}
-  - group: {{.Group}}
-    version: {{.Version}}
-    kind: {{.Kind}}
-  {{- end}}
-{{- end}}
-{{- with .Data}}
-  data:
-{{. | indent 4}}
-{{- end}}
-{{- with .Manager}}
-  manager:
-    name: {{.Name}}
-    {{- if .Namespace}}      
-    namespace: {{.Namespace}}
-    {{- end}}
-    group: {{.GroupVersionKind.Group}}
-    version: {{.GroupVersionKind.Version}}
-    kind: {{.GroupVersionKind.Kind}}
-{{- end}}
-{{- if .Descriptor}}
-  descriptor:
-{{yaml .Descriptor | printf "%s" | indent 4}}
-{{- else}}
-  descriptor: {}
-{{- end}}
-{{- with .Resources}}
-  resources:
-    {{- range $key, $value := . }}
-  - name: {{ $key }}
-    link: {{ $value }}
-    {{- end}}
-{{- end}}
-`
-)
+func newModuleTemplateValidator() (*crdschema.Validator, error) {
+	crd, err := crdschema.ParseCRD(moduleTemplateCRD)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ModuleTemplate CRD: %w", err)
+	}
 
-type moduleTemplateData struct {
-	ModuleName          string
-	ResourceName        string
-	Namespace           string
-	ModuleVersion       string
-	Descriptor          *compdesc.ComponentDescriptorVersion
-	Repository          string
-	Documentation       string
-	Icons               contentprovider.Icons
-	Labels              map[string]string
-	Annotations         map[string]string
-	Data                string
-	AssociatedResources []*metav1.GroupVersionKind
-	Resources           contentprovider.Resources
-	Manager             *contentprovider.Manager
-	RequiresDowntime    bool
+	validator, err := crdschema.NewValidator(crd, v1beta2.GroupVersion.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ModuleTemplate validator: %w", err)
+	}
+	return validator, nil
 }
 
+// GenerateModuleTemplate generates the ModuleTemplate of the module and writes it to the templateOutput file.
+// The ModuleTemplate is validated against the ModuleTemplate CRD before it is written.
+// The provenanceAnnotations are added to the annotations of the module config.
+// If reproducible is set, the digest of the ModuleTemplate content is added as annotation.
 func (s *Service) GenerateModuleTemplate(
 	moduleConfig *contentprovider.ModuleConfig,
 	descriptorToRender *compdesc.ComponentDescriptor,
 	data []byte,
 	isCrdClusterScoped bool,
+	provenanceAnnotations map[string]string,
 	templateOutput string,
+	reproducible bool,
 ) error {
 	if moduleConfig == nil {
 		return ErrEmptyModuleConfig
 	}
 
+	moduleTemplate, err := buildModuleTemplate(moduleConfig, descriptorToRender, data, isCrdClusterScoped,
+		provenanceAnnotations)
+	if err != nil {
+		return err
+	}
+
+	if reproducible {
+		if err = addContentDigest(moduleTemplate); err != nil {
+			return err
+		}
+	}
+
+	if err = s.validator.ValidateJSON(moduleTemplate); err != nil {
+		return fmt.Errorf("generated module template is invalid: %w", err)
+	}
+
+	content, err := yaml.Marshal(moduleTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal module template: %w", err)
+	}
+
+	if err = s.fileSystem.WriteFile(templateOutput, string(content)); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func buildModuleTemplate(moduleConfig *contentprovider.ModuleConfig,
+	descriptorToRender *compdesc.ComponentDescriptor,
+	data []byte,
+	isCrdClusterScoped bool,
+	provenanceAnnotations map[string]string,
+) (*v1beta2.ModuleTemplate, error) {
 	labels := generateLabels(moduleConfig)
-	annotations := generateAnnotations(moduleConfig, isCrdClusterScoped)
+	annotations := generateAnnotations(moduleConfig, isCrdClusterScoped, provenanceAnnotations)
 
 	ref, err := oci.ParseRef(moduleConfig.Name)
 	if err != nil {
-		return fmt.Errorf("failed to parse ref: %w", err)
+		return nil, fmt.Errorf("failed to parse ref: %w", err)
 	}
 	shortName := trimShortNameFromRef(ref)
 	labels[shared.ModuleName] = shortName
-	moduleTemplateName := shortName + "-" + moduleConfig.Version
 
-	moduleTemplate, err := template.New("moduleTemplate").Funcs(template.FuncMap{
-		"yaml":   yaml.Marshal,
-		"indent": indent,
-	}).Parse(modTemplate)
+	descriptor, err := marshalDescriptor(descriptorToRender)
 	if err != nil {
-		return fmt.Errorf("failed to parse module template: %w", err)
+		return nil, err
 	}
 
-	covertedDescriptor, err := ConvertDescriptorIfNotNil(descriptorToRender)
-	if err != nil {
-		return err
-	}
-
-	mtData := moduleTemplateData{
-		ModuleName:          shortName,
-		ResourceName:        moduleTemplateName,
-		ModuleVersion:       moduleConfig.Version,
-		Descriptor:          covertedDescriptor,
-		Repository:          moduleConfig.Repository,
-		Documentation:       moduleConfig.Documentation,
-		Icons:               moduleConfig.Icons,
-		Labels:              labels,
-		Annotations:         annotations,
-		AssociatedResources: moduleConfig.AssociatedResources,
-		Manager:             moduleConfig.Manager,
-		RequiresDowntime:    moduleConfig.RequiresDowntime,
-	}
-	if moduleConfig.Manifest.IsURL() {
-		mtData.Resources = contentprovider.Resources{
-			// defaults rawManifest to Manifest; may be overwritten by explicitly provided entries
-			"rawManifest": moduleConfig.Manifest.String(),
-		}
+	moduleTemplate := &v1beta2.ModuleTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta2.GroupVersion.String(),
+			Kind:       string(shared.ModuleTemplateKind),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        shortName + "-" + moduleConfig.Version,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: v1beta2.ModuleTemplateSpec{
+			ModuleName:       shortName,
+			Version:          moduleConfig.Version,
+			RequiresDowntime: moduleConfig.RequiresDowntime,
+			Info: &v1beta2.ModuleInfo{
+				Repository:    moduleConfig.Repository,
+				Documentation: moduleConfig.Documentation,
+				Icons:         generateIcons(moduleConfig.Icons),
+			},
+			AssociatedResources: generateAssociatedResources(moduleConfig.AssociatedResources),
+			Manager:             generateManager(moduleConfig.Manager),
+			Descriptor:          runtime.RawExtension{Raw: descriptor},
+		},
 	}
 
 	if len(data) > 0 {
 		crData, err := parseDefaultCRYaml(data)
 		if err != nil {
-			return fmt.Errorf("failed to parse cr data: %w", err)
+			return nil, fmt.Errorf("failed to parse cr data: %w", err)
+		}
+		if len(crData) > 0 {
+			moduleTemplate.Spec.Data = &unstructured.Unstructured{Object: crData}
 		}
-		mtData.Data = string(crData)
 	}
 
-	if len(moduleConfig.Resources) > 0 {
-		mtData.Resources = copyEntries(mtData.Resources, moduleConfig.Resources)
+	var resources contentprovider.Resources
+	// only a single manifest URL can be linked, other manifests are only available as raw-manifest resource
+	if manifests := moduleConfig.Manifest.Entries(); len(manifests) == 1 && manifests[0].IsURL() {
+		resources = contentprovider.Resources{
+			// defaults rawManifest to Manifest; may be overwritten by explicitly provided entries
+			"rawManifest": manifests[0].String(),
+		}
 	}
+	resources = copyEntries(resources, moduleConfig.Resources)
+	moduleTemplate.Spec.Resources = generateResources(resources)
 
-	w := &bytes.Buffer{}
-	if err = moduleTemplate.Execute(w, mtData); err != nil {
-		return fmt.Errorf("failed to execute template, %w", err)
-	}
+	return moduleTemplate, nil
+}
 
-	if err = s.fileSystem.WriteFile(templateOutput, w.String()); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// addContentDigest annotates the ModuleTemplate with the sha256 digest of its serialization without the annotation.
+// The serialization is deterministic, so the digest only changes if the content of the ModuleTemplate changes.
+func addContentDigest(moduleTemplate *v1beta2.ModuleTemplate) error {
+	delete(moduleTemplate.Annotations, common.ContentDigestAnnotationKey)
+
+	content, err := yaml.Marshal(moduleTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal module template for content digest: %w", err)
 	}
 
+	digest := sha256.Sum256(content)
+	moduleTemplate.Annotations[common.ContentDigestAnnotationKey] = "sha256:" + hex.EncodeToString(digest[:])
 	return nil
 }
 
+// marshalDescriptor returns the JSON representation of the descriptor, an empty object if there is none.
+func marshalDescriptor(descriptorToRender *compdesc.ComponentDescriptor) ([]byte, error) {
+	convertedDescriptor, err := ConvertDescriptorIfNotNil(descriptorToRender)
+	if err != nil {
+		return nil, err
+	}
+
+	if convertedDescriptor == nil {
+		return []byte("{}"), nil
+	}
+
+	descriptor, err := json.Marshal(*convertedDescriptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal descriptor: %w", err)
+	}
+	return descriptor, nil
+}
+
 func ConvertDescriptorIfNotNil(
 	descriptorToRender *compdesc.ComponentDescriptor,
 ) (*compdesc.ComponentDescriptorVersion, error) {
@@ -220,18 +232,13 @@ func ConvertDescriptorIfNotNil(
 	return covertedDescriptor, nil
 }
 
-func parseDefaultCRYaml(data []byte) ([]byte, error) {
-	var crData map[string]interface{}
+func parseDefaultCRYaml(data []byte) (map[string]any, error) {
+	var crData map[string]any
 	if err := yaml.Unmarshal(data, &crData); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cr data: %w", err)
 	}
 
-	cr, err := yaml.Marshal(crData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal cr data yaml: %w", err)
-	}
-
-	return cr, nil
+	return crData, nil
 }
 
 func generateLabels(config *contentprovider.ModuleConfig) map[string]string {
@@ -252,8 +259,12 @@ func generateLabels(config *contentprovider.ModuleConfig) map[string]string {
 	return labels
 }
 
-func generateAnnotations(config *contentprovider.ModuleConfig, isCrdClusterScoped bool) map[string]string {
-	annotations := config.Annotations
+func generateAnnotations(config *contentprovider.ModuleConfig,
+	isCrdClusterScoped bool,
+	provenanceAnnotations map[string]string,
+) map[string]string {
+	annotations := copyEntries(nil, config.Annotations)
+	annotations = copyEntries(annotations, provenanceAnnotations)
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
@@ -265,24 +276,54 @@ func generateAnnotations(config *contentprovider.ModuleConfig, isCrdClusterScope
 	return annotations
 }
 
-func indent(spaces int, input string) string {
-	out := strings.Builder{}
+// generateIcons returns the icons sorted by name.
+func generateIcons(icons contentprovider.Icons) []v1beta2.ModuleIcon {
+	if len(icons) == 0 {
+		return nil
+	}
 
-	lines := strings.Split(input, "\n")
+	result := make([]v1beta2.ModuleIcon, 0, len(icons))
+	for _, name := range slices.Sorted(maps.Keys(icons)) {
+		result = append(result, v1beta2.ModuleIcon{Name: name, Link: icons[name]})
+	}
+	return result
+}
 
-	// remove empty line at the end of the file if any
-	if len(strings.TrimSpace(lines[len(lines)-1])) == 0 {
-		lines = lines[:len(lines)-1]
+// generateResources returns the resources sorted by name.
+func generateResources(resources contentprovider.Resources) []v1beta2.Resource {
+	if len(resources) == 0 {
+		return nil
 	}
 
-	for i, line := range lines {
-		out.WriteString(strings.Repeat(" ", spaces))
-		out.WriteString(line)
-		if i < len(lines)-1 {
-			out.WriteString("\n")
-		}
+	result := make([]v1beta2.Resource, 0, len(resources))
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		result = append(result, v1beta2.Resource{Name: name, Link: resources[name]})
+	}
+	return result
+}
+
+func generateAssociatedResources(associatedResources []*metav1.GroupVersionKind) []metav1.GroupVersionKind {
+	if len(associatedResources) == 0 {
+		return nil
+	}
+
+	result := make([]metav1.GroupVersionKind, 0, len(associatedResources))
+	for _, gvk := range associatedResources {
+		result = append(result, *gvk)
+	}
+	return result
+}
+
+func generateManager(manager *contentprovider.Manager) *v1beta2.Manager {
+	if manager == nil {
+		return nil
+	}
+
+	return &v1beta2.Manager{
+		GroupVersionKind: manager.GroupVersionKind,
+		Namespace:        manager.Namespace,
+		Name:             manager.Name,
 	}
-	return out.String()
 }
 
 func trimShortNameFromRef(ref oci.RefSpec) string {
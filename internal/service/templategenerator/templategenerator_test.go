@@ -4,11 +4,16 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kyma-project/lifecycle-manager/api/shared"
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"ocm.software/ocm/api/ocm/compdesc"
+	"sigs.k8s.io/yaml"
 
+	"github.com/kyma-project/modulectl/internal/common"
 	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/crdschema"
 	"github.com/kyma-project/modulectl/internal/service/templategenerator"
 	"github.com/kyma-project/modulectl/internal/testutils"
 
@@ -24,7 +29,7 @@ func TestNew_WhenCalledWithNilDependencies_ReturnsError(t *testing.T) {
 func TestGenerateModuleTemplate_WhenCalledWithNilConfig_ReturnsError(t *testing.T) {
 	svc, _ := templategenerator.NewService(&mockFileSystem{})
 
-	err := svc.GenerateModuleTemplate(nil, nil, nil, false, "")
+	err := svc.GenerateModuleTemplate(nil, nil, nil, false, nil, "", false)
 
 	require.Error(t, err)
 	require.ErrorIs(t, err, templategenerator.ErrEmptyModuleConfig)
@@ -33,7 +38,7 @@ func TestGenerateModuleTemplate_WhenCalledWithNilConfig_ReturnsError(t *testing.
 func TestGenerateModuleTemplate_Success(t *testing.T) {
 	commonManifestValue := "https://github.com/kyma-project/template-operator/releases/" +
 		"download/1.0.1/template-operator.yaml"
-	commonManifest := contentprovider.MustUrlOrLocalFile(commonManifestValue)
+	commonManifest := contentprovider.MustUrlOrLocalFiles(commonManifestValue)
 
 	defaultData := []byte(`apiVersion: operator.kyma-project.io/v1alpha1
 kind: Sample
@@ -161,7 +166,7 @@ spec:
 				require.Contains(t, mockFS.writtenTemplate, "apps")
 				require.Contains(t, mockFS.writtenTemplate, "v1")
 				require.Contains(t, mockFS.writtenTemplate, "Deployment")
-				require.Equal(t, 2, strings.Count(mockFS.writtenTemplate, "namespace"))
+				require.Equal(t, 1, strings.Count(mockFS.writtenTemplate, "namespace"))
 			},
 		},
 		{
@@ -188,7 +193,7 @@ spec:
 				require.Contains(t, mockFS.writtenTemplate, "apps")
 				require.Contains(t, mockFS.writtenTemplate, "v1")
 				require.Contains(t, mockFS.writtenTemplate, "Deployment")
-				require.Equal(t, 1, strings.Count(mockFS.writtenTemplate, "namespace"))
+				require.Equal(t, 0, strings.Count(mockFS.writtenTemplate, "namespace"))
 			},
 		},
 		{
@@ -257,7 +262,7 @@ spec:
 				Version:     "1.0.0",
 				Labels:      map[string]string{"key": "value"},
 				Annotations: map[string]string{"annotation": "value"},
-				Manifest: contentprovider.MustUrlOrLocalFile(
+				Manifest: contentprovider.MustUrlOrLocalFiles(
 					"https://github.com/kyma-project/template-operator/releases/download/1.0.1/template-operator.yaml",
 				),
 				Resources: contentprovider.Resources{
@@ -268,7 +273,7 @@ spec:
 			assertions: func(t *testing.T, mockFS *mockFileSystem) {
 				t.Helper()
 				require.NotContains(t, mockFS.writtenTemplate, "kind: Sample")
-				require.Contains(t, mockFS.writtenTemplate, "\"operator.kyma-project.io/internal\": \"true\"")
+				require.Contains(t, mockFS.writtenTemplate, "operator.kyma-project.io/internal: \"true\"")
 			},
 		},
 		{
@@ -279,7 +284,7 @@ spec:
 				Version:     "1.0.0",
 				Labels:      map[string]string{"key": "value"},
 				Annotations: map[string]string{"annotation": "value"},
-				Manifest: contentprovider.MustUrlOrLocalFile(
+				Manifest: contentprovider.MustUrlOrLocalFiles(
 					"https://github.com/kyma-project/template-operator/releases/download/1.0.1/template-operator.yaml",
 				),
 				Resources: contentprovider.Resources{
@@ -290,7 +295,7 @@ spec:
 			assertions: func(t *testing.T, mockFS *mockFileSystem) {
 				t.Helper()
 				require.NotContains(t, mockFS.writtenTemplate, "kind: Sample")
-				require.Contains(t, mockFS.writtenTemplate, "\"operator.kyma-project.io/beta\": \"true\"")
+				require.Contains(t, mockFS.writtenTemplate, "operator.kyma-project.io/beta: \"true\"")
 			},
 		},
 		{
@@ -323,7 +328,7 @@ spec:
 				descriptor = testutils.CreateComponentDescriptor("example.com/component", "1.0.0")
 			}
 
-			err := svc.GenerateModuleTemplate(tt.moduleConfig, descriptor, tt.data, true, "output.yaml")
+			err := svc.GenerateModuleTemplate(tt.moduleConfig, descriptor, tt.data, true, nil, "output.yaml", false)
 
 			require.NoError(t, err)
 			require.Equal(t, "output.yaml", mockFS.path)
@@ -332,6 +337,136 @@ spec:
 	}
 }
 
+func TestGenerateModuleTemplate_WritesTypedModuleTemplate(t *testing.T) {
+	mockFS := &mockFileSystem{}
+	svc, _ := templategenerator.NewService(mockFS)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:          "kyma-project.io/module/template-operator",
+		Version:       "1.0.0",
+		Repository:    "https://github.com/kyma-project/template-operator",
+		Documentation: "https://kyma-project.io/#/template-operator/user/README",
+		Icons: contentprovider.Icons{
+			"module-icon": "https://example.com/module-icon.svg",
+			"logo":        "https://example.com/logo.svg",
+		},
+		Manifest: contentprovider.MustUrlOrLocalFiles("https://example.com/template-operator.yaml"),
+		Resources: contentprovider.Resources{
+			"crds": "https://example.com/crds.yaml",
+		},
+	}
+
+	err := svc.GenerateModuleTemplate(moduleConfig, nil, nil, false, nil, "output.yaml", false)
+	require.NoError(t, err)
+
+	var moduleTemplate v1beta2.ModuleTemplate
+	require.NoError(t, yaml.UnmarshalStrict([]byte(mockFS.writtenTemplate), &moduleTemplate))
+	require.Equal(t, "ModuleTemplate", moduleTemplate.Kind)
+	require.Equal(t, "operator.kyma-project.io/v1beta2", moduleTemplate.APIVersion)
+	require.Equal(t, "template-operator-1.0.0", moduleTemplate.Name)
+	require.Equal(t, "template-operator", moduleTemplate.Labels[shared.ModuleName])
+	require.Equal(t, shared.DisableLabelValue, moduleTemplate.Annotations[shared.IsClusterScopedAnnotation])
+	require.Equal(t, "template-operator", moduleTemplate.Spec.ModuleName)
+	require.Equal(t, []v1beta2.ModuleIcon{
+		{Name: "logo", Link: "https://example.com/logo.svg"},
+		{Name: "module-icon", Link: "https://example.com/module-icon.svg"},
+	}, moduleTemplate.Spec.Info.Icons)
+	require.Equal(t, []v1beta2.Resource{
+		{Name: "crds", Link: "https://example.com/crds.yaml"},
+		{Name: "rawManifest", Link: "https://example.com/template-operator.yaml"},
+	}, moduleTemplate.Spec.Resources)
+	require.Nil(t, moduleTemplate.Spec.Data)
+	require.JSONEq(t, "{}", string(moduleTemplate.Spec.Descriptor.Raw))
+}
+
+func TestGenerateModuleTemplate_ReturnsError_WhenModuleTemplateDoesNotMatchCRD(t *testing.T) {
+	mockFS := &mockFileSystem{}
+	svc, _ := templategenerator.NewService(mockFS)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/template-operator",
+		Version: "1.0.0-rc.1",
+		Resources: contentprovider.Resources{
+			"crds": "::no-uri",
+		},
+	}
+
+	err := svc.GenerateModuleTemplate(moduleConfig, nil, nil, false, nil, "output.yaml", false)
+
+	require.ErrorIs(t, err, crdschema.ErrSchemaViolation)
+	require.ErrorContains(t, err, "spec.version in body should match")
+	require.ErrorContains(t, err, "spec.resources[0].link in body must be of type uri")
+	require.Empty(t, mockFS.path)
+}
+
+func TestGenerateModuleTemplate_AddsContentDigest_WhenReproducible(t *testing.T) {
+	newModuleConfig := func(version string) *contentprovider.ModuleConfig {
+		return &contentprovider.ModuleConfig{
+			Name:    "kyma-project.io/module/template-operator",
+			Version: version,
+			Icons: contentprovider.Icons{
+				"module-icon": "https://example.com/module-icon.svg",
+				"logo":        "https://example.com/logo.svg",
+			},
+			Annotations: map[string]string{common.ContentDigestAnnotationKey: "sha256:stale"},
+		}
+	}
+	generate := func(moduleConfig *contentprovider.ModuleConfig) (string, v1beta2.ModuleTemplate) {
+		mockFS := &mockFileSystem{}
+		svc, _ := templategenerator.NewService(mockFS)
+		require.NoError(t, svc.GenerateModuleTemplate(moduleConfig, nil, nil, false, nil, "output.yaml", true))
+
+		var moduleTemplate v1beta2.ModuleTemplate
+		require.NoError(t, yaml.UnmarshalStrict([]byte(mockFS.writtenTemplate), &moduleTemplate))
+		return mockFS.writtenTemplate, moduleTemplate
+	}
+
+	firstContent, first := generate(newModuleConfig("1.0.0"))
+	secondContent, second := generate(newModuleConfig("1.0.0"))
+	_, changed := generate(newModuleConfig("1.0.1"))
+
+	require.Equal(t, firstContent, secondContent)
+	require.Regexp(t, "^sha256:[0-9a-f]{64}$", first.Annotations[common.ContentDigestAnnotationKey])
+	require.NotEqual(t, first.Annotations[common.ContentDigestAnnotationKey],
+		changed.Annotations[common.ContentDigestAnnotationKey])
+	require.Equal(t, first.Annotations, second.Annotations)
+}
+
+func TestGenerateModuleTemplate_DoesNotAddContentDigest_WhenNotReproducible(t *testing.T) {
+	mockFS := &mockFileSystem{}
+	svc, _ := templategenerator.NewService(mockFS)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/template-operator",
+		Version: "1.0.0",
+	}
+
+	err := svc.GenerateModuleTemplate(moduleConfig, nil, nil, false, nil, "output.yaml", false)
+
+	require.NoError(t, err)
+	require.NotContains(t, mockFS.writtenTemplate, common.ContentDigestAnnotationKey)
+}
+
+func TestGenerateModuleTemplate_AddsProvenanceAnnotations(t *testing.T) {
+	mockFS := &mockFileSystem{}
+	svc, _ := templategenerator.NewService(mockFS)
+	moduleConfig := &contentprovider.ModuleConfig{
+		Name:        "kyma-project.io/module/template-operator",
+		Version:     "1.0.0",
+		Annotations: map[string]string{"team": "framefrog"},
+	}
+
+	err := svc.GenerateModuleTemplate(moduleConfig, nil, nil, false,
+		map[string]string{"provenance.kyma-project.io/git-commit": "4c2e3f1d"}, "output.yaml", false)
+	require.NoError(t, err)
+
+	var moduleTemplate v1beta2.ModuleTemplate
+	require.NoError(t, yaml.UnmarshalStrict([]byte(mockFS.writtenTemplate), &moduleTemplate))
+	require.Equal(t, map[string]string{
+		"team":                                  "framefrog",
+		"provenance.kyma-project.io/git-commit": "4c2e3f1d",
+		shared.IsClusterScopedAnnotation:        shared.DisableLabelValue,
+	}, moduleTemplate.Annotations)
+	require.Equal(t, map[string]string{"team": "framefrog"}, moduleConfig.Annotations)
+}
+
 type mockFileSystem struct {
 	path, writtenTemplate string
 }
@@ -359,7 +494,7 @@ func assertCommon(t *testing.T, mockFS *mockFileSystem) {
 	require.Contains(t, mockFS.writtenTemplate, "version: 1.0.0")
 	require.Contains(t, mockFS.writtenTemplate, "moduleName: component")
 	require.Contains(t, mockFS.writtenTemplate, "component-1.0.0")
-	require.Contains(t, mockFS.writtenTemplate, "namespace: \"\"")
+	require.Contains(t, mockFS.writtenTemplate, "kind: ModuleTemplate")
 	require.Contains(t, mockFS.writtenTemplate, "example.com/component")
 	require.NotContains(t, mockFS.writtenTemplate, "---")
 	require.Contains(t, mockFS.writtenTemplate, "apiVersion: operator.kyma-project.io/v1alpha1")
@@ -0,0 +1,33 @@
+package validate
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type Options struct {
+	Out                   iotools.Out
+	ConfigFile            string
+	SkipVersionValidation bool
+	AllowUnknownFields    bool
+	ReservedKeyPrefixes   []string
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.ConfigFile == "" {
+		return fmt.Errorf("opts.ConfigFile must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if _, err := validation.ParseReservedKeyPrefixes(opts.ReservedKeyPrefixes); err != nil {
+		return fmt.Errorf("opts.ReservedKeyPrefixes is invalid: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	"github.com/kyma-project/modulectl/internal/service/modulecheck"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+)
+
+type ModuleConfigService interface {
+	ParseAndValidateModuleConfig(moduleConfigFile string, allowUnknownFields bool) (*contentprovider.ModuleConfig, error)
+	ValidateReservedKeys(moduleConfig *contentprovider.ModuleConfig,
+		reservedKeyPrefixes []validation.ReservedKeyPrefix,
+	) ([]moduleconfigreader.Violation, error)
+}
+
+type FileResolver interface {
+	// Resolve resolves a file reference, which can be either a URL or a local file path (may be just a file name).
+	// For local file paths, it will resolve the path relative to the provided basePath (absolute or relative).
+	Resolve(fileRef contentprovider.UrlOrLocalFile, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestFileResolver interface {
+	// ResolveAll resolves a list of file references, which can be URLs, local file paths, directories or glob
+	// patterns, into a single file. Several files are concatenated in a deterministic order.
+	ResolveAll(fileRefs contentprovider.UrlOrLocalFiles, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestRenderer interface {
+	// RenderChart renders a local Helm chart into a temp file and returns its path.
+	// The chart and values file paths are resolved relative to the provided basePath.
+	RenderChart(chart *contentprovider.Chart, basePath string) (string, error)
+	// RenderKustomization builds a local kustomization directory into a temp file and returns its path.
+	// The directory is resolved relative to the provided basePath.
+	RenderKustomization(kustomizationDir string, basePath string) (string, error)
+	CleanupTempFiles() []error
+}
+
+type ManifestService interface {
+	ExtractImagesFromManifest(manifestPath string, imageLocations []contentprovider.ImageLocation) ([]string, error)
+}
+
+type ImageVersionVerifierService interface {
+	VerifyModuleResources(moduleConfig *contentprovider.ModuleConfig, filePath string) error
+}
+
+type CRDParserService interface {
+	IsCRDClusterScoped(paths *types.ResourcePaths) (bool, error)
+	ValidateDefaultCR(paths *types.ResourcePaths) error
+}
+
+type SecurityConfigService interface {
+	ParseSecurityConfigData(securityConfigFile string) (*contentprovider.SecurityScanConfig, error)
+}
+
+// Service runs the checks of the create command that do not require a git repository or a registry.
+// It does not produce any artifacts.
+type Service struct {
+	moduleCheckService *modulecheck.Service
+	crdParserService   CRDParserService
+}
+
+func NewService(moduleConfigService ModuleConfigService,
+	manifestService ManifestService,
+	imageVersionVerifierService ImageVersionVerifierService,
+	crdParserService CRDParserService,
+	securityConfigService SecurityConfigService,
+	manifestFileResolver ManifestFileResolver,
+	defaultCRFileResolver FileResolver,
+	manifestRenderer ManifestRenderer,
+) (*Service, error) {
+	// The checks are shared with the create command, so that validate runs exactly what create runs.
+	moduleCheckService, err := modulecheck.NewService(moduleConfigService, manifestService,
+		imageVersionVerifierService, crdParserService, securityConfigService, manifestFileResolver,
+		defaultCRFileResolver, manifestRenderer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		moduleCheckService: moduleCheckService,
+		crdParserService:   crdParserService,
+	}, nil
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	// downloaded files are only needed for the duration of the validation
+	defer s.moduleCheckService.CleanupTempFiles(opts.Out)
+
+	module, err := s.moduleCheckService.Check(modulecheck.Options{
+		Out:                   opts.Out,
+		ConfigFile:            opts.ConfigFile,
+		AllowUnknownFields:    opts.AllowUnknownFields,
+		ReservedKeyPrefixes:   opts.ReservedKeyPrefixes,
+		SkipVersionValidation: opts.SkipVersionValidation,
+	})
+	if err != nil {
+		return err
+	}
+
+	opts.Out.Write("- Determining CRD scope\n")
+	resourcePaths := types.NewResourcePaths(module.DefaultCRFilePath, module.ManifestFilePath, "")
+	isCRDClusterScoped, err := s.crdParserService.IsCRDClusterScoped(resourcePaths)
+	if err != nil {
+		return fmt.Errorf("failed to determine if CRD is cluster scoped: %w", err)
+	}
+	opts.Out.Write(fmt.Sprintf("\tCRD is cluster scoped: %t\n", isCRDClusterScoped))
+
+	opts.Out.Write(fmt.Sprintf("Module %s in version %s is valid\n", module.ModuleConfig.Name,
+		module.ModuleConfig.Version))
+	return nil
+}
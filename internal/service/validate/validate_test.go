@@ -0,0 +1,566 @@
+package validate_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/common/validation"
+	"github.com/kyma-project/modulectl/internal/service/contentprovider"
+	moduleconfigreader "github.com/kyma-project/modulectl/internal/service/moduleconfig/reader"
+	"github.com/kyma-project/modulectl/internal/service/validate"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+func Test_NewService_ReturnsError_WhenModuleConfigServiceIsNil(t *testing.T) {
+	_, err := validate.NewService(nil, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		&manifestRendererStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "moduleConfigService")
+}
+
+func Test_NewService_ReturnsError_WhenDefaultCRFileResolverIsNil(t *testing.T) {
+	_, err := validate.NewService(&moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, nil, &manifestRendererStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "defaultCRFileResolver")
+}
+
+func Test_NewService_ReturnsError_WhenManifestRendererIsNil(t *testing.T) {
+	_, err := validate.NewService(&moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, &fileResolverStub{}, nil)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "manifestRenderer")
+}
+
+func Test_Run_ReturnsError_WhenConfigFileIsEmpty(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&fileResolverStub{}, &fileResolverStub{})
+
+	err := svc.Run(validate.Options{Out: iotools.NewDefaultOut(io.Discard)})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "opts.ConfigFile")
+}
+
+func Test_Run_ReturnsError_WhenModuleConfigIsInvalid(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceErrorStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &fileResolverStub{}, &fileResolverStub{})
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse module config")
+}
+
+func Test_Run_ReturnsError_WhenManifestCannotBeResolved(t *testing.T) {
+	manifestResolver := &fileResolverStub{err: errors.New("file does not exist")}
+	defaultCRResolver := &fileResolverStub{}
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		manifestResolver, defaultCRResolver)
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to resolve manifest file")
+	assert.Equal(t, 1, manifestResolver.cleanupTempFilesCallCount)
+	assert.Equal(t, 1, defaultCRResolver.cleanupTempFilesCallCount)
+}
+
+func Test_Run_ReturnsError_WhenReservedKeyPrefixesAreInvalid(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&fileResolverStub{}, &fileResolverStub{})
+	opts := newOptions(io.Discard)
+	opts.ReservedKeyPrefixes = []string{"example.com/=info"}
+
+	err := svc.Run(opts)
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "opts.ReservedKeyPrefixes is invalid")
+}
+
+func Test_Run_ValidatesReservedKeys(t *testing.T) {
+	moduleConfigService := &moduleConfigServiceReservedKeysStub{
+		warnings: []moduleconfigreader.Violation{
+			{FieldPath: "annotations[operator.kyma-project.io/doc-url]", Err: errReservedKey},
+		},
+	}
+	svc := newValidateService(t, moduleConfigService, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&fileResolverStub{}, &fileResolverStub{})
+	buffer := &bytes.Buffer{}
+	opts := newOptions(buffer)
+	opts.ReservedKeyPrefixes = []string{"operator.kyma-project.io/=warning"}
+
+	err := svc.Run(opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, []validation.ReservedKeyPrefix{
+		{Prefix: "operator.kyma-project.io/", Severity: validation.ReservedKeySeverityWarning},
+		{Prefix: "provenance.kyma-project.io/", Severity: validation.ReservedKeySeverityError},
+	}, moduleConfigService.reservedKeyPrefixes)
+	assert.Contains(t, buffer.String(),
+		"\tWarning: annotations[operator.kyma-project.io/doc-url]: key prefix is reserved\n")
+}
+
+func Test_Run_ReturnsError_WhenReservedKeyIsUsed(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceReservedKeysStub{err: errReservedKey}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &fileResolverStub{}, &fileResolverStub{})
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.ErrorIs(t, err, errReservedKey)
+	require.Contains(t, err.Error(), "failed to validate reserved keys")
+}
+
+func Test_Run_ResolvesAllManifestReferences(t *testing.T) {
+	manifestResolver := &fileResolverStub{}
+	svc := newValidateService(t, &moduleConfigServiceWithManifestListStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, manifestResolver, &fileResolverStub{})
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.NoError(t, err)
+	assert.Equal(t, contentprovider.MustUrlOrLocalFiles("crds", "manifests/*.yaml",
+		"https://example.com/rbac.yaml"), manifestResolver.fileRefs)
+}
+
+func Test_Run_ReturnsError_WhenImageExtractionFails(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceErrorStub{},
+		&imageVersionVerifierStub{}, &fileResolverStub{}, &fileResolverStub{})
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to extract images from manifest")
+}
+
+func Test_Run_ReturnsError_WhenVersionVerificationFails(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{},
+		&imageVersionVerifierErrorStub{}, &fileResolverStub{}, &fileResolverStub{})
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to verify module resources")
+}
+
+func Test_Run_SkipsVersionVerification_WhenSkipVersionValidationIsSet(t *testing.T) {
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{},
+		&imageVersionVerifierErrorStub{}, &fileResolverStub{}, &fileResolverStub{})
+	opts := newOptions(io.Discard)
+	opts.SkipVersionValidation = true
+
+	err := svc.Run(opts)
+
+	require.NoError(t, err)
+}
+
+func Test_Run_Succeeds_AndCleansUpTempFiles(t *testing.T) {
+	manifestResolver := &fileResolverStub{}
+	defaultCRResolver := &fileResolverStub{}
+	svc := newValidateService(t, &moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		manifestResolver, defaultCRResolver)
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Found 2 image(s)")
+	assert.Contains(t, out.String(), "Module kyma-project.io/module/telemetry in version 1.43.1 is valid")
+	assert.Equal(t, 1, manifestResolver.cleanupTempFilesCallCount)
+	assert.Equal(t, 1, defaultCRResolver.cleanupTempFilesCallCount)
+}
+
+func Test_Run_ReturnsError_WhenDefaultCRIsInvalid(t *testing.T) {
+	defaultCRResolver := &fileResolverStub{}
+	svc, err := validate.NewService(&moduleConfigServiceStub{}, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceErrorStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, defaultCRResolver,
+		&manifestRendererStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newOptions(io.Discard))
+
+	require.ErrorIs(t, err, errInvalidDefaultCR)
+	require.ErrorContains(t, err, "failed to validate default CR")
+	assert.Equal(t, 1, defaultCRResolver.cleanupTempFilesCallCount)
+}
+
+func Test_Run_ReturnsError_WhenBDBAImagesAreNotInManifest(t *testing.T) {
+	securityConfigService := &securityConfigServiceStub{
+		securityConfig: &contentprovider.SecurityScanConfig{
+			BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+		},
+	}
+	svc, err := validate.NewService(&moduleConfigServiceWithSecurityStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, securityConfigService, &fileResolverStub{},
+		&fileResolverStub{}, &manifestRendererStub{})
+	require.NoError(t, err)
+
+	err = svc.Run(newOptions(io.Discard))
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "failed to verify security config images")
+	assert.Equal(t, "sec-scanners-config.yaml", securityConfigService.parsedFile)
+}
+
+func Test_Run_Succeeds_WhenSecurityConfigMatchesManifest(t *testing.T) {
+	securityConfigService := &securityConfigServiceStub{
+		securityConfig: &contentprovider.SecurityScanConfig{
+			BDBA: []string{"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1"},
+		},
+	}
+	manifestService := &manifestServiceImagesStub{images: []string{
+		"europe-docker.pkg.dev/kyma-project/prod/telemetry-manager:1.43.1", "image2:v1.0",
+	}}
+	svc, err := validate.NewService(&moduleConfigServiceWithSecurityStub{}, manifestService,
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, securityConfigService, &fileResolverStub{},
+		&fileResolverStub{}, &manifestRendererStub{})
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+
+	err = svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Warning: image image2:v1.0 is not listed in the BDBA images")
+}
+
+func Test_Run_RendersManifestFromChart_WhenChartIsSet(t *testing.T) {
+	manifestResolver := &fileResolverStub{err: errors.New("manifest must not be resolved")}
+	manifestRenderer := &manifestRendererStub{}
+	svc, err := validate.NewService(&moduleConfigServiceWithChartStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, &securityConfigServiceStub{}, manifestResolver,
+		&fileResolverStub{}, manifestRenderer)
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+
+	err = svc.Run(validate.Options{Out: iotools.NewDefaultOut(out), ConfigFile: "config/module-config.yaml"})
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "- Rendering chart")
+	assert.Equal(t, "charts/telemetry", manifestRenderer.chart.Path)
+	assert.Equal(t, "config", manifestRenderer.basePath)
+	assert.Equal(t, 1, manifestRenderer.cleanupTempFilesCallCount)
+}
+
+func Test_Run_BuildsManifestFromKustomization_WhenKustomizationIsSet(t *testing.T) {
+	manifestResolver := &fileResolverStub{err: errors.New("manifest must not be resolved")}
+	manifestRenderer := &manifestRendererStub{}
+	svc, err := validate.NewService(&moduleConfigServiceWithKustomizationStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, &securityConfigServiceStub{}, manifestResolver,
+		&fileResolverStub{}, manifestRenderer)
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+
+	err = svc.Run(validate.Options{Out: iotools.NewDefaultOut(out), ConfigFile: "config/module-config.yaml"})
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "- Building kustomization")
+	assert.Equal(t, "overlays/prod", manifestRenderer.kustomizationDir)
+	assert.Equal(t, "config", manifestRenderer.basePath)
+	assert.Equal(t, 1, manifestRenderer.cleanupTempFilesCallCount)
+}
+
+func Test_Run_ReturnsError_WhenKustomizationCannotBeBuilt(t *testing.T) {
+	manifestRenderer := &manifestRendererStub{err: errors.New("missing kustomization file")}
+	svc := newValidateServiceWithRenderer(t, &moduleConfigServiceWithKustomizationStub{}, manifestRenderer)
+
+	err := svc.Run(newOptions(io.Discard))
+
+	require.ErrorContains(t, err, "failed to build manifest from kustomization: missing kustomization file")
+}
+
+func Test_Run_ReturnsError_WhenChartCannotBeRendered(t *testing.T) {
+	manifestRenderer := &manifestRendererStub{err: errors.New("failed to load chart")}
+	svc, err := validate.NewService(&moduleConfigServiceWithChartStub{}, &manifestServiceStub{},
+		&imageVersionVerifierStub{}, &crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{},
+		&fileResolverStub{}, manifestRenderer)
+	require.NoError(t, err)
+
+	err = svc.Run(newOptions(io.Discard))
+
+	require.ErrorContains(t, err, "failed to render manifest from chart: failed to load chart")
+	assert.Equal(t, 1, manifestRenderer.cleanupTempFilesCallCount)
+}
+
+func newValidateService(t *testing.T,
+	moduleConfigService validate.ModuleConfigService,
+	manifestService validate.ManifestService,
+	imageVersionVerifierService validate.ImageVersionVerifierService,
+	manifestFileResolver validate.ManifestFileResolver,
+	defaultCRFileResolver validate.FileResolver,
+) *validate.Service {
+	t.Helper()
+	svc, err := validate.NewService(moduleConfigService, manifestService, imageVersionVerifierService,
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, manifestFileResolver, defaultCRFileResolver,
+		&manifestRendererStub{})
+	require.NoError(t, err)
+	return svc
+}
+
+func newValidateServiceWithRenderer(t *testing.T,
+	moduleConfigService validate.ModuleConfigService,
+	manifestRenderer validate.ManifestRenderer,
+) *validate.Service {
+	t.Helper()
+	svc, err := validate.NewService(moduleConfigService, &manifestServiceStub{}, &imageVersionVerifierStub{},
+		&crdParserServiceStub{}, &securityConfigServiceStub{}, &fileResolverStub{}, &fileResolverStub{},
+		manifestRenderer)
+	require.NoError(t, err)
+	return svc
+}
+
+func newOptions(writer io.Writer) validate.Options {
+	return validate.Options{
+		Out:        iotools.NewDefaultOut(writer),
+		ConfigFile: "module-config.yaml",
+	}
+}
+
+var errReservedKey = errors.New("key prefix is reserved")
+
+// reservedKeysStub is embedded by the module config service stubs to report no reserved keys.
+type reservedKeysStub struct{}
+
+func (*reservedKeysStub) ValidateReservedKeys(_ *contentprovider.ModuleConfig,
+	_ []validation.ReservedKeyPrefix,
+) ([]moduleconfigreader.Violation, error) {
+	return nil, nil
+}
+
+type moduleConfigServiceStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceStub) ParseAndValidateModuleConfig(_ string, _ bool) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:      "kyma-project.io/module/telemetry",
+		Version:   "1.43.1",
+		Manifest:  contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		DefaultCR: contentprovider.MustUrlOrLocalFile("default-cr.yaml"),
+	}, nil
+}
+
+type moduleConfigServiceWithManifestListStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithManifestListStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:     "kyma-project.io/module/telemetry",
+		Version:  "1.43.1",
+		Manifest: contentprovider.MustUrlOrLocalFiles("crds", "manifests/*.yaml", "https://example.com/rbac.yaml"),
+	}, nil
+}
+
+type moduleConfigServiceReservedKeysStub struct {
+	moduleConfigServiceStub
+
+	warnings            []moduleconfigreader.Violation
+	err                 error
+	reservedKeyPrefixes []validation.ReservedKeyPrefix
+}
+
+func (s *moduleConfigServiceReservedKeysStub) ValidateReservedKeys(_ *contentprovider.ModuleConfig,
+	reservedKeyPrefixes []validation.ReservedKeyPrefix,
+) ([]moduleconfigreader.Violation, error) {
+	s.reservedKeyPrefixes = reservedKeyPrefixes
+	return s.warnings, s.err
+}
+
+type moduleConfigServiceErrorStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceErrorStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return nil, errors.New("failed to read module config file")
+}
+
+type fileResolverStub struct {
+	err                       error
+	fileRefs                  contentprovider.UrlOrLocalFiles
+	cleanupTempFilesCallCount int
+}
+
+func (frs *fileResolverStub) Resolve(_ contentprovider.UrlOrLocalFile, _ string) (string, error) {
+	if frs.err != nil {
+		return "", frs.err
+	}
+	return "/tmp/some-file.yaml", nil
+}
+
+func (frs *fileResolverStub) ResolveAll(fileRefs contentprovider.UrlOrLocalFiles, _ string) (string, error) {
+	if frs.err != nil {
+		return "", frs.err
+	}
+	frs.fileRefs = fileRefs
+	return "/tmp/some-file.yaml", nil
+}
+
+func (frs *fileResolverStub) CleanupTempFiles() []error {
+	frs.cleanupTempFilesCallCount++
+	return nil
+}
+
+type manifestServiceStub struct{}
+
+func (*manifestServiceStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
+	return []string{"image1:1.0.0", "image2:v1.0"}, nil
+}
+
+type manifestServiceErrorStub struct{}
+
+func (*manifestServiceErrorStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
+	return nil, errors.New("invalid image")
+}
+
+type imageVersionVerifierStub struct{}
+
+func (*imageVersionVerifierStub) VerifyModuleResources(_ *contentprovider.ModuleConfig, _ string) error {
+	return nil
+}
+
+type imageVersionVerifierErrorStub struct{}
+
+func (*imageVersionVerifierErrorStub) VerifyModuleResources(_ *contentprovider.ModuleConfig, _ string) error {
+	return errors.New("no matched version found")
+}
+
+type crdParserServiceStub struct{}
+
+func (*crdParserServiceStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+	return false, nil
+}
+
+func (*crdParserServiceStub) ValidateDefaultCR(_ *types.ResourcePaths) error {
+	return nil
+}
+
+type crdParserServiceErrorStub struct{}
+
+var errInvalidDefaultCR = errors.New("invalid default CR")
+
+func (*crdParserServiceErrorStub) IsCRDClusterScoped(_ *types.ResourcePaths) (bool, error) {
+	return false, nil
+}
+
+func (*crdParserServiceErrorStub) ValidateDefaultCR(_ *types.ResourcePaths) error {
+	return errInvalidDefaultCR
+}
+
+type moduleConfigServiceWithSecurityStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithSecurityStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:     "kyma-project.io/module/telemetry",
+		Version:  "1.43.1",
+		Manifest: contentprovider.MustUrlOrLocalFiles("manifest.yaml"),
+		Security: "sec-scanners-config.yaml",
+	}, nil
+}
+
+type manifestServiceImagesStub struct {
+	images []string
+}
+
+func (m *manifestServiceImagesStub) ExtractImagesFromManifest(
+	_ string, _ []contentprovider.ImageLocation,
+) ([]string, error) {
+	return m.images, nil
+}
+
+type securityConfigServiceStub struct {
+	securityConfig *contentprovider.SecurityScanConfig
+	parsedFile     string
+}
+
+func (s *securityConfigServiceStub) ParseSecurityConfigData(
+	securityConfigFile string,
+) (*contentprovider.SecurityScanConfig, error) {
+	s.parsedFile = securityConfigFile
+	return s.securityConfig, nil
+}
+
+type moduleConfigServiceWithChartStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithChartStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:    "kyma-project.io/module/telemetry",
+		Version: "1.43.1",
+		Chart: &contentprovider.Chart{
+			Path:        "charts/telemetry",
+			ReleaseName: "telemetry",
+			Namespace:   "kyma-system",
+		},
+	}, nil
+}
+
+type moduleConfigServiceWithKustomizationStub struct {
+	reservedKeysStub
+}
+
+func (*moduleConfigServiceWithKustomizationStub) ParseAndValidateModuleConfig(
+	_ string, _ bool,
+) (*contentprovider.ModuleConfig, error) {
+	return &contentprovider.ModuleConfig{
+		Name:          "kyma-project.io/module/telemetry",
+		Version:       "1.43.1",
+		Kustomization: "overlays/prod",
+	}, nil
+}
+
+type manifestRendererStub struct {
+	err                       error
+	chart                     *contentprovider.Chart
+	kustomizationDir          string
+	basePath                  string
+	cleanupTempFilesCallCount int
+}
+
+func (s *manifestRendererStub) RenderChart(chart *contentprovider.Chart, basePath string) (string, error) {
+	s.chart = chart
+	s.basePath = basePath
+	if s.err != nil {
+		return "", s.err
+	}
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (s *manifestRendererStub) RenderKustomization(kustomizationDir string, basePath string) (string, error) {
+	s.kustomizationDir = kustomizationDir
+	s.basePath = basePath
+	if s.err != nil {
+		return "", s.err
+	}
+	return "/tmp/rendered-manifest.yaml", nil
+}
+
+func (s *manifestRendererStub) CleanupTempFiles() []error {
+	s.cleanupTempFilesCallCount++
+	return nil
+}
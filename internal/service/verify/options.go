@@ -0,0 +1,47 @@
+package verify
+
+import (
+	"fmt"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+type Options struct {
+	Out                iotools.Out
+	Component          string
+	ModuleTemplateFile string
+	PublicKeyFile      string
+	SignatureName      string
+	types.RegistryAccess
+}
+
+func (opts Options) Validate() error {
+	if opts.Out == nil {
+		return fmt.Errorf("opts.Out must not be nil: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.PublicKeyFile == "" {
+		return fmt.Errorf("opts.PublicKeyFile must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if opts.SignatureName == "" {
+		return fmt.Errorf("opts.SignatureName must not be empty: %w", commonerrors.ErrInvalidOption)
+	}
+
+	if (opts.Component == "") == (opts.ModuleTemplateFile == "") {
+		return fmt.Errorf("either opts.Component or opts.ModuleTemplateFile must be set: %w",
+			commonerrors.ErrInvalidOption)
+	}
+
+	if opts.ModuleTemplateFile != "" {
+		return nil
+	}
+
+	if _, _, err := types.SplitComponent(opts.Component); err != nil {
+		return err
+	}
+
+	return opts.RegistryAccess.Validate()
+}
@@ -0,0 +1,160 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/cpi"
+	"sigs.k8s.io/yaml"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+)
+
+var ErrNoDescriptor = errors.New("module template does not contain a component descriptor")
+
+type RegistryService interface {
+	LookupComponentVersion(name, version string, insecure bool, userPasswordCreds, registryURL string,
+	) (cpi.ComponentVersionAccess, error)
+}
+
+type CredentialService interface {
+	UserPasswordCredentials(flagCredentials, credentialsFile string) (string, error)
+	ConfigureTargetRegistry(config credential.TargetRegistryConfig) error
+}
+
+type SignatureService interface {
+	VerifyComponentVersion(componentVersion cpi.ComponentVersionAccess, name, publicKeyFile string) error
+	VerifyDescriptor(descriptor *compdesc.ComponentDescriptor, name, publicKeyFile string) error
+}
+
+type FileSystem interface {
+	ReadFile(path string) ([]byte, error)
+}
+
+// Service verifies the signature of a published component version or of the descriptor of a ModuleTemplate.
+type Service struct {
+	registryService   RegistryService
+	credentialService CredentialService
+	signatureService  SignatureService
+	fileSystem        FileSystem
+}
+
+func NewService(registryService RegistryService,
+	credentialService CredentialService,
+	signatureService SignatureService,
+	fileSystem FileSystem,
+) (*Service, error) {
+	if registryService == nil {
+		return nil, fmt.Errorf("registryService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if credentialService == nil {
+		return nil, fmt.Errorf("credentialService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if signatureService == nil {
+		return nil, fmt.Errorf("signatureService must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	if fileSystem == nil {
+		return nil, fmt.Errorf("fileSystem must not be nil: %w", commonerrors.ErrInvalidArg)
+	}
+
+	return &Service{
+		registryService:   registryService,
+		credentialService: credentialService,
+		signatureService:  signatureService,
+		fileSystem:        fileSystem,
+	}, nil
+}
+
+func (s *Service) Run(opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.ModuleTemplateFile != "" {
+		err = s.verifyModuleTemplate(opts)
+	} else {
+		err = s.verifyComponentVersion(opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	opts.Out.Write(fmt.Sprintf("\tSignature %s is valid\n", opts.SignatureName))
+	return nil
+}
+
+// verifyComponentVersion verifies the digests and the signature of the component version published to the registry.
+func (s *Service) verifyComponentVersion(opts Options) error {
+	name, version, err := types.SplitComponent(opts.Component)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := s.credentialService.UserPasswordCredentials(opts.Credentials, opts.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to resolve registry credentials: %w", err)
+	}
+
+	if err = s.credentialService.ConfigureTargetRegistry(credential.TargetRegistryConfig{
+		RegistryURL:    opts.RegistryURL,
+		TokenFile:      opts.RegistryTokenFile,
+		CAFile:         opts.RegistryCAFile,
+		ClientCertFile: opts.RegistryClientCert,
+		ClientKeyFile:  opts.RegistryClientKey,
+	}); err != nil {
+		return fmt.Errorf("failed to configure target registry: %w", err)
+	}
+
+	componentVersion, err := s.registryService.LookupComponentVersion(name, version, opts.Insecure, credentials,
+		opts.RegistryURL)
+	if err != nil {
+		return fmt.Errorf("failed to get component version: %w", err)
+	}
+	defer componentVersion.Close()
+
+	opts.Out.Write(fmt.Sprintf("- Verifying signature %s of component %s in version %s\n", opts.SignatureName,
+		name, version))
+	if err = s.signatureService.VerifyComponentVersion(componentVersion, opts.SignatureName,
+		opts.PublicKeyFile); err != nil {
+		return fmt.Errorf("failed to verify component version: %w", err)
+	}
+	return nil
+}
+
+// verifyModuleTemplate verifies the signature of the descriptor rendered into the ModuleTemplate.
+func (s *Service) verifyModuleTemplate(opts Options) error {
+	content, err := s.fileSystem.ReadFile(opts.ModuleTemplateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read module template: %w", err)
+	}
+
+	moduleTemplate := &v1beta2.ModuleTemplate{}
+	if err = yaml.Unmarshal(content, moduleTemplate); err != nil {
+		return fmt.Errorf("failed to parse module template: %w", err)
+	}
+
+	raw := moduleTemplate.Spec.Descriptor.Raw
+	if len(raw) == 0 || string(raw) == "{}" {
+		return fmt.Errorf("%s: %w", opts.ModuleTemplateFile, ErrNoDescriptor)
+	}
+
+	descriptor, err := compdesc.Decode(raw)
+	if err != nil {
+		return fmt.Errorf("failed to decode component descriptor of module template: %w", err)
+	}
+
+	opts.Out.Write(fmt.Sprintf("- Verifying signature %s of component %s in version %s\n", opts.SignatureName,
+		descriptor.GetName(), descriptor.GetVersion()))
+	if err = s.signatureService.VerifyDescriptor(descriptor, opts.SignatureName, opts.PublicKeyFile); err != nil {
+		return fmt.Errorf("failed to verify module template: %w", err)
+	}
+	return nil
+}
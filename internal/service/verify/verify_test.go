@@ -0,0 +1,244 @@
+package verify_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/cpi"
+	"sigs.k8s.io/yaml"
+
+	commonerrors "github.com/kyma-project/modulectl/internal/common/errors"
+	"github.com/kyma-project/modulectl/internal/common/types"
+	"github.com/kyma-project/modulectl/internal/service/credential"
+	"github.com/kyma-project/modulectl/internal/service/signature"
+	"github.com/kyma-project/modulectl/internal/service/verify"
+	"github.com/kyma-project/modulectl/internal/testutils"
+	iotools "github.com/kyma-project/modulectl/tools/io"
+)
+
+const (
+	moduleName    = "kyma-project.io/module/template-operator"
+	moduleVersion = "1.0.0"
+)
+
+func Test_NewService_ReturnsError_WhenRegistryServiceIsNil(t *testing.T) {
+	_, err := verify.NewService(nil, &credentialServiceStub{}, &signatureServiceStub{}, &fileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "registryService")
+}
+
+func Test_NewService_ReturnsError_WhenSignatureServiceIsNil(t *testing.T) {
+	_, err := verify.NewService(&registryServiceStub{}, &credentialServiceStub{}, nil, &fileSystemStub{})
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidArg)
+	require.Contains(t, err.Error(), "signatureService")
+}
+
+func Test_Run_ReturnsError_WhenComponentAndModuleTemplateAreSet(t *testing.T) {
+	svc := newService(t, &registryServiceStub{}, &signatureServiceStub{}, &fileSystemStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *verify.Options) {
+		opts.ModuleTemplateFile = "template.yaml"
+	}))
+
+	require.ErrorIs(t, err, commonerrors.ErrInvalidOption)
+	require.Contains(t, err.Error(), "either opts.Component or opts.ModuleTemplateFile")
+}
+
+func Test_Run_VerifiesPublishedComponentVersion(t *testing.T) {
+	registryService := &registryServiceStub{}
+	signatureService := &signatureServiceStub{}
+	svc := newService(t, registryService, signatureService, &fileSystemStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.NoError(t, err)
+	assert.Equal(t, moduleName, registryService.name)
+	assert.Equal(t, moduleVersion, registryService.version)
+	assert.Equal(t, "user:password", registryService.credentials)
+	assert.True(t, registryService.componentVersion.closed)
+	assert.Equal(t, signature.DefaultName, signatureService.name)
+	assert.Equal(t, "public.pem", signatureService.publicKeyFile)
+	assert.Contains(t, out.String(), "Signature kyma-module is valid")
+}
+
+func Test_Run_ReturnsError_WhenSignatureOfComponentVersionIsInvalid(t *testing.T) {
+	signatureService := &signatureServiceStub{err: signature.ErrVerificationFailed}
+	svc := newService(t, &registryServiceStub{}, signatureService, &fileSystemStub{})
+	out := &bytes.Buffer{}
+
+	err := svc.Run(newOptions(out))
+
+	require.ErrorIs(t, err, signature.ErrVerificationFailed)
+	require.ErrorContains(t, err, "failed to verify component version")
+	assert.NotContains(t, out.String(), "is valid")
+}
+
+func Test_Run_ReturnsError_WhenComponentVersionDoesNotExist(t *testing.T) {
+	svc := newService(t, &registryServiceStub{err: errors.New("not found")}, &signatureServiceStub{},
+		&fileSystemStub{})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}))
+
+	require.ErrorContains(t, err, "failed to get component version: not found")
+}
+
+func Test_Run_VerifiesDescriptorOfModuleTemplate(t *testing.T) {
+	signatureService := &signatureServiceStub{}
+	fileSystem := &fileSystemStub{content: moduleTemplate(t, testutils.CreateComponentDescriptor(moduleName,
+		moduleVersion))}
+	registryService := &registryServiceStub{}
+	svc := newService(t, registryService, signatureService, fileSystem)
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *verify.Options) {
+		opts.Component = ""
+		opts.RegistryURL = ""
+		opts.ModuleTemplateFile = "template.yaml"
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, "template.yaml", fileSystem.path)
+	require.NotNil(t, signatureService.descriptor)
+	assert.Equal(t, moduleName, signatureService.descriptor.GetName())
+	assert.Equal(t, moduleVersion, signatureService.descriptor.GetVersion())
+	assert.Empty(t, registryService.name)
+}
+
+func Test_Run_ReturnsError_WhenModuleTemplateHasNoDescriptor(t *testing.T) {
+	svc := newService(t, &registryServiceStub{}, &signatureServiceStub{},
+		&fileSystemStub{content: moduleTemplate(t, nil)})
+
+	err := svc.Run(newOptions(&bytes.Buffer{}, func(opts *verify.Options) {
+		opts.Component = ""
+		opts.ModuleTemplateFile = "template.yaml"
+	}))
+
+	require.ErrorIs(t, err, verify.ErrNoDescriptor)
+}
+
+func newService(t *testing.T, registryService verify.RegistryService, signatureService verify.SignatureService,
+	fileSystem verify.FileSystem,
+) *verify.Service {
+	t.Helper()
+	svc, err := verify.NewService(registryService, &credentialServiceStub{}, signatureService, fileSystem)
+	require.NoError(t, err)
+	return svc
+}
+
+func newOptions(out *bytes.Buffer, modifiers ...func(opts *verify.Options)) verify.Options {
+	opts := verify.Options{
+		Out:           iotools.NewDefaultOut(out),
+		Component:     moduleName + ":" + moduleVersion,
+		PublicKeyFile: "public.pem",
+		SignatureName: signature.DefaultName,
+		RegistryAccess: types.RegistryAccess{
+			RegistryURL: "https://registry.kyma.cx",
+			Credentials: "user:password",
+		},
+	}
+	for _, modify := range modifiers {
+		modify(&opts)
+	}
+	return opts
+}
+
+func moduleTemplate(t *testing.T, descriptor *compdesc.ComponentDescriptor) []byte {
+	t.Helper()
+	raw := []byte("{}")
+	if descriptor != nil {
+		converted, err := compdesc.Convert(descriptor)
+		require.NoError(t, err)
+		raw, err = json.Marshal(converted)
+		require.NoError(t, err)
+	}
+
+	content, err := yaml.Marshal(&v1beta2.ModuleTemplate{
+		Spec: v1beta2.ModuleTemplateSpec{
+			ModuleName: "template-operator",
+			Version:    moduleVersion,
+			Descriptor: runtime.RawExtension{Raw: raw},
+		},
+	})
+	require.NoError(t, err)
+	return content
+}
+
+// Test Stubs
+
+type componentVersionStub struct {
+	cpi.ComponentVersionAccess
+
+	closed bool
+}
+
+func (s *componentVersionStub) Close() error {
+	s.closed = true
+	return nil
+}
+
+type registryServiceStub struct {
+	componentVersion *componentVersionStub
+	err              error
+	name             string
+	version          string
+	credentials      string
+}
+
+func (s *registryServiceStub) LookupComponentVersion(name, version string, _ bool, credentials, _ string,
+) (cpi.ComponentVersionAccess, error) {
+	s.name, s.version, s.credentials = name, version, credentials
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.componentVersion = &componentVersionStub{}
+	return s.componentVersion, nil
+}
+
+type credentialServiceStub struct{}
+
+func (*credentialServiceStub) UserPasswordCredentials(flagCredentials, _ string) (string, error) {
+	return flagCredentials, nil
+}
+
+func (*credentialServiceStub) ConfigureTargetRegistry(_ credential.TargetRegistryConfig) error {
+	return nil
+}
+
+type signatureServiceStub struct {
+	err           error
+	name          string
+	publicKeyFile string
+	descriptor    *compdesc.ComponentDescriptor
+}
+
+func (s *signatureServiceStub) VerifyComponentVersion(_ cpi.ComponentVersionAccess, name, publicKeyFile string,
+) error {
+	s.name, s.publicKeyFile = name, publicKeyFile
+	return s.err
+}
+
+func (s *signatureServiceStub) VerifyDescriptor(descriptor *compdesc.ComponentDescriptor,
+	name, publicKeyFile string,
+) error {
+	s.descriptor, s.name, s.publicKeyFile = descriptor, name, publicKeyFile
+	return s.err
+}
+
+type fileSystemStub struct {
+	content []byte
+	path    string
+}
+
+func (s *fileSystemStub) ReadFile(path string) ([]byte, error) {
+	s.path = path
+	return s.content, nil
+}
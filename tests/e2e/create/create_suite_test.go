@@ -38,7 +38,12 @@ const (
 	iconsWithoutName           = invalidConfigs + "icons-without-name.yaml"
 	invalidSecurityConfig      = invalidConfigs + "not-existing-security.yaml"
 	invalidSecurityConfigImage = invalidConfigs + "with-security.yaml"
+	staleSecurityConfigImages  = invalidConfigs + "with-stale-security.yaml"
 	withManifestLatestMainTags = invalidConfigs + "with-manifest-image-latest-or-main-tags.yaml"
+	unknownFieldConfig         = invalidConfigs + "unknown-field.yaml"
+	defaultCRWithoutCRDConfig  = invalidConfigs + "defaultcr-without-crd.yaml"
+	overriddenLabelConfig      = invalidConfigs + "overridden-label.yaml"
+	reservedAnnotationConfig   = invalidConfigs + "reserved-annotation.yaml"
 
 	validConfigs                  = testdataDir + "valid/"
 	minimalConfig                 = validConfigs + "minimal.yaml"
@@ -61,10 +66,19 @@ const (
 	withManifestShaDigest         = validConfigs + "with-manifest-sha-digest.yaml"
 	withManifestAndSecurity       = validConfigs + "with-manifest-and-security.yaml"
 	withManifestNoImages          = validConfigs + "with-manifest-no-deployment-statefulset.yaml"
+	withManifestWorkloads         = validConfigs + "with-manifest-workloads.yaml"
+	withChart                     = validConfigs + "with-chart.yaml"
+	withKustomization             = validConfigs + "with-kustomization.yaml"
+	withManifestList              = validConfigs + "with-manifest-list.yaml"
 	withSecurityScanDisabled      = validConfigs + "with-securityScanEnabled-false.yaml"
 	withSecurityScanEnabled       = validConfigs + "with-securityScanEnabled-true.yaml"
+	withSourceRegistryImage       = validConfigs + "with-source-registry-image.yaml"
+	withLocalManifest             = validConfigs + "with-local-manifest.yaml"
+
+	registryTargetsFile = "./testdata/registry-targets/targets.yaml"
 
 	ociRegistry          = "http://k3d-oci.localhost:5001"
+	sourceOciRegistry    = "k3d-source-oci.localhost:5003"
 	templateOutputPath   = "/tmp/template.yaml"
 	privateOciRegistry   = "http://k3d-private-oci.localhost:5002"
 	ociRegistryCreds     = "k3duser:k3dpass"
@@ -84,10 +98,19 @@ type createCmd struct {
 	dryRun                    bool
 	skipVersionValidation     bool
 	disableOCMRegistryPush    bool
+	copyResources             bool
+	reproducible              bool
+	registryTargetsFile       string
 	outputConstructorFile     string
+	allowUnknownFields        bool
 }
 
 func (cmd *createCmd) execute() error {
+	_, err := cmd.executeWithOutput()
+	return err
+}
+
+func (cmd *createCmd) executeWithOutput() (string, error) {
 	var command *exec.Cmd
 
 	args := []string{"create"}
@@ -132,6 +155,22 @@ func (cmd *createCmd) execute() error {
 		args = append(args, "--disable-ocm-registry-push")
 	}
 
+	if cmd.copyResources {
+		args = append(args, "--copy-resources")
+	}
+
+	if cmd.reproducible {
+		args = append(args, "--reproducible")
+	}
+
+	if cmd.registryTargetsFile != "" {
+		args = append(args, "--registry-targets-file="+cmd.registryTargetsFile)
+	}
+
+	if cmd.allowUnknownFields {
+		args = append(args, "--allow-unknown-fields")
+	}
+
 	if cmd.outputConstructorFile != "" {
 		args = append(args, "--output-constructor-file="+cmd.outputConstructorFile)
 	}
@@ -141,7 +180,7 @@ func (cmd *createCmd) execute() error {
 	command = exec.Command("modulectl", args...)
 	cmdOut, err := command.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("create command failed with output: %s and error: %w", cmdOut, err)
+		return "", fmt.Errorf("create command failed with output: %s and error: %w", cmdOut, err)
 	}
-	return nil
+	return string(cmdOut), nil
 }
@@ -3,10 +3,12 @@
 package create_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/kyma-project/lifecycle-manager/api/shared"
 	"github.com/kyma-project/lifecycle-manager/api/v1beta2"
@@ -92,7 +94,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		By("Then the command should fail", func() {
 			err := cmd.execute()
 			Expect(err).Should(HaveOccurred())
-			Expect(err.Error()).Should(ContainSubstring("opts.ModuleName must not be empty: invalid Option"))
+			Expect(err.Error()).Should(ContainSubstring("- name: must not be empty: invalid Option"))
 		})
 	})
 
@@ -108,7 +110,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		By("Then the command should fail", func() {
 			err := cmd.execute()
 			Expect(err).Should(HaveOccurred())
-			Expect(err.Error()).Should(ContainSubstring("opts.ModuleVersion must not be empty: invalid Option"))
+			Expect(err.Error()).Should(ContainSubstring("- version: must not be empty: invalid Option"))
 		})
 	})
 
@@ -126,7 +128,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate manifest: must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - manifest: must not be empty: invalid Option"))
 		})
 	})
 
@@ -144,7 +146,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate repository: must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - repository: must not be empty: invalid Option"))
 		})
 	})
 
@@ -162,7 +164,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate documentation: must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - documentation: must not be empty: invalid Option"))
 		})
 	})
 
@@ -180,7 +182,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate repository: 'http://github.com/kyma-project/template-operator' is not using https scheme: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - repository (line 4, column 13): 'http://github.com/kyma-project/template-operator' is not using https scheme: invalid Option"))
 		})
 	})
 
@@ -198,7 +200,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate documentation: 'http://github.com/kyma-project/template-operator/blob/main/README.md' is not using https scheme: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - documentation (line 5, column 16): 'http://github.com/kyma-project/template-operator/blob/main/README.md' is not using https scheme: invalid Option"))
 		})
 	})
 
@@ -216,7 +218,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate module icons: must contain at least one icon: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - icons: must contain at least one icon: invalid Option"))
 		})
 	})
 
@@ -252,7 +254,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate module icons: link must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - icons[module-icon] (line 7, column 5): link must not be empty: invalid Option"))
 		})
 	})
 
@@ -270,7 +272,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate module icons: name must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - icons[] (line 7, column 3): name must not be empty: invalid Option"))
 		})
 	})
 
@@ -306,7 +308,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate resources: failed to validate link: 'http://some.other/location/template-operator.yaml' is not using https scheme: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - resources[someResource] (line 10, column 5): failed to validate link: 'http://some.other/location/template-operator.yaml' is not using https scheme: invalid Option"))
 		})
 	})
 
@@ -324,7 +326,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate resources: link must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - resources[someResource] (line 10, column 5): link must not be empty: invalid Option"))
 		})
 	})
 
@@ -342,7 +344,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			Expect(err).Should(HaveOccurred())
 			Expect(
 				err.Error(),
-			).Should(ContainSubstring("failed to parse module config: failed to validate module config: failed to validate resources: name must not be empty: invalid Option"))
+			).Should(ContainSubstring("failed to parse module config: failed to validate module config: module config has 1 violation(s):\n  - resources[] (line 10, column 3): name must not be empty: invalid Option"))
 		})
 	})
 
@@ -381,6 +383,148 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		})
 	})
 
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with module-config referencing a non-existing security config", func() {
+			cmd = createCmd{
+				moduleConfigFile:          invalidSecurityConfig,
+				registry:                  ociRegistry,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("failed to parse security config"))
+			Expect(err.Error()).Should(ContainSubstring("security config file does not exist"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with security config without BDBA image matching the module version", func() {
+			cmd = createCmd{
+				moduleConfigFile:          invalidSecurityConfigImage,
+				registry:                  ociRegistry,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("failed to validate BDBA image tags"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with security config listing BDBA images not used in the manifest", func() {
+			cmd = createCmd{
+				moduleConfigFile:          staleSecurityConfigImages,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("BDBA images [europe-docker.pkg.dev/kyma-project/prod/template-operator:2.0.0] are not used in the manifest"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with module-config containing an unknown field", func() {
+			cmd = createCmd{
+				moduleConfigFile:          unknownFieldConfig,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("requiresDowntme (line 9, column 1): unknown field \"requiresDowntme\", did you mean \"requiresDowntime\"?"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with default CR whose CRD is not part of the manifest", func() {
+			cmd = createCmd{
+				moduleConfigFile:          defaultCRWithoutCRDConfig,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				dryRun:                    true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("failed to validate default CR: CRD of the default CR not found in the manifest"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with module-config overriding a label set by modulectl", func() {
+			cmd = createCmd{
+				moduleConfigFile:          overriddenLabelConfig,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				dryRun:                    true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("labels[operator.kyma-project.io/module-name] (line 10, column 41): label operator.kyma-project.io/module-name is set by modulectl and must not be overridden"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with module-config containing an annotation with a reserved key prefix", func() {
+			cmd = createCmd{
+				moduleConfigFile:          reservedAnnotationConfig,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				dryRun:                    true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should fail", func() {
+			err := cmd.execute()
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("failed to validate reserved keys: module config has 1 violation(s):\n  - annotations[provenance.kyma-project.io/git-commit]: key prefix \"provenance.kyma-project.io/\" is reserved"))
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with module-config containing an unknown field and allow-unknown-fields flag", func() {
+			cmd = createCmd{
+				moduleConfigFile:          unknownFieldConfig,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				dryRun:                    true,
+				moduleSourcesGitDirectory: templateOperatorPath,
+				allowUnknownFields:        true,
+			}
+		})
+		By("Then the command should succeed", func() {
+			Expect(cmd.execute()).To(Succeed())
+		})
+	})
+
 	It("Given 'modulectl create' command", func() {
 		var cmd createCmd
 		By("When invoked with minimal valid module-config and dry-run flag", func() {
@@ -496,15 +640,20 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 				moduleConfigFile:          minimalConfig,
 				registry:                  ociRegistry,
 				insecure:                  true,
+				output:                    templateOutputPath,
 				moduleSourcesGitDirectory: templateOperatorPath,
 			}
 		})
-		By("Then the command should fail with same version exists message", func() {
-			err := cmd.execute()
-			Expect(
-				err.Error(),
-			).Should(ContainSubstring(fmt.Sprintf("could not push component version: cannot push component version %s: component version already exists, cannot push the new version",
-				moduleVersion)))
+		By("Then the command should succeed, as the sources and the content are the same", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should be rendered from the existing component version", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor.GetVersion()).To(Equal(moduleVersion))
+				Expect(descriptor.RepositoryContexts).To(HaveLen(1))
+			})
 		})
 	})
 
@@ -530,12 +679,38 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 				moduleSourcesGitDirectory: templateOperatorPath,
 			}
 		})
-		By("Then the command should fail with same version exists message", func() {
-			err := cmd.execute()
-			Expect(
-				err.Error(),
-			).Should(ContainSubstring(fmt.Sprintf("component kyma-project.io/module/template-operator in version %s already exists: component version already exists",
-				moduleVersion)))
+		By("Then the command should succeed, as the existing component version is identical", func() {
+			Expect(cmd.execute()).To(Succeed())
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with reproducible flag and a module-config with local files only", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withLocalManifest,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				reproducible:              true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+			Expect(cmd.execute()).To(Succeed())
+		})
+		By("Then invoked again with the same inputs", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should be rendered from the existing component version", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor.GetVersion()).To(Equal("1.0.5"))
+				Expect(descriptor.RepositoryContexts).To(HaveLen(1))
+			})
+		})
+		By("Then invoked in dry-run mode with the same inputs", func() {
+			cmd.dryRun = true
+			Expect(cmd.execute()).To(Succeed())
 		})
 	})
 
@@ -674,7 +849,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 
 	It("Given 'modulectl create' command", func() {
 		var cmd createCmd
-		By("When invoked with valid module-config referencing security config (which is now ignored)",
+		By("When invoked with valid module-config referencing security config",
 			func() {
 				cmd = createCmd{
 					moduleConfigFile:          withSecurityConfig,
@@ -690,27 +865,26 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			By("And module template file should be generated")
 			Expect(filesIn("/tmp/")).Should(ContainElement("template.yaml"))
 
-			By("And the module template should contain only images from manifest (security config is ignored)", func() {
+			By("And the module template should contain only images from manifest", func() {
 				template, err := readModuleTemplate(templateOutputPath)
 				Expect(err).ToNot(HaveOccurred())
 				descriptor := getDescriptor(template)
 				Expect(descriptor).ToNot(BeNil())
 
-				By("And descriptor.component.resources should only contain manifest images")
-				// The security config previously added template-operator:2.0.0, which should no longer be present
-				imageResources := getImageResourcesMap(descriptor)
-				// Should only have the module image from the manifest (template-operator:1.0.3), NOT template-operator:2.0.0 from security config
-				Expect(len(imageResources)).To(BeNumerically(">=", 1), "Should have at least the module image from manifest")
-
-				// Verify the image from the remote manifest is present
 				resource := findResourceByNameVersionType(descriptor.Resources, "template-operator", moduleVersion,
 					"ociArtifact")
 				Expect(resource).ToNot(BeNil())
 
-				// Verify that template-operator:2.0.0 from security config is NOT present
-				resource = findResourceByNameVersionType(descriptor.Resources, "template-operator", "2.0.0",
-					"ociArtifact")
-				Expect(resource).To(BeNil(), "template-operator:2.0.0 from security config should not be present")
+				By("And descriptor.component.sources should contain the security scan labels")
+				Expect(descriptor.Sources).To(HaveLen(1))
+				labelsMap := flatten(descriptor.Sources[0].Labels)
+				Expect(labelsMap).To(HaveKeyWithValue("scan.security.kyma-project.io/rc-tag", "1.0.3"))
+				Expect(labelsMap).To(HaveKeyWithValue("scan.security.kyma-project.io/dev-branch", "main"))
+				Expect(labelsMap).To(HaveKeyWithValue("scan.security.kyma-project.io/language", "golang-mod"))
+				Expect(labelsMap).To(HaveKeyWithValue("scan.security.kyma-project.io/exclude",
+					"**/test/**,**/*_test.go"))
+				Expect(labelsMap).To(HaveKeyWithValue("scan.security.kyma-project.io/bdba",
+					"europe-docker.pkg.dev/kyma-project/prod/template-operator:1.0.3"))
 			})
 		})
 	})
@@ -1052,7 +1226,7 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 			By("And module template file should be generated")
 			Expect(filesIn("/tmp/")).Should(ContainElement("template.yaml"))
 
-			By("And the module template should contain images from manifest only (not from security config)", func() {
+			By("And the module template should contain images from manifest only", func() {
 				template, err := readModuleTemplate(templateOutputPath)
 				Expect(err).ToNot(HaveOccurred())
 				descriptor := getDescriptor(template)
@@ -1135,6 +1309,172 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		})
 	})
 
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with valid module-config containing images in workloads and image locations", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withManifestWorkloads,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should succeed and extract images from all workloads", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should contain images from the DaemonSet, CronJob and custom resource", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+
+				imageResources := getImageResourcesMap(descriptor)
+
+				expectedImages := map[string]struct {
+					reference string
+					version   string
+				}{
+					"template-operator": {"europe-docker.pkg.dev/kyma-project/prod/template-operator:1.0.3", "1.0.3"},
+					"fluent-bit":        {"fluent/fluent-bit:3.1.9", "3.1.9"},
+					"busybox":           {"busybox:1.36.1", "1.36.1"},
+					"nginx":             {"nginx:1.27.2", "1.27.2"},
+				}
+
+				Expect(len(imageResources)).To(Equal(len(expectedImages)), "Expected exactly %d image resources",
+					len(expectedImages))
+
+				for imageName, expected := range expectedImages {
+					err := verifyImageResource(imageResources, imageName, expected.reference, expected.version)
+					Expect(err).ToNot(HaveOccurred(), "Failed verification for image: %s", imageName)
+				}
+			})
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with valid module-config referencing a Helm chart", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withChart,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should succeed and render the chart as the manifest", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should contain the images of the rendered chart", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+
+				imageResources := getImageResourcesMap(descriptor)
+
+				expectedImages := map[string]struct {
+					reference string
+					version   string
+				}{
+					"template-operator": {"europe-docker.pkg.dev/kyma-project/prod/template-operator:1.0.3", "1.0.3"},
+					"nginx":             {"nginx:1.27.2", "1.27.2"},
+				}
+
+				Expect(len(imageResources)).To(Equal(len(expectedImages)), "Expected exactly %d image resources",
+					len(expectedImages))
+
+				for imageName, expected := range expectedImages {
+					err := verifyImageResource(imageResources, imageName, expected.reference, expected.version)
+					Expect(err).ToNot(HaveOccurred(), "Failed verification for image: %s", imageName)
+				}
+			})
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with valid module-config referencing a Helm chart and overwrite flag", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withChart,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				overwrite:                 true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+			Expect(cmd.execute()).To(Succeed())
+		})
+		By("Then invoked again without overwrite flag, rendering the chart to another temporary file", func() {
+			cmd.overwrite = false
+			output, err := cmd.executeWithOutput()
+			Expect(err).ToNot(HaveOccurred())
+
+			By("And the existing component version should have identical content", func() {
+				Expect(output).To(ContainSubstring("already exists with identical content"))
+			})
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with valid module-config referencing a kustomization overlay", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withKustomization,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should succeed and build the overlay as the manifest", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should contain the image set by the overlay", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+
+				imageResources := getImageResourcesMap(descriptor)
+				Expect(imageResources).To(HaveLen(1))
+				err = verifyImageResource(imageResources, "template-operator",
+					"europe-docker.pkg.dev/kyma-project/prod/template-operator:1.0.3", "1.0.3")
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with valid module-config referencing a list of manifest directories and globs", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withManifestList,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+			}
+		})
+		By("Then the command should succeed and concatenate the files as the manifest", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should contain the image of the concatenated manifest", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+
+				imageResources := getImageResourcesMap(descriptor)
+				Expect(imageResources).To(HaveLen(1))
+				err = verifyImageResource(imageResources, "template-operator",
+					"europe-docker.pkg.dev/kyma-project/prod/template-operator:1.0.3", "1.0.3")
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
 	It("Given 'modulectl create' command", func() {
 		var cmd createCmd
 		By("When invoked with valid module-config containing images in initContainers", func() {
@@ -1275,6 +1615,102 @@ var _ = Describe("Test 'create' command", Ordered, func() {
 		})
 	})
 
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with a registry targets file", func() {
+			cmd = createCmd{
+				moduleConfigFile:          minimalConfig,
+				registryTargetsFile:       registryTargetsFile,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+				overwrite:                 true,
+			}
+		})
+		By("Then the command should succeed", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the module template should be rendered from the primary target", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+				Expect(descriptor.GetEffectiveRepositoryContext().Object["baseUrl"]).To(Equal(ociRegistry))
+			})
+
+			By("And the component version should be pushed to the mirror target", func() {
+				outputDir := GinkgoT().TempDir()
+				pull := exec.Command("modulectl", "pull", "kyma-project.io/module/template-operator:"+moduleVersion,
+					"--registry=http://"+sourceOciRegistry, "--insecure", "--output-dir="+outputDir)
+				output, err := pull.CombinedOutput()
+				Expect(err).ToNot(HaveOccurred(), string(output))
+			})
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked with --copy-resources and an image of another registry", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withSourceRegistryImage,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+				overwrite:                 true,
+				copyResources:             true,
+			}
+		})
+		By("Then the command should succeed", func() {
+			Expect(cmd.execute()).To(Succeed())
+
+			By("And the image resource should only point to the target registry", func() {
+				template, err := readModuleTemplate(templateOutputPath)
+				Expect(err).ToNot(HaveOccurred())
+				descriptor := getDescriptor(template)
+				Expect(descriptor).ToNot(BeNil())
+
+				resource := findResourceByName(descriptor.Resources, "busybox")
+				Expect(resource).ToNot(BeNil())
+				Expect(resource.Version).To(Equal("1.36.1"))
+				access, err := json.Marshal(resource.Access)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(access)).ToNot(ContainSubstring(sourceOciRegistry))
+				Expect(string(access)).To(ContainSubstring(strings.TrimPrefix(ociRegistry, "http://")))
+			})
+		})
+		By("Then invoked again with the same inputs without the overwrite flag", func() {
+			cmd.overwrite = false
+		})
+		By("Then the command should succeed, as the copied image has the digest of the source image", func() {
+			Expect(cmd.execute()).To(Succeed())
+		})
+	})
+
+	It("Given 'modulectl create' command", func() {
+		var cmd createCmd
+		By("When invoked without --copy-resources and an image of another registry", func() {
+			cmd = createCmd{
+				moduleConfigFile:          withSourceRegistryImage,
+				registry:                  ociRegistry,
+				insecure:                  true,
+				output:                    templateOutputPath,
+				moduleSourcesGitDirectory: templateOperatorPath,
+				overwrite:                 true,
+			}
+			Expect(cmd.execute()).To(Succeed())
+		})
+		By("Then invoked again with --copy-resources and without the overwrite flag", func() {
+			cmd.overwrite = false
+			cmd.copyResources = true
+		})
+		By("Then the command should fail, as the existing image resource references the source registry", func() {
+			err := cmd.execute()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("already exists with different content"))
+			Expect(err.Error()).Should(ContainSubstring("resource busybox"))
+		})
+	})
+
 	It("Given 'modulectl create' command", func() {
 		var cmd createCmd
 		constructorFilePath := "/tmp/component-constructor.yaml"
@@ -1572,6 +2008,12 @@ func validateMinimalModuleTemplate(template *v1beta2.ModuleTemplate, descriptor
 	Expect(githubAccessSpec.RepoURL).To(Equal("https://github.com/kyma-project/template-operator"))
 	Expect(githubAccessSpec.Commit).To(Not(BeEmpty()))
 
+	By("And the build provenance should be stamped into the annotations and component labels")
+	Expect(annotations).To(HaveKeyWithValue("provenance.kyma-project.io/git-commit", githubAccessSpec.Commit))
+	Expect(annotations).To(HaveKey("provenance.kyma-project.io/raw-manifest-digest"))
+	Expect(flatten(descriptor.Labels)).To(
+		HaveKeyWithValue("provenance.kyma-project.io/git-commit", githubAccessSpec.Commit))
+
 	By("And spec.associatedResources should be empty")
 	Expect(template.Spec.AssociatedResources).To(BeEmpty())
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
@@ -61,7 +62,12 @@ func (s *ArchiveFileSystem) GetArchiveFileSystem() vfs.FileSystem {
 
 type TarData = []byte
 
-func (s *ArchiveFileSystem) ArchiveFile(filePath string) (TarData, error) {
+const archivedFileMode = 0o644
+
+// ArchiveFile returns a tar archive holding the file under the given entry name.
+// The header is normalized to the entry name, the file's size and a fixed mode, owner and modification time,
+// so that archiving the same content always produces the same bytes, wherever the file is stored.
+func (s *ArchiveFileSystem) ArchiveFile(filePath, entryName string) (TarData, error) {
 	fileInfo, err := s.osFileSystem.Stat(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get file info for %q: %w", filePath, err)
@@ -77,6 +83,8 @@ func (s *ArchiveFileSystem) ArchiveFile(filePath string) (TarData, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to create header for file %q: %w", filePath, err)
 	}
+	normalizeHeader(header, entryName)
+
 	outputBuffer := bytes.Buffer{}
 	tarWriter := tar.NewWriter(&outputBuffer)
 
@@ -98,3 +106,16 @@ func (s *ArchiveFileSystem) ArchiveFile(filePath string) (TarData, error) {
 	}
 	return outputBuffer.Bytes(), nil
 }
+
+func normalizeHeader(header *tar.Header, entryName string) {
+	header.Name = entryName
+	header.Mode = archivedFileMode
+	header.ModTime = time.Unix(0, 0)
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid = 0
+	header.Gid = 0
+	header.Uname = ""
+	header.Gname = ""
+	header.Format = tar.FormatUSTAR
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/mandelsoft/vfs/pkg/memoryfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
@@ -38,7 +39,7 @@ func TestGenerateTarArchive(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		tarData, err := afs.ArchiveFile("test/path/file.txt")
+		tarData, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
 		require.NoError(t, err)
 
 		// then verify the tar archive is created correctly, including the padding etc.
@@ -56,6 +57,62 @@ func TestGenerateTarArchive(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, expectedData, data)
 	})
+	t.Run("should generate the same tar data regardless of the file metadata", func(t *testing.T) {
+		// given
+		mockFs := memoryfs.New()
+		err := mockFs.MkdirAll("test/path", 0o755)
+		require.NoError(t, err)
+		err = vfs.WriteFile(mockFs, "test/path/file.txt", []byte("content"), 0o600)
+		require.NoError(t, err)
+
+		afs, err := filesystem.NewArchiveFileSystem(memoryfs.New(), mockFs)
+		require.NoError(t, err)
+
+		// when
+		first, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
+		require.NoError(t, err)
+		modTime := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+		err = mockFs.Chtimes("test/path/file.txt", modTime, modTime)
+		require.NoError(t, err)
+		err = mockFs.Chmod("test/path/file.txt", 0o755)
+		require.NoError(t, err)
+		second, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, first, second)
+		header, err := tar.NewReader(bytes.NewReader(second)).Next()
+		require.NoError(t, err)
+		assert.Equal(t, time.Unix(0, 0), header.ModTime)
+		assert.Equal(t, int64(0o644), header.Mode)
+		assert.Zero(t, header.Uid)
+		assert.Empty(t, header.Uname)
+	})
+	t.Run("should generate the same tar data regardless of the file path", func(t *testing.T) {
+		// given
+		mockFs := memoryfs.New()
+		err := mockFs.MkdirAll("tmp", 0o755)
+		require.NoError(t, err)
+		err = vfs.WriteFile(mockFs, "tmp/kyma-module-manifest-1234.yaml", []byte("content"), 0o600)
+		require.NoError(t, err)
+		err = vfs.WriteFile(mockFs, "tmp/kyma-module-manifest-5678.yaml", []byte("content"), 0o600)
+		require.NoError(t, err)
+
+		afs, err := filesystem.NewArchiveFileSystem(memoryfs.New(), mockFs)
+		require.NoError(t, err)
+
+		// when
+		first, err := afs.ArchiveFile("tmp/kyma-module-manifest-1234.yaml", "raw-manifest.yaml")
+		require.NoError(t, err)
+		second, err := afs.ArchiveFile("tmp/kyma-module-manifest-5678.yaml", "raw-manifest.yaml")
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, first, second)
+		header, err := tar.NewReader(bytes.NewReader(second)).Next()
+		require.NoError(t, err)
+		assert.Equal(t, "raw-manifest.yaml", header.Name)
+	})
 	t.Run("should return an error on file not found", func(t *testing.T) {
 		// given
 		mockFs := memoryfs.New()
@@ -66,7 +123,7 @@ func TestGenerateTarArchive(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		tarData, err := afs.ArchiveFile("test/path/file.txt")
+		tarData, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
 		require.Error(t, err, "expected error when file does not exist")
 		require.ErrorContains(
 			t,
@@ -99,7 +156,7 @@ func TestGenerateTarArchive(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		tarData, err := afs.ArchiveFile("test/path/file.txt")
+		tarData, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
 		require.Error(t, err, "expected error when file does not exist")
 		require.ErrorContains(t, err, "unable to open file", "error should be specific enough to identify it's origin")
 		require.ErrorContains(
@@ -132,7 +189,7 @@ func TestGenerateTarArchive(t *testing.T) {
 		require.NoError(t, err)
 
 		// when
-		tarData, err := afs.ArchiveFile("test/path/file.txt")
+		tarData, err := afs.ArchiveFile("test/path/file.txt", "file.txt")
 		require.Error(t, err, "expected error when file does not exist")
 		require.ErrorContains(
 			t,
@@ -18,7 +18,18 @@ func (u *Helper) FileExists(path string) (bool, error) {
 	}
 }
 
-const perm = 0o600
+const (
+	perm    = 0o600
+	dirPerm = 0o755
+)
+
+func (u *Helper) MkdirAll(path string) error {
+	if err := os.MkdirAll(path, dirPerm); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", path, err)
+	}
+
+	return nil
+}
 
 func (u *Helper) WriteFile(path, content string) error {
 	if err := os.WriteFile(path, []byte(content), perm); err != nil {
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"time"
 )
 
@@ -41,6 +43,20 @@ func (fs *TempFileSystem) DownloadTempFile(dir, pattern string, url *url.URL) (s
 	return tmpFile.Name(), nil
 }
 
+// WriteTempFile writes the content to a new temp file that is removed by RemoveTempFiles.
+func (fs *TempFileSystem) WriteTempFile(dir, pattern string, content []byte) (string, error) {
+	tmpFile, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file with pattern %s: %w", pattern, err)
+	}
+	defer tmpFile.Close()
+	fs.files = append(fs.files, tmpFile)
+	if _, err := tmpFile.Write(content); err != nil {
+		return "", fmt.Errorf("failed to write to temp file %s: %w", tmpFile.Name(), err)
+	}
+	return tmpFile.Name(), nil
+}
+
 // FileExists checks if a file exists at the given filePath and is a regular file.
 func (fs *TempFileSystem) FileExists(filePath string) (bool, error) {
 	info, err := os.Stat(filePath)
@@ -53,6 +69,60 @@ func (fs *TempFileSystem) FileExists(filePath string) (bool, error) {
 	return info.Mode().IsRegular(), nil
 }
 
+// DirExists checks if a directory exists at the given dirPath.
+func (fs *TempFileSystem) DirExists(dirPath string) (bool, error) {
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat directory %s: %w", dirPath, err)
+	}
+	return info.IsDir(), nil
+}
+
+// ListFiles returns the regular files in the directory and its subdirectories in lexical order.
+func (fs *TempFileSystem) ListFiles(dirPath string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dirPath, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", dirPath, err)
+	}
+	return files, nil
+}
+
+// Glob returns the regular files matching the pattern in lexical order.
+func (fs *TempFileSystem) Glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match pattern %s: %w", pattern, err)
+	}
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+			files = append(files, match)
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func (fs *TempFileSystem) ReadFile(filePath string) ([]byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	return content, nil
+}
+
 func (fs *TempFileSystem) RemoveTempFiles() []error {
 	var errs []error
 	for _, file := range fs.files {
@@ -0,0 +1,92 @@
+package filesystem_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/modulectl/tools/filesystem"
+)
+
+func TestTempFileSystem_ListFiles_ReturnsFilesOfSubdirectoriesInLexicalOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "b.yaml")
+	writeTestFile(t, dir, "a/z.yaml")
+	writeTestFile(t, dir, "a.yaml")
+
+	files, err := filesystem.NewTempFileSystem().ListFiles(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a", "z.yaml"),
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.yaml"),
+	}, files)
+}
+
+func TestTempFileSystem_ListFiles_ReturnsError_WhenDirectoryDoesNotExist(t *testing.T) {
+	_, err := filesystem.NewTempFileSystem().ListFiles(filepath.Join(t.TempDir(), "missing"))
+
+	require.ErrorContains(t, err, "failed to walk directory")
+}
+
+func TestTempFileSystem_Glob_ReturnsMatchingRegularFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "b.yaml")
+	writeTestFile(t, dir, "a.yaml")
+	writeTestFile(t, dir, "c.json")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "d.yaml"), 0o755))
+
+	files, err := filesystem.NewTempFileSystem().Glob(filepath.Join(dir, "*.yaml"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}, files)
+}
+
+func TestTempFileSystem_Glob_ReturnsError_WhenPatternIsInvalid(t *testing.T) {
+	_, err := filesystem.NewTempFileSystem().Glob("[a-")
+
+	require.ErrorContains(t, err, "failed to match pattern")
+}
+
+func TestTempFileSystem_DirExists(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "a.yaml")
+	fileSystem := filesystem.NewTempFileSystem()
+
+	exists, err := fileSystem.DirExists(dir)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = fileSystem.DirExists(filepath.Join(dir, "a.yaml"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = fileSystem.DirExists(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTempFileSystem_WriteTempFile_IsRemovedByRemoveTempFiles(t *testing.T) {
+	fileSystem := filesystem.NewTempFileSystem()
+
+	filePath, err := fileSystem.WriteTempFile(t.TempDir(), "manifest-*.yaml", []byte("kind: Role\n"))
+	require.NoError(t, err)
+	content, err := fileSystem.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Role\n", string(content))
+
+	assert.Empty(t, fileSystem.RemoveTempFiles())
+	_, err = fileSystem.ReadFile(filePath)
+	require.ErrorContains(t, err, "failed to read file")
+}
+
+func writeTestFile(t *testing.T, dir, name string) {
+	t.Helper()
+	filePath := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	require.NoError(t, os.WriteFile(filePath, []byte("kind: ConfigMap\n"), 0o600))
+}
@@ -6,7 +6,6 @@ import (
 
 	mandelsofterrors "github.com/mandelsoft/goutils/errors"
 	"ocm.software/ocm/api/ocm/cpi"
-	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
 	"ocm.software/ocm/api/ocm/tools/transfer"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
 	"ocm.software/ocm/api/utils/misc"
@@ -21,7 +20,7 @@ type OCIRepo struct{}
 
 var errComponentVersionAlreadyExists = errors.New("component version already exists, cannot push the new version")
 
-func (o *OCIRepo) GetComponentVersion(archive *comparch.ComponentArchive,
+func (o *OCIRepo) GetComponentVersion(archive ComponentArchiveMeta,
 	repo cpi.Repository,
 ) (cpi.ComponentVersionAccess, error) {
 	version, err := repo.LookupComponentVersion(archive.GetName(), archive.GetVersion())
@@ -32,6 +31,21 @@ func (o *OCIRepo) GetComponentVersion(archive *comparch.ComponentArchive,
 	return version, nil
 }
 
+func (o *OCIRepo) ListComponentVersions(name string, repo cpi.Repository) ([]string, error) {
+	component, err := repo.LookupComponent(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component: %w", err)
+	}
+	defer component.Close()
+
+	versions, err := component.ListVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component versions: %w", err)
+	}
+
+	return versions, nil
+}
+
 func (o *OCIRepo) ExistsComponentVersion(archive ComponentArchiveMeta,
 	repo cpi.Repository,
 ) (bool, error) {
@@ -43,8 +57,11 @@ func (o *OCIRepo) ExistsComponentVersion(archive ComponentArchiveMeta,
 	return exists, nil
 }
 
-func (o *OCIRepo) PushComponentVersion(archive *comparch.ComponentArchive, repo cpi.Repository,
-	overwrite bool,
+// PushComponentVersion transfers the component version to the repository. If copyResources is set, the resources
+// are transferred by value: the referenced OCI artifacts are copied into the repository and the access specs of the
+// transferred descriptor point to the copies.
+func (o *OCIRepo) PushComponentVersion(archive cpi.ComponentVersionAccess, repo cpi.Repository,
+	overwrite, copyResources bool,
 ) error {
 	exists, _ := repo.ExistsComponentVersion(archive.GetName(), archive.GetVersion())
 	if exists && !overwrite {
@@ -52,7 +69,7 @@ func (o *OCIRepo) PushComponentVersion(archive *comparch.ComponentArchive, repo
 			archive.GetVersion(), errComponentVersionAlreadyExists)
 	}
 
-	transferHandler, err := standard.New(standard.Overwrite(overwrite))
+	transferHandler, err := standard.New(standard.Overwrite(overwrite), standard.ResourcesByValue(copyResources))
 	if err != nil {
 		return fmt.Errorf("failed to setup archive transfer: %w", err)
 	}